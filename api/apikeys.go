@@ -0,0 +1,162 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Prefixo das chaves geradas, que facilita identificá-las em varreduras de segredos
+const apiKeyPrefix = "lta_"
+
+// apiKeyRequest é o corpo da criação de uma API key
+type apiKeyRequest struct {
+	Name       string     `json:"name"`
+	Owner      string     `json:"owner"`
+	Scopes     []string   `json:"scopes"`
+	DailyQuota int64      `json:"dailyQuota"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+}
+
+// issuedAPIKey é a resposta da criação e da rotação, a única que traz o segredo
+type issuedAPIKey struct {
+	*models.APIKey
+	Key string `json:"key"`
+}
+
+// generateAPIKey gera uma nova chave e retorna também o prefixo e o hash gravados
+func generateAPIKey() (key, prefix, hash string, err error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", "", err
+	}
+	key = apiKeyPrefix + hex.EncodeToString(secret)
+	return key, key[:len(apiKeyPrefix)+8], hashAPIKey(key), nil
+}
+
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func CreateAPIKey(c *gin.Context) {
+	var req apiKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidBody(c, err)
+		return
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	req.Owner = strings.TrimSpace(req.Owner)
+	if req.Name == "" {
+		badRequest(c, "api_key_name_required")
+		return
+	}
+	if req.Owner == "" {
+		req.Owner = req.Name
+	}
+
+	if len(req.Scopes) == 0 {
+		respondError(c, newError(http.StatusBadRequest, "api_key_scopes_required").with("validScopes", models.Scopes))
+		return
+	}
+	for _, scope := range req.Scopes {
+		if !models.ValidScope(scope) {
+			respondError(c, newError(http.StatusBadRequest, "api_key_scope_unknown", scope).with("validScopes", models.Scopes))
+			return
+		}
+	}
+
+	if req.DailyQuota < 0 {
+		badRequest(c, "api_key_quota_invalid")
+		return
+	}
+
+	now := time.Now()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		badRequest(c, "api_key_expiry_invalid")
+		return
+	}
+
+	key, prefix, hash, err := generateAPIKey()
+	if err != nil {
+		respondError(c, newError(http.StatusInternalServerError, "api_key_generate_failed"))
+		return
+	}
+
+	apiKey := &models.APIKey{
+		ID:         primitive.NewObjectID(),
+		Name:       req.Name,
+		Owner:      req.Owner,
+		Prefix:     prefix,
+		Hash:       hash,
+		Scopes:     req.Scopes,
+		DailyQuota: req.DailyQuota,
+		ExpiresAt:  req.ExpiresAt,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := models.CreateAPIKey(apiKey); err != nil {
+		storeError(c, err, "api_key_create_failed")
+		return
+	}
+
+	// A chave é exibida apenas na criação e na rotação
+	c.JSON(http.StatusCreated, issuedAPIKey{APIKey: apiKey, Key: key})
+}
+
+func GetAPIKeys(c *gin.Context) {
+	keys, err := models.GetAPIKeys()
+	if err != nil {
+		storeError(c, err, "api_key_fetch_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"apiKeys": keys})
+}
+
+// RotateAPIKey gera um novo segredo para a chave; o anterior deixa de valer
+func RotateAPIKey(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		badRequest(c, "invalid_id")
+		return
+	}
+
+	key, prefix, hash, err := generateAPIKey()
+	if err != nil {
+		respondError(c, newError(http.StatusInternalServerError, "api_key_generate_failed"))
+		return
+	}
+
+	apiKey, err := models.RotateAPIKey(id, prefix, hash)
+	if err != nil {
+		lookupError(c, err, "api_key_not_found", "api_key_rotate_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, issuedAPIKey{APIKey: apiKey, Key: key})
+}
+
+// RevokeAPIKey revoga a chave; ela continua listada com revokedAt
+func RevokeAPIKey(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		badRequest(c, "invalid_id")
+		return
+	}
+
+	if err := models.RevokeAPIKey(id); err != nil {
+		lookupError(c, err, "api_key_not_found", "api_key_revoke_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "API key revogada com sucesso"})
+}
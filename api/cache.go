@@ -0,0 +1,202 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/cache"
+	"github.com/bulletdev/lta-results-api/config"
+	"github.com/bulletdev/lta-results-api/events"
+	"github.com/gin-gonic/gin"
+)
+
+// Respostas maiores que isso não são guardadas
+const maxCachedBody = 1 << 20
+
+// newResponseCache cria o cache configurado e o inscreve nos eventos de alteração das
+// partidas
+func newResponseCache(cfg config.Cache) cache.Store {
+	store, err := cache.NewStore(cfg.Backend, cfg.MaxEntries)
+	if err != nil {
+		log.Fatalf("Erro ao configurar o cache: %v", err)
+	}
+	cache.Watch(context.Background(), store, events.Default)
+	return store
+}
+
+// CacheResponse guarda as respostas JSON bem-sucedidas da rota, identificadas pelo
+// caminho, pela query normalizada e pelo Accept, e responde às requisições seguintes
+// sem chamar o handler. As entradas recebem as tags de tags e são invalidadas quando uma
+// alteração de partida atinge uma delas. Toda resposta guardada tem uma ETag forte e
+// If-None-Match com ela retorna 304. As entradas duram settings.TTL (0 desativa o
+// cache) e os clientes podem reutilizá-las por settings.MaxAge segundos.
+func CacheResponse(store cache.Store, settings config.Cache, tags func(c *gin.Context) []string) gin.HandlerFunc {
+	ttl, maxAge := settings.TTL.Duration, settings.MaxAge
+	if ttl == 0 {
+		log.Println("Cache de respostas desativado")
+		return func(c *gin.Context) { c.Next() }
+	}
+	cacheControl := "public, max-age=" + strconv.Itoa(maxAge)
+
+	return func(c *gin.Context) {
+		key := cacheKey(c)
+		entry, err := store.Get(c.Request.Context(), key)
+		if err != nil {
+			log.Printf("[%s] Erro ao ler o cache: %v", c.GetString(requestIDKey), err)
+		}
+		if entry != nil {
+			c.Header("X-Cache", "HIT")
+			serveCached(c, entry, cacheControl)
+			return
+		}
+
+		writer := &cachingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		if writer.passthrough {
+			return
+		}
+		if writer.Status() != http.StatusOK {
+			writer.flush()
+			return
+		}
+
+		entry = &cache.Entry{
+			Body:        writer.buf.Bytes(),
+			ContentType: writer.Header().Get("Content-Type"),
+			ETag:        strongETag(writer.buf.Bytes()),
+			Tags:        tags(c),
+			StoredAt:    time.Now(),
+		}
+		if err := store.Set(c.Request.Context(), key, entry, ttl); err != nil {
+			log.Printf("[%s] Erro ao gravar no cache: %v", c.GetString(requestIDKey), err)
+		}
+		c.Header("X-Cache", "MISS")
+		serveCached(c, entry, cacheControl)
+	}
+}
+
+// serveCached responde com a entrada ou com 304 se o cliente já tiver a mesma versão
+func serveCached(c *gin.Context, entry *cache.Entry, cacheControl string) {
+	c.Header("ETag", entry.ETag)
+	c.Header("Cache-Control", cacheControl)
+	c.Header("Vary", "Accept")
+
+	if etagMatches(c.GetHeader("If-None-Match"), entry.ETag) {
+		c.AbortWithStatus(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, entry.ContentType, entry.Body)
+	c.Abort()
+}
+
+// cacheKey identifica a requisição pelo caminho, pelos parâmetros não vazios em ordem
+// alfabética e pelo Accept, que também escolhe o formato da resposta
+func cacheKey(c *gin.Context) string {
+	query := url.Values{}
+	for name, values := range c.Request.URL.Query() {
+		for _, value := range values {
+			if value = strings.TrimSpace(value); value != "" {
+				query.Add(name, value)
+			}
+		}
+	}
+	// Encode ordena os parâmetros pelo nome
+	return c.Request.URL.Path + "?" + query.Encode() + "|" + c.GetHeader("Accept")
+}
+
+// strongETag deriva a ETag do conteúdo da resposta
+func strongETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches compara o If-None-Match com a ETag, aceitando listas e "*"
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// cachingWriter retém a resposta JSON do handler para guardá-la no cache. Respostas em
+// outros formatos, grandes demais ou enviadas em partes passam direto para o cliente.
+type cachingWriter struct {
+	gin.ResponseWriter
+	buf         bytes.Buffer
+	passthrough bool
+}
+
+func (w *cachingWriter) Write(data []byte) (int, error) {
+	if !w.passthrough && w.cacheable(len(data)) {
+		return w.buf.Write(data)
+	}
+	w.flush()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *cachingWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *cachingWriter) Flush() {
+	w.flush()
+	w.ResponseWriter.Flush()
+}
+
+// cacheable indica se a resposta ainda pode ser guardada após mais n bytes
+func (w *cachingWriter) cacheable(n int) bool {
+	return w.Status() == http.StatusOK &&
+		strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") &&
+		w.buf.Len()+n <= maxCachedBody
+}
+
+// flush envia o que foi retido e passa a escrever direto no cliente
+func (w *cachingWriter) flush() {
+	if w.passthrough {
+		return
+	}
+	w.passthrough = true
+	if w.buf.Len() > 0 {
+		w.ResponseWriter.Write(w.buf.Bytes())
+		w.buf.Reset()
+	} else {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+// resultsCacheTags marca as listagens pelos filtros de região e time
+func resultsCacheTags(c *gin.Context) []string {
+	var tags []string
+	if region := c.Query("region"); region != "" {
+		tags = append(tags, cache.RegionTag(region))
+	}
+	if team := c.Query("team"); team != "" {
+		tags = append(tags, cache.TeamTag(team))
+	}
+	if len(tags) == 0 {
+		tags = append(tags, cache.TagResults)
+	}
+	return tags
+}
+
+func playerCacheTags(c *gin.Context) []string {
+	return []string{cache.PlayerTag(c.Param("playerName"))}
+}
+
+func teamCacheTags(c *gin.Context) []string {
+	return []string{cache.TeamTag(c.Param("teamName"))}
+}
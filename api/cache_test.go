@@ -0,0 +1,97 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/cache"
+	"github.com/bulletdev/lta-results-api/config"
+	"github.com/gin-gonic/gin"
+)
+
+// newCachedRouter monta uma rota em cache que conta as chamadas ao handler e responde
+// com o status informado na query
+func newCachedRouter(store cache.Store) (*gin.Engine, *int) {
+	gin.SetMode(gin.TestMode)
+
+	calls := 0
+	settings := config.Cache{TTL: config.Duration{Duration: time.Minute}, MaxAge: 60}
+	router := gin.New()
+	router.GET("/teams/:teamName/stats", CacheResponse(store, settings, teamCacheTags), func(c *gin.Context) {
+		calls++
+		if c.Query("fail") != "" {
+			c.JSON(http.StatusInternalServerError, gin.H{"calls": calls})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"team": c.Param("teamName"), "calls": calls})
+	})
+	return router, &calls
+}
+
+func TestCacheResponse(t *testing.T) {
+	store := cache.NewMemoryStore(10)
+	router, calls := newCachedRouter(store)
+
+	first := request(router, "/teams/LOUD/stats?region=sul&season=")
+	if first.Code != http.StatusOK || first.Header().Get("X-Cache") != "MISS" {
+		t.Fatalf("primeira resposta = %d, X-Cache %s", first.Code, first.Header().Get("X-Cache"))
+	}
+	etag := first.Header().Get("ETag")
+	if etag == "" || first.Header().Get("Cache-Control") != "public, max-age=60" {
+		t.Errorf("headers de cache = ETag %q, Cache-Control %q", etag, first.Header().Get("Cache-Control"))
+	}
+
+	// Parâmetros vazios não mudam a chave
+	second := request(router, "/teams/LOUD/stats?region=sul")
+	if second.Header().Get("X-Cache") != "HIT" || second.Body.String() != first.Body.String() || *calls != 1 {
+		t.Errorf("segunda resposta = X-Cache %s, %d chamadas ao handler", second.Header().Get("X-Cache"), *calls)
+	}
+
+	// O cliente com a mesma versão recebe 304
+	if w := request(router, "/teams/LOUD/stats?region=sul", "If-None-Match", `W/"x", `+etag); w.Code != http.StatusNotModified {
+		t.Errorf("status com If-None-Match = %d, esperado 304", w.Code)
+	}
+
+	// Outro Accept é outra entrada
+	if w := request(router, "/teams/LOUD/stats?region=sul", "Accept", "text/csv"); w.Header().Get("X-Cache") != "MISS" {
+		t.Errorf("outro Accept respondido do cache")
+	}
+}
+
+func TestCacheResponseInvalidation(t *testing.T) {
+	store := cache.NewMemoryStore(10)
+	router, calls := newCachedRouter(store)
+
+	request(router, "/teams/LOUD/stats")
+	request(router, "/teams/RED/stats")
+
+	// A tag do time invalida apenas as respostas dele, sem diferenciar maiúsculas
+	if err := store.Invalidate(context.Background(), cache.TeamTag("loud")); err != nil {
+		t.Fatalf("erro ao invalidar: %v", err)
+	}
+	if w := request(router, "/teams/LOUD/stats"); w.Header().Get("X-Cache") != "MISS" {
+		t.Error("resposta invalidada servida do cache")
+	}
+	if w := request(router, "/teams/RED/stats"); w.Header().Get("X-Cache") != "HIT" {
+		t.Error("resposta de outro time invalidada")
+	}
+	if *calls != 3 {
+		t.Errorf("%d chamadas ao handler, esperado 3", *calls)
+	}
+}
+
+func TestCacheResponseSkipsErrors(t *testing.T) {
+	router, calls := newCachedRouter(cache.NewMemoryStore(10))
+
+	for i := 0; i < 2; i++ {
+		w := request(router, "/teams/LOUD/stats?fail=1")
+		if w.Code != http.StatusInternalServerError || w.Header().Get("X-Cache") != "" {
+			t.Fatalf("erro respondido com status %d e X-Cache %q", w.Code, w.Header().Get("X-Cache"))
+		}
+	}
+	if *calls != 2 {
+		t.Errorf("%d chamadas ao handler, esperado 2: erros não devem ser guardados", *calls)
+	}
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/bulletdev/lta-results-api/champions"
+	"github.com/bulletdev/lta-results-api/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func GetChampionSynergy(c *gin.Context) {
+	championMatrix(c, champions.Synergy)
+}
+
+func GetChampionCounters(c *gin.Context) {
+	championMatrix(c, champions.Counters)
+}
+
+// championMatrix lê os parâmetros comuns e responde com a matriz calculada por compute
+func championMatrix(c *gin.Context, compute func([]models.MatchResult, champions.Options) ([]champions.PairStats, error)) {
+	minGames, err := strconv.Atoi(c.DefaultQuery("minGames", "3"))
+	if err != nil || minGames < 1 {
+		badRequest(c, "min_games_invalid")
+		return
+	}
+
+	confidence, err := strconv.ParseFloat(c.DefaultQuery("confidence", "0.95"), 64)
+	if err != nil {
+		badRequest(c, "confidence_invalid")
+		return
+	}
+
+	filter := bson.M{}
+	if region := c.Query("region"); region != "" {
+		filter["region"] = region
+	}
+	if stage := c.Query("stage"); stage != "" {
+		filter["tournamentStage"] = stage
+	}
+
+	matches, err := models.GetAllMatchResults(filter)
+	if err != nil {
+		storeError(c, err, "results_fetch_failed")
+		return
+	}
+
+	opts := champions.Options{
+		Champion:   c.Query("champion"),
+		Position:   c.Query("position"),
+		MinGames:   minGames,
+		Confidence: confidence,
+	}
+
+	pairs, err := compute(matches, opts)
+	if err != nil {
+		badRequest(c, "confidence_invalid")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"minGames":   minGames,
+		"confidence": confidence,
+		"pairs":      pairs,
+	})
+}
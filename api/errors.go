@@ -0,0 +1,101 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/bulletdev/lta-results-api/database"
+	"github.com/bulletdev/lta-results-api/i18n"
+	"github.com/bulletdev/lta-results-api/validation"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ErrorResponse é o envelope de todas as respostas de erro da API
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+// ErrorBody descreve um erro. Code é estável e pode ser usado pelos clientes;
+// Message é traduzida conforme o Accept-Language da requisição.
+type ErrorBody struct {
+	Code      string                 `json:"code"`
+	Message   string                 `json:"message"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+	RequestID string                 `json:"requestId,omitempty"`
+}
+
+// apiError é um erro ainda não traduzido, com o status HTTP da resposta
+type apiError struct {
+	status  int
+	code    string
+	args    []interface{}
+	details map[string]interface{}
+}
+
+func newError(status int, code string, args ...interface{}) *apiError {
+	return &apiError{status: status, code: code, args: args}
+}
+
+// with acrescenta um detalhe ao erro
+func (e *apiError) with(key string, value interface{}) *apiError {
+	if e.details == nil {
+		e.details = make(map[string]interface{})
+	}
+	e.details[key] = value
+	return e
+}
+
+// respondError interrompe a requisição respondendo com o erro no idioma do cliente
+func respondError(c *gin.Context, e *apiError) {
+	lang := i18n.Negotiate(c.GetHeader("Accept-Language"))
+	c.Header("Content-Language", lang)
+	c.AbortWithStatusJSON(e.status, ErrorResponse{Error: ErrorBody{
+		Code:      e.code,
+		Message:   i18n.Translate(lang, e.code, e.args...),
+		Details:   e.details,
+		RequestID: c.GetString(requestIDKey),
+	}})
+}
+
+func badRequest(c *gin.Context, code string, args ...interface{}) {
+	respondError(c, newError(http.StatusBadRequest, code, args...))
+}
+
+func notFound(c *gin.Context, code string, args ...interface{}) {
+	respondError(c, newError(http.StatusNotFound, code, args...))
+}
+
+// invalidBody responde 400 com o motivo da falha ao ler o corpo da requisição
+func invalidBody(c *gin.Context, err error) {
+	respondError(c, newError(http.StatusBadRequest, "invalid_body").with("reason", err.Error()))
+}
+
+// invalidFields responde 422 com os erros de cada campo no idioma do cliente
+func invalidFields(c *gin.Context, errs validation.Errors) {
+	lang := i18n.Negotiate(c.GetHeader("Accept-Language"))
+	respondError(c, newError(http.StatusUnprocessableEntity, "validation_failed").with("fields", errs.Translate(lang)))
+}
+
+// storeError responde a uma falha do banco: 503 quando ele está indisponível
+// e 500 com o código informado nos demais casos
+func storeError(c *gin.Context, err error, code string) {
+	log.Printf("[%s] %s: %v", c.GetString(requestIDKey), code, err)
+
+	if database.IsUnavailable(err) {
+		respondError(c, newError(http.StatusServiceUnavailable, "service_unavailable"))
+		return
+	}
+	respondError(c, newError(http.StatusInternalServerError, code))
+}
+
+// lookupError responde a uma falha ao buscar um documento: 404 com notFoundCode
+// quando ele não existe e, nos demais casos, o mesmo que storeError
+func lookupError(c *gin.Context, err error, notFoundCode, failureCode string) {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		notFound(c, notFoundCode)
+		return
+	}
+	storeError(c, err, failureCode)
+}
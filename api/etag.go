@@ -0,0 +1,35 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/bulletdev/lta-results-api/models"
+	"github.com/gin-gonic/gin"
+)
+
+// matchETag retorna a ETag da versão atual de um resultado
+func matchETag(match *models.MatchResult) string {
+	return `"` + strconv.FormatInt(match.Version, 10) + `"`
+}
+
+// checkIfMatch compara o header If-Match com a versão atual do resultado,
+// respondendo 412 quando ele não corresponde. Sem o header, a escrita é aceita.
+func checkIfMatch(c *gin.Context, match *models.MatchResult) bool {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return true
+	}
+
+	// If-Match usa comparação forte, então ETags fracas (W/) nunca correspondem
+	current := matchETag(match)
+	for _, tag := range strings.Split(header, ",") {
+		if strings.TrimSpace(tag) == current {
+			return true
+		}
+	}
+
+	respondError(c, newError(http.StatusPreconditionFailed, "result_version_conflict").with("currentVersion", match.Version))
+	return false
+}
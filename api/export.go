@@ -0,0 +1,106 @@
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/bulletdev/lta-results-api/export"
+	"github.com/bulletdev/lta-results-api/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// negotiateFormat determina o formato da resposta, respondendo 406 se ele não for suportado
+func negotiateFormat(c *gin.Context) (string, bool) {
+	format := export.Negotiate(c.Query("format"), c.GetHeader("Accept"))
+	if format == "" {
+		respondError(c, newError(http.StatusNotAcceptable, "format_not_acceptable"))
+		return "", false
+	}
+	return format, true
+}
+
+// exportMatches transmite as partidas do filtro no formato solicitado. Em CSV e XLSX
+// cada linha é um jogador em uma partida; em NDJSON cada linha é uma partida. Quando
+// include é informado, apenas os jogadores aceitos por ele são exportados.
+func exportMatches(c *gin.Context, format, filename string, filter bson.M, findOptions *options.FindOptions, include func(models.Player) bool) {
+	filename = export.SafeName(filename)
+
+	// A consulta é aberta antes do status, para que uma falha do banco ainda vire um erro JSON
+	ctx := c.Request.Context()
+	cursor, err := models.OpenMatchResults(ctx, filter, findOptions)
+	if err != nil {
+		storeError(c, err, "results_fetch_failed")
+		return
+	}
+
+	w := c.Writer
+	w.Header().Set("Content-Type", export.ContentType(format))
+	if format != export.FormatNDJSON {
+		w.Header().Set("Content-Disposition", `attachment; filename="`+filename+"."+format+`"`)
+	}
+	w.WriteHeader(http.StatusOK)
+
+	var write func(*models.MatchResult) error
+	var closeWriter func() error
+
+	switch format {
+	case export.FormatNDJSON:
+		encoder := json.NewEncoder(w)
+		write = func(match *models.MatchResult) error {
+			if include != nil {
+				match.Players = filterPlayers(match.Players, include)
+			}
+			if err := encoder.Encode(match); err != nil {
+				return err
+			}
+			w.Flush()
+			return nil
+		}
+		closeWriter = func() error { return nil }
+
+	default:
+		var rows export.RowWriter
+		var err error
+		if format == export.FormatXLSX {
+			rows, err = export.NewXLSXWriter(w, filename)
+		} else {
+			rows, err = export.NewCSVWriter(w)
+		}
+		if err != nil {
+			log.Printf("Erro ao iniciar exportação %s: %v", format, err)
+			cursor.Close(ctx)
+			return
+		}
+		write = func(match *models.MatchResult) error {
+			for _, row := range export.Rows(match, include) {
+				if err := rows.Write(row); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		closeWriter = rows.Close
+	}
+
+	// O status já foi enviado, então erros durante o streaming apenas interrompem a resposta
+	if err := cursor.Each(ctx, write); err != nil {
+		log.Printf("Erro durante exportação %s: %v", format, err)
+		return
+	}
+	if err := closeWriter(); err != nil {
+		log.Printf("Erro ao finalizar exportação %s: %v", format, err)
+	}
+}
+
+func filterPlayers(players []models.Player, include func(models.Player) bool) []models.Player {
+	filtered := make([]models.Player, 0, len(players))
+	for _, player := range players {
+		if include(player) {
+			filtered = append(filtered, player)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,200 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/fantasy"
+	"github.com/bulletdev/lta-results-api/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func GetFantasyRulesets(c *gin.Context) {
+	rulesets, err := models.GetFantasyRulesets()
+	if err != nil {
+		storeError(c, err, "ruleset_fetch_failed")
+		return
+	}
+
+	// O ruleset padrão está sempre disponível, mesmo sem ter sido salvo
+	hasDefault := false
+	for _, ruleset := range rulesets {
+		if ruleset.Name == models.DefaultRulesetName {
+			hasDefault = true
+		}
+	}
+	if !hasDefault {
+		rulesets = append([]models.FantasyRuleset{*models.DefaultFantasyRuleset()}, rulesets...)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"rulesets": rulesets})
+}
+
+func GetFantasyRuleset(c *gin.Context) {
+	ruleset, ok := loadRuleset(c, c.Param("name"))
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, ruleset)
+}
+
+func GetFantasyMatchPoints(c *gin.Context) {
+	ruleset, ok := loadRuleset(c, c.DefaultQuery("ruleset", models.DefaultRulesetName))
+	if !ok {
+		return
+	}
+
+	match, err := models.GetMatchResultByID(c.Param("matchId"))
+	if err != nil {
+		lookupError(c, err, "result_not_found", "results_fetch_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"matchId": match.MatchID,
+		"ruleset": ruleset.Name,
+		"players": fantasy.ScoreMatch(ruleset, *match),
+	})
+}
+
+func GetFantasyPlayerPoints(c *gin.Context) {
+	playerName := c.Param("playerName")
+	ruleset, ok := loadRuleset(c, c.DefaultQuery("ruleset", models.DefaultRulesetName))
+	if !ok {
+		return
+	}
+
+	filter := fantasyFilter(c)
+	filter["players.name"] = playerName
+
+	matches, err := models.GetAllMatchResults(filter)
+	if err != nil {
+		storeError(c, err, "results_fetch_failed")
+		return
+	}
+
+	if len(matches) == 0 {
+		notFound(c, "player_not_found")
+		return
+	}
+
+	// Restringir os agregados ao jogador solicitado
+	var weeks []fantasy.Total
+	for _, total := range fantasy.Weekly(ruleset, matches, "") {
+		if total.Player == playerName {
+			weeks = append(weeks, total)
+		}
+	}
+	var season *fantasy.Total
+	for _, total := range fantasy.Season(ruleset, matches) {
+		if total.Player == playerName {
+			season = &total
+			break
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"player":  playerName,
+		"ruleset": ruleset.Name,
+		"matches": fantasy.ScorePlayerMatches(ruleset, matches, playerName),
+		"weeks":   weeks,
+		"season":  season,
+	})
+}
+
+func GetFantasyWeeklyPoints(c *gin.Context) {
+	ruleset, ok := loadRuleset(c, c.DefaultQuery("ruleset", models.DefaultRulesetName))
+	if !ok {
+		return
+	}
+
+	matches, err := models.GetAllMatchResults(fantasyFilter(c))
+	if err != nil {
+		storeError(c, err, "results_fetch_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"ruleset": ruleset.Name,
+		"weeks":   fantasy.Weekly(ruleset, matches, c.Query("week")),
+	})
+}
+
+func GetFantasySeasonPoints(c *gin.Context) {
+	ruleset, ok := loadRuleset(c, c.DefaultQuery("ruleset", models.DefaultRulesetName))
+	if !ok {
+		return
+	}
+
+	matches, err := models.GetAllMatchResults(fantasyFilter(c))
+	if err != nil {
+		storeError(c, err, "results_fetch_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"ruleset": ruleset.Name,
+		"players": fantasy.Season(ruleset, matches),
+	})
+}
+
+func SaveFantasyRuleset(c *gin.Context) {
+	var ruleset models.FantasyRuleset
+	if err := c.ShouldBindJSON(&ruleset); err != nil {
+		invalidBody(c, err)
+		return
+	}
+
+	ruleset.Name = c.Param("name")
+	now := time.Now()
+	if existing, err := models.GetFantasyRuleset(ruleset.Name); err == nil && existing != nil && !existing.CreatedAt.IsZero() {
+		ruleset.CreatedAt = existing.CreatedAt
+	} else {
+		ruleset.CreatedAt = now
+	}
+	ruleset.UpdatedAt = now
+
+	if err := models.SaveFantasyRuleset(&ruleset); err != nil {
+		storeError(c, err, "ruleset_save_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, ruleset)
+}
+
+func DeleteFantasyRuleset(c *gin.Context) {
+	if err := models.DeleteFantasyRuleset(c.Param("name")); err != nil {
+		storeError(c, err, "ruleset_delete_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Regras de fantasy excluídas com sucesso"})
+}
+
+// loadRuleset busca o ruleset pelo nome e responde com erro caso não exista
+func loadRuleset(c *gin.Context, name string) (*models.FantasyRuleset, bool) {
+	ruleset, err := models.GetFantasyRuleset(name)
+	if err != nil {
+		storeError(c, err, "ruleset_fetch_failed")
+		return nil, false
+	}
+	if ruleset == nil {
+		notFound(c, "ruleset_not_found")
+		return nil, false
+	}
+	return ruleset, true
+}
+
+// fantasyFilter monta o filtro de partidas a partir dos parâmetros region e stage
+func fantasyFilter(c *gin.Context) bson.M {
+	filter := bson.M{}
+	if region := c.Query("region"); region != "" {
+		filter["region"] = region
+	}
+	if stage := c.Query("stage"); stage != "" {
+		filter["tournamentStage"] = stage
+	}
+	return filter
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/bulletdev/lta-results-api/gql"
+	"github.com/gin-gonic/gin"
+)
+
+// GraphQL executa consultas enviadas por POST (corpo JSON) ou GET (parâmetros query,
+// operationName e variables)
+func GraphQL(c *gin.Context) {
+	var req gql.Request
+
+	if c.Request.Method == http.MethodGet {
+		req.Query = c.Query("query")
+		req.OperationName = c.Query("operationName")
+		if variables := c.Query("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				badRequest(c, "graphql_variables_invalid")
+				return
+			}
+		}
+	} else if err := c.ShouldBindJSON(&req); err != nil {
+		invalidBody(c, err)
+		return
+	}
+
+	if req.Query == "" {
+		badRequest(c, "graphql_query_required")
+		return
+	}
+
+	result := gql.Execute(c.Request.Context(), req)
+
+	// Erros de execução fazem parte da resposta GraphQL; apenas consultas
+	// que não puderam ser executadas retornam 400
+	status := http.StatusOK
+	if result.Data == nil && result.HasErrors() {
+		status = http.StatusBadRequest
+	}
+	c.JSON(status, result)
+}
@@ -1,12 +1,17 @@
 package api
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
 
+	"github.com/bulletdev/lta-results-api/export"
 	"github.com/bulletdev/lta-results-api/models"
+	"github.com/bulletdev/lta-results-api/mvp"
 	"github.com/bulletdev/lta-results-api/scraper"
+	"github.com/bulletdev/lta-results-api/validation"
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -14,6 +19,11 @@ import (
 )
 
 func GetMatchResults(c *gin.Context) {
+	format, ok := negotiateFormat(c)
+	if !ok {
+		return
+	}
+
 	// Parâmetros de consulta
 	region := c.Query("region")
 	team := c.Query("team")
@@ -28,21 +38,32 @@ func GetMatchResults(c *gin.Context) {
 	}
 	if team != "" {
 		filter["$or"] = []bson.M{
-			{"teama": team},
-			{"teamb": team},
+			{"teamA": team},
+			{"teamB": team},
 		}
 	}
 
 	// Opções de consulta
 	findOptions := options.Find()
 	findOptions.SetSort(bson.M{"date": -1})
+
+	// Exportações trazem todos os resultados, a menos que a paginação seja informada
+	if format != export.FormatJSON {
+		if c.Query("limit") != "" || c.Query("page") != "" {
+			findOptions.SetSkip(int64(skip))
+			findOptions.SetLimit(int64(limit))
+		}
+		exportMatches(c, format, "results", filter, findOptions, nil)
+		return
+	}
+
 	findOptions.SetSkip(int64(skip))
 	findOptions.SetLimit(int64(limit))
 
 	// Executar consulta
 	results, total, err := models.GetMatchResults(filter, findOptions)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar resultados"})
+		storeError(c, err, "results_fetch_failed")
 		return
 	}
 
@@ -64,25 +85,46 @@ func GetMatchResultByID(c *gin.Context) {
 	// Buscar resultado por ID
 	result, err := models.GetMatchResultByID(matchID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Resultado não encontrado"})
+		lookupError(c, err, "result_not_found", "results_fetch_failed")
 		return
 	}
 
+	etag := matchETag(result)
+	c.Header("ETag", etag)
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
 	c.JSON(http.StatusOK, result)
 }
 
 func GetPlayerStats(c *gin.Context) {
 	playerName := c.Param("playerName")
 
+	format, ok := negotiateFormat(c)
+	if !ok {
+		return
+	}
+
+	// Exportar as linhas do jogador em cada partida
+	if format != export.FormatJSON {
+		filter := bson.M{"players.name": playerName}
+		findOptions := options.Find().SetSort(bson.M{"date": -1})
+		exportMatches(c, format, "player-"+playerName, filter, findOptions, func(p models.Player) bool {
+			return p.Name == playerName
+		})
+		return
+	}
+
 	// Buscar estatísticas do jogador
 	stats, err := models.GetPlayerStats(playerName)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar estatísticas"})
+		storeError(c, err, "stats_fetch_failed")
 		return
 	}
 
 	if stats == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Jogador não encontrado"})
+		notFound(c, "player_not_found")
 		return
 	}
 
@@ -92,15 +134,30 @@ func GetPlayerStats(c *gin.Context) {
 func GetTeamStats(c *gin.Context) {
 	teamName := c.Param("teamName")
 
+	format, ok := negotiateFormat(c)
+	if !ok {
+		return
+	}
+
+	// Exportar as linhas dos jogadores do time em cada partida
+	if format != export.FormatJSON {
+		filter := bson.M{"$or": []bson.M{{"teamA": teamName}, {"teamB": teamName}}}
+		findOptions := options.Find().SetSort(bson.M{"date": -1})
+		exportMatches(c, format, "team-"+teamName, filter, findOptions, func(p models.Player) bool {
+			return p.Team == teamName
+		})
+		return
+	}
+
 	// Buscar estatísticas do time
 	stats, err := models.GetTeamStats(teamName)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar estatísticas"})
+		storeError(c, err, "stats_fetch_failed")
 		return
 	}
 
 	if stats == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Time não encontrado"})
+		notFound(c, "team_not_found", teamName)
 		return
 	}
 
@@ -116,7 +173,12 @@ func CreateMatchResult(c *gin.Context) {
 	var matchResult models.MatchResult
 
 	if err := c.ShouldBindJSON(&matchResult); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		invalidBody(c, err)
+		return
+	}
+
+	if errs := validation.MatchResult(&matchResult); errs != nil {
+		invalidFields(c, errs)
 		return
 	}
 
@@ -130,44 +192,153 @@ func CreateMatchResult(c *gin.Context) {
 	matchResult.CreatedAt = now
 	matchResult.UpdatedAt = now
 
+	// Calcular o MVP quando não informado
+	mvp.Assign(&matchResult)
+
 	// Inserir no banco de dados
-	if err := models.CreateMatchResult(&matchResult); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar resultado"})
+	if err := models.CreateMatchResult(&matchResult, author(c, models.SourceAdmin)); err != nil {
+		if errors.Is(err, models.ErrMatchExists) {
+			respondError(c, newError(http.StatusConflict, "result_exists"))
+			return
+		}
+		storeError(c, err, "result_create_failed")
 		return
 	}
 
+	c.Header("ETag", matchETag(&matchResult))
 	c.JSON(http.StatusCreated, matchResult)
 }
 
+// UpdateMatchResult substitui o resultado inteiro pelo corpo da requisição,
+// preservando o _id e o createdAt do documento atual
 func UpdateMatchResult(c *gin.Context) {
-	matchID := c.Param("matchId")
+	current, ok := loadMatchForWrite(c)
+	if !ok {
+		return
+	}
 
-	var matchResult models.MatchResult
-	if err := c.ShouldBindJSON(&matchResult); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	var next models.MatchResult
+	if err := c.ShouldBindJSON(&next); err != nil {
+		invalidBody(c, err)
+		return
+	}
+
+	replaceMatchResult(c, current, &next)
+}
+
+// PatchMatchResult aplica um JSON Merge Patch (RFC 7386) sobre o resultado atual
+func PatchMatchResult(c *gin.Context) {
+	if ct := c.ContentType(); ct != "application/merge-patch+json" && ct != "application/json" {
+		respondError(c, newError(http.StatusUnsupportedMediaType, "patch_content_type_invalid"))
 		return
 	}
 
-	// Atualizar data de atualização
-	matchResult.UpdatedAt = time.Now()
+	current, ok := loadMatchForWrite(c)
+	if !ok {
+		return
+	}
+
+	raw, err := c.GetRawData()
+	if err != nil {
+		invalidBody(c, err)
+		return
+	}
+	var patch map[string]interface{}
+	if err := json.Unmarshal(raw, &patch); err != nil {
+		invalidBody(c, err)
+		return
+	}
 
-	// Atualizar no banco de dados
-	if err := models.UpdateMatchResult(matchID, &matchResult); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar resultado"})
+	// O patch é aplicado sobre a representação JSON do documento atual
+	data, err := json.Marshal(current)
+	if err != nil {
+		storeError(c, err, "result_update_failed")
+		return
+	}
+	var document map[string]interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		storeError(c, err, "result_update_failed")
+		return
+	}
+	merged := mergePatch(document, patch).(map[string]interface{})
+
+	// Com novos jogadores o MVP anterior deixa de valer e é recalculado,
+	// a menos que o próprio patch informe um
+	if _, ok := patch["players"]; ok {
+		if _, ok := patch["mvp"]; !ok {
+			delete(merged, "mvp")
+		}
+	}
+
+	data, err = json.Marshal(merged)
+	if err != nil {
+		invalidBody(c, err)
+		return
+	}
+	var next models.MatchResult
+	if err := json.Unmarshal(data, &next); err != nil {
+		invalidBody(c, err)
+		return
+	}
+
+	replaceMatchResult(c, current, &next)
+}
+
+// loadMatchForWrite busca o resultado da rota e confere o If-Match da requisição
+func loadMatchForWrite(c *gin.Context) (*models.MatchResult, bool) {
+	current, err := models.GetMatchResultByID(c.Param("matchId"))
+	if err != nil {
+		lookupError(c, err, "result_not_found", "results_fetch_failed")
+		return nil, false
+	}
+	if !checkIfMatch(c, current) {
+		return nil, false
+	}
+	return current, true
+}
+
+// replaceMatchResult valida next e o grava no lugar de current, respondendo com o
+// resultado atualizado e sua nova ETag
+func replaceMatchResult(c *gin.Context, current, next *models.MatchResult) {
+	// O matchId da rota prevalece; um valor diferente no corpo é um erro
+	if next.MatchID == "" {
+		next.MatchID = current.MatchID
+	} else if next.MatchID != current.MatchID {
+		invalidFields(c, validation.Errors{validation.NewFieldError("matchId", "match_id_mismatch")})
+		return
+	}
+	if errs := validation.MatchResult(next); errs != nil {
+		invalidFields(c, errs)
+		return
+	}
+
+	// Campos imutáveis vêm sempre do documento atual
+	next.ID = current.ID
+	next.CreatedAt = current.CreatedAt
+	next.UpdatedAt = time.Now()
+	mvp.Assign(next)
+
+	if err := models.ReplaceMatchResult(current, next, author(c, models.SourceAdmin)); err != nil {
+		if errors.Is(err, models.ErrVersionConflict) {
+			respondError(c, newError(http.StatusPreconditionFailed, "result_version_conflict"))
+			return
+		}
+		lookupError(c, err, "result_not_found", "result_update_failed")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Resultado atualizado com sucesso"})
+	c.Header("ETag", matchETag(next))
+	c.JSON(http.StatusOK, next)
 }
 
 func DeleteMatchResult(c *gin.Context) {
 	matchID := c.Param("matchId")
 
-	// Excluir do banco de dados
-	if err := models.DeleteMatchResult(matchID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao excluir resultado"})
+	// Mover para a lixeira; a exclusão definitiva é feita pela rotina de retenção
+	if err := models.DeleteMatchResult(matchID, author(c, models.SourceAdmin)); err != nil {
+		lookupError(c, err, "result_not_found", "result_delete_failed")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Resultado excluído com sucesso"})
+	c.JSON(http.StatusOK, gin.H{"message": "Resultado movido para a lixeira"})
 }
@@ -0,0 +1,161 @@
+package api
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/buildinfo"
+	"github.com/bulletdev/lta-results-api/config"
+	"github.com/bulletdev/lta-results-api/database"
+	"github.com/bulletdev/lta-results-api/models"
+	"github.com/bulletdev/lta-results-api/scraper"
+	"github.com/gin-gonic/gin"
+)
+
+// healthTimeout limita o tempo de cada verificação, para que o probe não fique pendurado
+// quando o banco não responde
+const healthTimeout = 2 * time.Second
+
+// scrapeFailureThreshold é o número de falhas seguidas de uma região a partir do qual a
+// API é reportada como degradada
+const scrapeFailureThreshold = 3
+
+// startedAt marca o início do processo, usado no uptime do health check
+var startedAt = time.Now()
+
+// Livez indica apenas que o processo está de pé e atendendo requisições
+func Livez(c *gin.Context) {
+	c.Header("Cache-Control", "no-cache, no-store, must-revalidate")
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Readyz indica se a API pode receber tráfego, o que exige o banco acessível
+func Readyz(c *gin.Context) {
+	c.Header("Cache-Control", "no-cache, no-store, must-revalidate")
+
+	latency, err := pingDatabase(c.Request.Context())
+	if err != nil {
+		logCheckFailure(c, "database", err)
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"status": "unavailable",
+			"checks": gin.H{"database": gin.H{"status": "down"}},
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status": "ready",
+		"checks": gin.H{"database": gin.H{"status": "up", "latencyMs": milliseconds(latency)}},
+	})
+}
+
+// HealthCheck monta o relatório detalhado: o banco, o scraping de cada região e o build.
+// A API fica "unhealthy" (503) sem o banco e "degraded" quando alguma região acumula
+// falhas seguidas no scraping.
+func HealthCheck(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Adicionar headers importantes
+		c.Header("Access-Control-Allow-Origin", "*")
+		c.Header("Access-Control-Allow-Methods", "GET, OPTIONS")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Accept")
+		c.Header("Cache-Control", "no-cache, no-store, must-revalidate")
+		c.Header("Pragma", "no-cache")
+		c.Header("Expires", "0")
+
+		status, code := "healthy", http.StatusOK
+
+		dbCheck := gin.H{"status": "up", "backend": cfg.Database.Backend}
+		latency, err := pingDatabase(c.Request.Context())
+		if err != nil {
+			logCheckFailure(c, "database", err)
+			dbCheck["status"] = "down"
+			status, code = "unhealthy", http.StatusServiceUnavailable
+		} else {
+			dbCheck["latencyMs"] = milliseconds(latency)
+		}
+
+		scraperCheck := gin.H{"status": "ok", "schedule": cfg.Scraper.Schedule}
+		if next, ok := scraper.NextRun(); ok {
+			scraperCheck["nextRunAt"] = next.UTC().Format(time.RFC3339)
+		}
+		if err != nil {
+			// Sem o banco não há como saber o resultado das últimas execuções
+			scraperCheck["status"] = "unknown"
+		} else if regions, err := scrapeStatuses(c.Request.Context(), cfg.Scraper.Sources); err != nil {
+			logCheckFailure(c, "scraper", err)
+			scraperCheck["status"] = "unknown"
+		} else {
+			scraperCheck["regions"] = regions
+			for _, region := range regions {
+				if region.ConsecutiveFailures >= scrapeFailureThreshold {
+					scraperCheck["status"] = "degraded"
+					if status == "healthy" {
+						status = "degraded"
+					}
+				}
+			}
+		}
+
+		build := buildinfo.Get()
+		c.JSON(code, gin.H{
+			"status":        status,
+			"time":          time.Now().Format(time.RFC3339),
+			"version":       build.Version,
+			"build":         build,
+			"uptimeSeconds": int64(time.Since(startedAt).Seconds()),
+			"checks": gin.H{
+				"database": dbCheck,
+				"scraper":  scraperCheck,
+			},
+		})
+	}
+}
+
+// logCheckFailure registra o erro de uma verificação. As rotas de health são públicas,
+// então a resposta traz apenas o status, sem detalhes do driver ou da infraestrutura.
+func logCheckFailure(c *gin.Context, check string, err error) {
+	log.Printf("[%s] Health check %s falhou: %v", c.GetString(requestIDKey), check, err)
+}
+
+// pingDatabase mede o tempo de resposta do armazenamento
+func pingDatabase(ctx context.Context) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, healthTimeout)
+	defer cancel()
+
+	start := time.Now()
+	if err := database.Ping(ctx); err != nil {
+		return 0, err
+	}
+	return time.Since(start), nil
+}
+
+// scrapeStatuses retorna a situação das regiões configuradas, inclusive as ainda não
+// extraídas, e das que saíram da configuração mas ainda têm registro
+func scrapeStatuses(ctx context.Context, sources map[string]string) ([]models.ScrapeStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, healthTimeout)
+	defer cancel()
+
+	recorded, err := models.GetScrapeStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(recorded))
+	for _, status := range recorded {
+		seen[status.Region] = true
+	}
+	for region := range sources {
+		if !seen[region] {
+			recorded = append(recorded, models.ScrapeStatus{Region: region})
+		}
+	}
+	sort.Slice(recorded, func(i, j int) bool { return recorded[i].Region < recorded[j].Region })
+	return recorded, nil
+}
+
+// milliseconds converte a duração para milissegundos com precisão de microssegundos
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/bulletdev/lta-results-api/models"
+	"github.com/bulletdev/lta-results-api/validation"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GetMatchResultHistory lista as revisões de uma partida, da mais recente para a mais antiga
+func GetMatchResultHistory(c *gin.Context) {
+	matchID := c.Param("matchId")
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if limit < 1 || limit > 500 {
+		limit = 50
+	}
+
+	revisions, err := models.GetMatchRevisions(matchID, int64(limit))
+	if err != nil {
+		storeError(c, err, "history_fetch_failed")
+		return
+	}
+
+	// Sem revisões, diferenciar uma partida sem histórico de uma inexistente
+	if len(revisions) == 0 {
+		if _, err := models.GetMatchResultByID(matchID); err != nil {
+			lookupError(c, err, "result_not_found", "history_fetch_failed")
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"matchId": matchID, "revisions": revisions})
+}
+
+// RevertMatchResult restaura a partida para o estado registrado em uma revisão
+func RevertMatchResult(c *gin.Context) {
+	revisionID, err := primitive.ObjectIDFromHex(c.Param("revisionId"))
+	if err != nil {
+		badRequest(c, "invalid_id")
+		return
+	}
+
+	current, ok := loadMatchForWrite(c)
+	if !ok {
+		return
+	}
+
+	revision, err := models.GetMatchRevision(current.MatchID, revisionID)
+	if err == nil && revision.Snapshot == nil {
+		err = mongo.ErrNoDocuments
+	}
+	if err != nil {
+		lookupError(c, err, "revision_not_found", "history_fetch_failed")
+		return
+	}
+
+	// As regras de validação podem ter mudado desde a revisão
+	if errs := validation.MatchResult(revision.Snapshot); errs != nil {
+		invalidFields(c, errs)
+		return
+	}
+
+	reverted, err := models.RevertMatchResult(current, revision, author(c, models.SourceAdmin))
+	if err != nil {
+		if errors.Is(err, models.ErrVersionConflict) {
+			respondError(c, newError(http.StatusPreconditionFailed, "result_version_conflict"))
+			return
+		}
+		lookupError(c, err, "result_not_found", "result_revert_failed")
+		return
+	}
+
+	c.Header("ETag", matchETag(reverted))
+	c.JSON(http.StatusOK, reverted)
+}
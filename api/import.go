@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/bulletdev/lta-results-api/importer"
+	"github.com/bulletdev/lta-results-api/models"
+	"github.com/gin-gonic/gin"
+)
+
+// Tamanho máximo do corpo aceito na importação em lote
+const maxBulkImportSize = 50 << 20
+
+func BulkImportMatchResults(c *gin.Context) {
+	format := c.Query("format")
+	if format == "" {
+		format = importer.DetectFormat(c.ContentType())
+	}
+	if format == "" {
+		respondError(c, newError(http.StatusUnsupportedMediaType, "import_format_required"))
+		return
+	}
+
+	dryRun, _ := strconv.ParseBool(c.DefaultQuery("dryRun", "false"))
+	batchSize, err := strconv.Atoi(c.DefaultQuery("batchSize", strconv.Itoa(importer.DefaultBatchSize)))
+	if err != nil || batchSize < 1 || batchSize > importer.MaxBatchSize {
+		badRequest(c, "import_batch_size_invalid", importer.MaxBatchSize)
+		return
+	}
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxBulkImportSize)
+	records, err := importer.Parse(body, format)
+	if err != nil {
+		respondError(c, newError(http.StatusBadRequest, "import_file_invalid").with("reason", err.Error()))
+		return
+	}
+
+	report := importer.Run(records, importer.Options{
+		DryRun:    dryRun,
+		BatchSize: batchSize,
+		Author:    author(c, models.SourceImport),
+	})
+
+	c.JSON(http.StatusOK, report)
+}
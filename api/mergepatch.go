@@ -0,0 +1,24 @@
+package api
+
+// mergePatch aplica um JSON Merge Patch (RFC 7386) sobre target: objetos são
+// mesclados recursivamente, null remove o campo e os demais valores substituem
+// o valor atual
+func mergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = make(map[string]interface{})
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergePatch(targetObject[key], value)
+	}
+	return targetObject
+}
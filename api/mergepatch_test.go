@@ -0,0 +1,48 @@
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func decodeJSON(t *testing.T, raw string) interface{} {
+	t.Helper()
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		t.Fatalf("JSON inválido %s: %v", raw, err)
+	}
+	return value
+}
+
+// Os casos seguem os exemplos do apêndice A da RFC 7386
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name                  string
+		target, patch, result string
+	}{
+		{"substitui um campo", `{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"adiciona um campo", `{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{"null remove o campo", `{"a":"b"}`, `{"a":null}`, `{}`},
+		{"null mantém os outros campos", `{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{"arrays são substituídos", `{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"valor substituído por array", `{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{"objetos aninhados são mesclados", `{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{"arrays de objetos não são mesclados", `{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{"array substitui o documento", `["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{"objeto substitui um array", `["a","b"]`, `{"a":"b"}`, `{"a":"b"}`},
+		{"valor substitui um objeto", `{"a":"foo"}`, `"bar"`, `"bar"`},
+		{"null em campo ausente", `{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{"documento nulo vira objeto", `null`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+		{"patch vazio não altera nada", `{"a":{"b":1}}`, `{}`, `{"a":{"b":1}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergePatch(decodeJSON(t, tt.target), decodeJSON(t, tt.patch))
+			if want := decodeJSON(t, tt.result); !reflect.DeepEqual(got, want) {
+				t.Errorf("mergePatch(%s, %s) = %v, esperado %s", tt.target, tt.patch, got, tt.result)
+			}
+		})
+	}
+}
@@ -1,40 +1,213 @@
 package api
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
-	"os"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/bulletdev/lta-results-api/apikeys"
+	"github.com/bulletdev/lta-results-api/config"
+	"github.com/bulletdev/lta-results-api/models"
+	"github.com/bulletdev/lta-results-api/oidc"
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func AuthMiddleware() gin.HandlerFunc {
-	// Log na inicialização do middleware
-	adminAPIKey := os.Getenv("ADMIN_API_KEY")
+// AuthMiddleware autentica as requisições por um token JWT no header Authorization,
+// quando o provedor OIDC está configurado, ou pelo header X-API-Key. São aceitas as
+// chaves cadastradas em /api/v1/admin/keys e, para a configuração inicial, a chave de
+// ADMIN_API_KEY, que tem o escopo admin. Os segredos nunca são registrados no log. A
+// configuração OIDC já é verificada por config.Validate; um erro aqui é retornado.
+func AuthMiddleware(cfg config.Auth) (gin.HandlerFunc, error) {
+	adminAPIKey := cfg.AdminAPIKey
 	log.Printf("Middleware inicializado. ADMIN_API_KEY configurada: %v", adminAPIKey != "")
 
-	return func(c *gin.Context) {
-		apiKey := c.GetHeader("X-API-Key")
-		// Remover espaços em branco que possam estar causando problemas
-		apiKey = strings.TrimSpace(apiKey)
+	oidcConfig, err := cfg.OIDC.Verifier()
+	if err != nil {
+		return nil, fmt.Errorf("configuração OIDC inválida: %w", err)
+	}
+	var verifier *oidc.Verifier
+	if oidcConfig.Enabled() {
+		if verifier, err = oidc.NewVerifier(oidcConfig); err != nil {
+			return nil, fmt.Errorf("erro ao configurar a autenticação OIDC: %w", err)
+		}
+		log.Printf("Autenticação por token habilitada (papéis na claim %s)", oidcConfig.RolesClaim)
+	}
 
-		log.Printf("Requisição recebida. API Key fornecida: '%s'", apiKey)
+	return func(c *gin.Context) {
+		if token, ok := bearerToken(c); ok {
+			authenticateToken(c, verifier, token)
+			return
+		}
 
+		apiKey := strings.TrimSpace(c.GetHeader("X-API-Key"))
 		if apiKey == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "API key ausente"})
+			respondError(c, newError(http.StatusUnauthorized, "api_key_missing"))
+			return
+		}
+
+		if adminAPIKey != "" && subtle.ConstantTimeCompare([]byte(apiKey), []byte(adminAPIKey)) == 1 {
+			c.Set(actorKey, "admin")
+			c.Set(credentialKey, "admin")
+			c.Set(scopesKey, []string{models.ScopeAdmin})
+			c.Next()
+			return
+		}
+
+		key, err := models.GetAPIKeyByHash(hashAPIKey(apiKey))
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				rejectAPIKey(c, "api_key_invalid", "chave desconhecida")
+				return
+			}
+			storeError(c, err, "api_key_lookup_failed")
+			return
+		}
+
+		now := time.Now()
+		switch {
+		case key.RevokedAt != nil:
+			rejectAPIKey(c, "api_key_revoked", "chave revogada "+key.ID.Hex())
+			return
+		case key.Expired(now):
+			rejectAPIKey(c, "api_key_expired", "chave expirada "+key.ID.Hex())
+			return
+		}
+
+		used, err := apikeys.RecordUse(key.ID, now)
+		if err != nil {
+			storeError(c, err, "api_key_lookup_failed")
+			return
+		}
+		if key.DailyQuota > 0 && used > key.DailyQuota {
+			reset := now.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+			c.Header("Retry-After", strconv.Itoa(int(reset.Sub(now).Seconds())+1))
+			respondError(c, newError(http.StatusTooManyRequests, "api_key_quota_exceeded").
+				with("dailyQuota", key.DailyQuota).
+				with("resetAt", reset))
 			return
 		}
 
-		adminAPIKey := os.Getenv("ADMIN_API_KEY")
-		log.Printf("Comparando chaves: Recebida='%s', Esperada='%s', Iguais=%v",
-			apiKey, adminAPIKey, apiKey == adminAPIKey)
+		c.Set(actorKey, key.Owner)
+		c.Set(credentialKey, "key:"+key.ID.Hex())
+		c.Set(scopesKey, key.Scopes)
+		c.Next()
+	}, nil
+}
+
+// optionalCredentialsKey marca, no contexto do gin, as requisições em que a credencial
+// é opcional
+const optionalCredentialsKey = "optionalCredentials"
+
+// WithCredentials executa o middleware apenas nas requisições que enviam uma API key ou
+// um token. Nas rotas públicas, autentica quem se identifica para que o rate limit use
+// a credencial; as demais seguem sem autenticação. Uma credencial inválida, revogada ou
+// expirada não bloqueia a requisição, que segue limitada pelo IP.
+func WithCredentials(middleware gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := bearerToken(c); ok || strings.TrimSpace(c.GetHeader("X-API-Key")) != "" {
+			c.Set(optionalCredentialsKey, true)
+			middleware(c)
+		}
+	}
+}
+
+// bearerToken extrai o token do header Authorization: Bearer
+func bearerToken(c *gin.Context) (string, bool) {
+	scheme, token, ok := strings.Cut(strings.TrimSpace(c.GetHeader("Authorization")), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
+// authenticateToken valida o JWT e concede os escopos dos papéis do usuário
+func authenticateToken(c *gin.Context, verifier *oidc.Verifier, token string) {
+	if verifier == nil {
+		rejectCredentials(c, "token_auth_disabled")
+		return
+	}
 
-		if apiKey != adminAPIKey {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "API key inválida"})
+	identity, err := verifier.Verify(c.Request.Context(), token)
+	if err != nil {
+		log.Printf("[%s] Token rejeitado para %s: %v", c.GetString(requestIDKey), c.ClientIP(), err)
+		if errors.Is(err, oidc.ErrExpired) {
+			rejectCredentials(c, "token_expired")
 			return
 		}
+		rejectCredentials(c, "token_invalid")
+		return
+	}
+
+	c.Set(actorKey, identity.Name)
+	c.Set(credentialKey, "user:"+identity.Subject)
+	c.Set(scopesKey, identity.Scopes)
+	c.Next()
+}
+
+// rejectAPIKey rejeita a chave e registra o motivo sem a chave enviada
+func rejectAPIKey(c *gin.Context, code, reason string) {
+	log.Printf("[%s] API key rejeitada (%s) para %s", c.GetString(requestIDKey), reason, c.ClientIP())
+	rejectCredentials(c, code)
+}
+
+// rejectCredentials responde 401 com code. Onde a credencial é opcional, a requisição
+// segue sem autenticação e o rate limit usa o IP.
+func rejectCredentials(c *gin.Context, code string) {
+	if c.GetBool(optionalCredentialsKey) {
+		return
+	}
+	respondError(c, newError(http.StatusUnauthorized, code))
+}
+
+// scopesKey é a chave, no contexto do gin, dos escopos concedidos à requisição
+const scopesKey = "scopes"
+
+// RequireScope exige que a requisição autenticada tenha o escopo informado ou o
+// escopo admin
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, granted := range c.GetStringSlice(scopesKey) {
+			if granted == scope || granted == models.ScopeAdmin {
+				c.Next()
+				return
+			}
+		}
+		respondError(c, newError(http.StatusForbidden, "insufficient_scope", scope).with("requiredScope", scope))
+	}
+}
+
+// actorKey é a chave, no contexto do gin, de quem foi autenticado na requisição
+const actorKey = "actor"
+
+// author identifica, para o histórico, o administrador autenticado na requisição
+func author(c *gin.Context, source string) models.Author {
+	return models.Author{Actor: c.GetString(actorKey), Source: source}
+}
+
+// requestIDKey é a chave do ID da requisição no contexto do gin
+const requestIDKey = "requestID"
+
+// RequestID identifica cada requisição pelo header X-Request-ID, gerando um ID
+// quando o cliente não envia, e o devolve na resposta
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := strings.TrimSpace(c.GetHeader("X-Request-ID"))
+		if id == "" || len(id) > 128 {
+			buf := make([]byte, 16)
+			rand.Read(buf)
+			id = hex.EncodeToString(buf)
+		}
 
+		c.Set(requestIDKey, id)
+		c.Header("X-Request-ID", id)
 		c.Next()
 	}
 }
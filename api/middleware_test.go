@@ -0,0 +1,175 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/config"
+	"github.com/bulletdev/lta-results-api/database"
+	"github.com/bulletdev/lta-results-api/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// newTestRouter abre um armazenamento embutido temporário e monta as rotas com as
+// rotas públicas limitadas a uma requisição por minuto
+func newTestRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	cfg := config.Defaults()
+	cfg.Database.Backend = config.BackendEmbedded
+	cfg.Database.Path = filepath.Join(t.TempDir(), "api.db")
+	if err := database.Connect(cfg.Database); err != nil {
+		t.Fatalf("erro ao abrir o armazenamento: %v", err)
+	}
+	t.Cleanup(database.Close)
+
+	cfg.RateLimit.Public = "1/1m"
+	router, err := SetupRouter(cfg)
+	if err != nil {
+		t.Fatalf("erro ao montar as rotas: %v", err)
+	}
+	return router
+}
+
+// createKey cadastra uma API key com o segredo e os escopos informados
+func createKey(t *testing.T, secret string, revoked bool, scopes ...string) *models.APIKey {
+	t.Helper()
+
+	now := time.Now()
+	key := &models.APIKey{
+		ID:        primitive.NewObjectID(),
+		Name:      secret,
+		Owner:     "parceiro",
+		Hash:      hashAPIKey(secret),
+		Scopes:    scopes,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if revoked {
+		key.RevokedAt = &now
+	}
+	if err := models.CreateAPIKey(key); err != nil {
+		t.Fatalf("erro ao criar a chave: %v", err)
+	}
+	return key
+}
+
+func request(router *gin.Engine, path string, header ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func errorCode(t *testing.T, w *httptest.ResponseRecorder) string {
+	t.Helper()
+
+	var body ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("resposta de erro inválida: %s", w.Body)
+	}
+	return body.Error.Code
+}
+
+func TestPublicRoutesIgnoreRejectedCredentials(t *testing.T) {
+	tests := []struct {
+		name   string
+		header []string
+	}{
+		{"chave desconhecida", []string{"X-API-Key", "lta_desconhecida"}},
+		{"chave revogada", []string{"X-API-Key", "lta_revogada"}},
+		{"token sem OIDC configurado", []string{"Authorization", "Bearer abc.def.ghi"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter(t)
+			createKey(t, "lta_revogada", true, models.ScopeRead)
+
+			// A credencial rejeitada não bloqueia a requisição...
+			if w := request(router, "/api/v1/fantasy/rulesets", tt.header...); w.Code != http.StatusOK {
+				t.Fatalf("status = %d, esperado 200: %s", w.Code, w.Body)
+			}
+			// ...que consome o bucket do IP, como uma requisição sem credenciais
+			if w := request(router, "/api/v1/fantasy/rulesets"); w.Code != http.StatusTooManyRequests {
+				t.Errorf("status sem credenciais = %d, esperado 429", w.Code)
+			}
+		})
+	}
+}
+
+func TestPublicRoutesLimitByValidKey(t *testing.T) {
+	router := newTestRouter(t)
+	createKey(t, "lta_valida", false, models.ScopeRead)
+
+	// A chave válida tem um bucket próprio, separado do IP
+	if w := request(router, "/api/v1/fantasy/rulesets", "X-API-Key", "lta_valida"); w.Code != http.StatusOK {
+		t.Fatalf("status com a chave = %d, esperado 200: %s", w.Code, w.Body)
+	}
+	if w := request(router, "/api/v1/fantasy/rulesets"); w.Code != http.StatusOK {
+		t.Errorf("status sem credenciais = %d, esperado 200", w.Code)
+	}
+}
+
+func TestAdminRoutesRejectCredentials(t *testing.T) {
+	router := newTestRouter(t)
+	createKey(t, "lta_revogada", true, models.ScopeAdmin)
+
+	tests := []struct {
+		name     string
+		header   []string
+		wantCode string
+	}{
+		{"sem credenciais", nil, "api_key_missing"},
+		{"chave desconhecida", []string{"X-API-Key", "lta_desconhecida"}, "api_key_invalid"},
+		{"chave revogada", []string{"X-API-Key", "lta_revogada"}, "api_key_revoked"},
+		{"token sem OIDC configurado", []string{"Authorization", "Bearer abc.def.ghi"}, "token_auth_disabled"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := request(router, "/api/v1/admin/keys", tt.header...)
+			if w.Code != http.StatusUnauthorized {
+				t.Fatalf("status = %d, esperado 401", w.Code)
+			}
+			if code := errorCode(t, w); code != tt.wantCode {
+				t.Errorf("código = %s, esperado %s", code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestDailyQuota(t *testing.T) {
+	router := newTestRouter(t)
+	key := createKey(t, "lta_cota", false, models.ScopeAdmin)
+	if _, err := database.GetCollection("api_keys").
+		UpdateOne(context.Background(), bson.M{"_id": key.ID}, bson.M{"$set": bson.M{"dailyQuota": 2}}); err != nil {
+		t.Fatalf("erro ao definir a cota: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if w := request(router, "/api/v1/admin/keys", "X-API-Key", "lta_cota"); w.Code != http.StatusOK {
+			t.Fatalf("requisição %d dentro da cota: status %d", i+1, w.Code)
+		}
+	}
+
+	w := request(router, "/api/v1/admin/keys", "X-API-Key", "lta_cota")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("status além da cota = %d, esperado 429", w.Code)
+	}
+	if code := errorCode(t, w); code != "api_key_quota_exceeded" {
+		t.Errorf("código = %s, esperado api_key_quota_exceeded", code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("resposta sem Retry-After")
+	}
+}
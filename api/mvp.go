@@ -0,0 +1,42 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/bulletdev/lta-results-api/models"
+	"github.com/bulletdev/lta-results-api/mvp"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func GetMVPRanking(c *gin.Context) {
+	filter := bson.M{}
+	if region := c.Query("region"); region != "" {
+		filter["region"] = region
+	}
+	if stage := c.Query("stage"); stage != "" {
+		filter["tournamentStage"] = stage
+	}
+
+	matches, err := models.GetAllMatchResults(filter)
+	if err != nil {
+		storeError(c, err, "results_fetch_failed")
+		return
+	}
+
+	ranking := mvp.Ranking(matches)
+
+	// Contagem apenas dos jogadores que já foram MVP
+	counts := make([]gin.H, 0)
+	for _, entry := range ranking {
+		if entry.MVPs > 0 {
+			counts = append(counts, gin.H{"player": entry.Player, "team": entry.Team, "mvps": entry.MVPs})
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"matches": len(matches),
+		"counts":  counts,
+		"ranking": ranking,
+	})
+}
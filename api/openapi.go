@@ -0,0 +1,750 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/bulletdev/lta-results-api/buildinfo"
+	"github.com/bulletdev/lta-results-api/champions"
+	"github.com/bulletdev/lta-results-api/events"
+	"github.com/bulletdev/lta-results-api/fantasy"
+	"github.com/bulletdev/lta-results-api/importer"
+	"github.com/bulletdev/lta-results-api/models"
+	"github.com/bulletdev/lta-results-api/mvp"
+	"github.com/bulletdev/lta-results-api/openapi"
+	"github.com/bulletdev/lta-results-api/prediction"
+	"github.com/bulletdev/lta-results-api/simulation"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	specOnce sync.Once
+	spec     *openapi.Document
+)
+
+// OpenAPISpec retorna a especificação OpenAPI de todas as rotas de SetupRouter
+func OpenAPISpec() *openapi.Document {
+	specOnce.Do(func() {
+		spec = buildOpenAPISpec()
+	})
+	return spec
+}
+
+func GetOpenAPISpec(c *gin.Context) {
+	c.JSON(http.StatusOK, OpenAPISpec())
+}
+
+func GetAPIDocs(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(docsPage))
+}
+
+// Página de documentação com o Swagger UI apontando para /openapi.json
+const docsPage = `<!DOCTYPE html>
+<html lang="pt-BR">
+<head>
+  <meta charset="utf-8">
+  <title>LTA Match Results API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>
+`
+
+func buildOpenAPISpec() *openapi.Document {
+	doc := openapi.New(openapi.Info{
+		Title:       "LTA Match Results API",
+		Description: "Resultados, estatísticas e análises das partidas da LTA.",
+		Version:     "1.0.0",
+	})
+	doc.Servers = []openapi.Server{{URL: "/"}}
+	doc.Components.SecuritySchemes["apiKey"] = &openapi.SecurityScheme{
+		Type: "apiKey",
+		In:   "header",
+		Name: "X-API-Key",
+	}
+	doc.Components.SecuritySchemes["bearerAuth"] = &openapi.SecurityScheme{
+		Type:         "http",
+		Scheme:       "bearer",
+		BearerFormat: "JWT",
+		Description:  "Token do provedor de identidade; os papéis do token são convertidos em escopos",
+	}
+
+	errorDetail := openapi.Object(map[string]*openapi.Schema{
+		"code":      {Type: "string", Description: "Código estável do erro, ex.: result_not_found"},
+		"message":   {Type: "string", Description: "Mensagem no idioma negociado"},
+		"details":   {Type: "object", Description: "Informações adicionais, como reason ou validEvents"},
+		"requestId": {Type: "string", Description: "ID da requisição, também enviado no header X-Request-ID"},
+	})
+	errorDetail.Required = []string{"code", "message"}
+	errorBody := doc.Define("Error", &openapi.Schema{
+		Type: "object",
+		Description: "Envelope de erro. A mensagem é traduzida conforme o Accept-Language (pt-BR, en ou es) " +
+			"e falhas de infraestrutura do banco retornam 503 com o código service_unavailable.",
+		Properties: map[string]*openapi.Schema{"error": errorDetail},
+		Required:   []string{"error"},
+	})
+	message := doc.Define("Message", &openapi.Schema{
+		Type:       "object",
+		Properties: map[string]*openapi.Schema{"message": openapi.String()},
+		Required:   []string{"message"},
+	})
+
+	match := doc.Schema(models.MatchResult{})
+	doc.Schema(models.Player{})
+	playerStats := doc.Schema(models.PlayerStats{})
+	teamStats := doc.Schema(models.TeamStats{})
+	scheduled := doc.Schema(models.ScheduledMatch{})
+	ruleset := doc.Schema(models.FantasyRuleset{})
+	webhook := doc.Schema(models.Webhook{})
+	delivery := doc.Schema(models.WebhookDelivery{})
+	event := doc.Schema(events.Event{})
+
+	failure := func(description string) *openapi.Response { return openapi.JSON(description, errorBody) }
+	ok := func(description string, schema *openapi.Schema) map[string]*openapi.Response {
+		return map[string]*openapi.Response{
+			"200": openapi.JSON(description, schema),
+			"500": failure("Erro interno"),
+		}
+	}
+	admin := func(scope string, op *openapi.Operation) *openapi.Operation {
+		op.Tags = append(op.Tags, "Admin")
+		op.Security = []map[string][]string{{"apiKey": {}}, {"bearerAuth": {}}}
+		op.Description = strings.TrimSpace(op.Description + " Requer o escopo " + scope + ".")
+		op.Responses["401"] = failure("API key ou token ausente, inválido, revogado ou expirado")
+		op.Responses["403"] = failure("A credencial não tem o escopo " + scope)
+		op.Responses["429"] = failure("Limite de requisições ou cota diária da API key esgotados")
+		return op
+	}
+
+	region := openapi.Query("region", "Filtrar por região", openapi.String())
+	stage := openapi.Query("stage", "Filtrar por fase do torneio", openapi.String())
+	format := openapi.Query("format", "Formato da resposta (também negociado pelo header Accept)", openapi.Enum("json", "csv", "ndjson", "xlsx"))
+	ifNoneMatch := openapi.Header("If-None-Match", "ETag de uma resposta anterior; responde 304 se ela não tiver mudado")
+	cached := func(op *openapi.Operation) *openapi.Operation {
+		op.Parameters = append(op.Parameters, ifNoneMatch)
+		op.Responses["304"] = &openapi.Response{Description: "Resposta não modificada desde a ETag informada"}
+		return op
+	}
+	exported := func(responses map[string]*openapi.Response) map[string]*openapi.Response {
+		for _, mediaType := range []string{"text/csv", "application/x-ndjson", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"} {
+			responses["200"].Content[mediaType] = openapi.MediaType{Schema: openapi.String()}
+		}
+		responses["406"] = failure("Formato não suportado")
+		return responses
+	}
+
+	// Sistema
+	databaseCheck := openapi.Object(map[string]*openapi.Schema{
+		"status":    openapi.Enum("up", "down"),
+		"backend":   openapi.String(),
+		"latencyMs": openapi.Number(),
+	})
+	readiness := openapi.Object(map[string]*openapi.Schema{
+		"status": openapi.Enum("ready", "unavailable"),
+		"checks": openapi.Object(map[string]*openapi.Schema{"database": databaseCheck}),
+	})
+	health := openapi.Object(map[string]*openapi.Schema{
+		"status":        openapi.Enum("healthy", "degraded", "unhealthy"),
+		"time":          {Type: "string", Format: "date-time"},
+		"version":       openapi.String(),
+		"build":         doc.Schema(buildinfo.Info{}),
+		"uptimeSeconds": openapi.Integer(),
+		"checks": openapi.Object(map[string]*openapi.Schema{
+			"database": databaseCheck,
+			"scraper": openapi.Object(map[string]*openapi.Schema{
+				"status":    openapi.Enum("ok", "degraded", "unknown"),
+				"schedule":  openapi.String(),
+				"nextRunAt": {Type: "string", Format: "date-time"},
+				"regions":   openapi.Array(doc.Schema(models.ScrapeStatus{})),
+			}),
+		}),
+	})
+	doc.Add("GET", "/livez", &openapi.Operation{
+		Tags:    []string{"Sistema"},
+		Summary: "Verificar se o processo está no ar",
+		Responses: map[string]*openapi.Response{"200": openapi.JSON("Processo ativo", openapi.Object(map[string]*openapi.Schema{
+			"status": openapi.String(),
+		}))},
+	})
+	doc.Add("GET", "/readyz", &openapi.Operation{
+		Tags:        []string{"Sistema"},
+		Summary:     "Verificar se a API pode receber tráfego",
+		Description: "Falha enquanto o banco de dados não responder.",
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSON("API pronta", readiness),
+			"503": openapi.JSON("Banco de dados indisponível", readiness),
+		},
+	})
+	doc.Add("GET", "/health", &openapi.Operation{
+		Tags:        []string{"Sistema"},
+		Summary:     "Relatório de saúde da API e das dependências",
+		Description: "Inclui a latência do banco, o último scraping bem-sucedido de cada região, a próxima execução agendada e a versão do build. A API fica \"degraded\" quando uma região acumula 3 falhas seguidas no scraping e \"unhealthy\" quando o banco está indisponível.",
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSON("API saudável ou degradada", health),
+			"503": openapi.JSON("Banco de dados indisponível", health),
+		},
+	})
+	doc.Add("OPTIONS", "/health", &openapi.Operation{
+		Tags:      []string{"Sistema"},
+		Summary:   "Preflight CORS do health check",
+		Responses: map[string]*openapi.Response{"204": {Description: "Sem conteúdo"}},
+	})
+	doc.Add("GET", "/openapi.json", &openapi.Operation{
+		Tags:      []string{"Sistema"},
+		Summary:   "Esta especificação OpenAPI",
+		Responses: map[string]*openapi.Response{"200": openapi.JSON("Documento OpenAPI 3", &openapi.Schema{Type: "object"})},
+	})
+	doc.Add("GET", "/docs", &openapi.Operation{
+		Tags:    []string{"Sistema"},
+		Summary: "Página de documentação interativa",
+		Responses: map[string]*openapi.Response{"200": {
+			Description: "Página HTML",
+			Content:     map[string]openapi.MediaType{"text/html": {Schema: openapi.String()}},
+		}},
+	})
+
+	// Resultados
+	doc.Add("GET", "/api/v1/results", cached(&openapi.Operation{
+		Tags:        []string{"Resultados"},
+		Summary:     "Listar resultados de partidas",
+		Description: "As respostas JSON são guardadas em cache e invalidadas quando uma partida da região ou do time filtrado é alterada.",
+		Parameters: []*openapi.Parameter{
+			region,
+			openapi.Query("team", "Filtrar por time (teamA ou teamB)", openapi.String()),
+			openapi.Query("limit", "Itens por página", &openapi.Schema{Type: "integer", Default: 10}),
+			openapi.Query("page", "Página", &openapi.Schema{Type: "integer", Default: 1}),
+			format,
+		},
+		Responses: exported(ok("Resultados paginados", openapi.Object(map[string]*openapi.Schema{
+			"results": openapi.Array(match),
+			"pagination": openapi.Object(map[string]*openapi.Schema{
+				"total": openapi.Integer(),
+				"page":  openapi.Integer(),
+				"limit": openapi.Integer(),
+				"pages": openapi.Integer(),
+			}),
+		}))),
+	}))
+	doc.Add("GET", "/api/v1/results/:matchId", cached(&openapi.Operation{
+		Tags:    []string{"Resultados"},
+		Summary: "Obter um resultado",
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSON("Resultado, com a versão na ETag", match),
+			"404": failure("Resultado não encontrado"),
+		},
+	}))
+
+	// Stream
+	streamParams := []*openapi.Parameter{
+		region,
+		openapi.Query("team", "Filtrar por time (repetido ou separado por vírgula)", openapi.String()),
+		openapi.Query("lastEventId", "Reenviar os eventos posteriores a este ID", openapi.Integer()),
+		openapi.Header("Last-Event-ID", "Reenviar os eventos posteriores a este ID"),
+	}
+	doc.Add("GET", "/api/v1/stream", &openapi.Operation{
+		Tags:        []string{"Stream"},
+		Summary:     "Alterações em tempo real (Server-Sent Events)",
+		Description: "Cada mensagem tem o ID do evento, o tipo no campo event e o Event em JSON no campo data.",
+		Parameters:  streamParams,
+		Responses: map[string]*openapi.Response{"200": {
+			Description: "Stream de eventos",
+			Content:     map[string]openapi.MediaType{"text/event-stream": {Schema: event}},
+		}},
+	})
+	doc.Add("GET", "/api/v1/stream/ws", &openapi.Operation{
+		Tags:        []string{"Stream"},
+		Summary:     "Alterações em tempo real (WebSocket)",
+		Description: "Após o upgrade, cada mensagem de texto é um Event em JSON.",
+		Parameters:  streamParams,
+		Responses:   map[string]*openapi.Response{"101": {Description: "Conexão WebSocket aberta"}},
+	})
+
+	// GraphQL
+	graphqlResult := openapi.Object(map[string]*openapi.Schema{
+		"data":       {Type: "object", Nullable: true},
+		"errors":     openapi.Array(openapi.Object(map[string]*openapi.Schema{"message": openapi.String()})),
+		"extensions": {Type: "object"},
+	})
+	doc.Add("GET", "/api/v1/graphql", &openapi.Operation{
+		Tags:    []string{"GraphQL"},
+		Summary: "Executar uma consulta GraphQL",
+		Parameters: []*openapi.Parameter{
+			{Name: "query", In: "query", Required: true, Schema: openapi.String()},
+			openapi.Query("operationName", "Operação a executar", openapi.String()),
+			openapi.Query("variables", "Variáveis em JSON", openapi.String()),
+		},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSON("Resultado GraphQL", graphqlResult),
+			"400": openapi.JSON("Consulta inválida ou acima dos limites", graphqlResult),
+		},
+	})
+	doc.Add("POST", "/api/v1/graphql", &openapi.Operation{
+		Tags:    []string{"GraphQL"},
+		Summary: "Executar uma consulta GraphQL",
+		RequestBody: openapi.Body(&openapi.Schema{
+			Type: "object",
+			Properties: map[string]*openapi.Schema{
+				"query":         openapi.String(),
+				"operationName": openapi.String(),
+				"variables":     {Type: "object"},
+			},
+			Required: []string{"query"},
+		}),
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSON("Resultado GraphQL", graphqlResult),
+			"400": openapi.JSON("Consulta inválida ou acima dos limites", graphqlResult),
+		},
+	})
+
+	// Estatísticas
+	playerResponses := exported(ok("Estatísticas do jogador", playerStats))
+	playerResponses["404"] = failure("Jogador não encontrado")
+	doc.Add("GET", "/api/v1/players/:playerName/stats", cached(&openapi.Operation{
+		Tags:       []string{"Estatísticas"},
+		Summary:    "Estatísticas de um jogador",
+		Parameters: []*openapi.Parameter{format},
+		Responses:  playerResponses,
+	}))
+	teamResponses := exported(ok("Estatísticas do time", teamStats))
+	teamResponses["404"] = failure("Time não encontrado")
+	doc.Add("GET", "/api/v1/teams/:teamName/stats", cached(&openapi.Operation{
+		Tags:       []string{"Estatísticas"},
+		Summary:    "Estatísticas de um time",
+		Parameters: []*openapi.Parameter{format},
+		Responses:  teamResponses,
+	}))
+
+	// Previsões e simulações
+	predictResponses := ok("Previsão da série", doc.Schema(prediction.Prediction{}))
+	predictResponses["400"] = failure("Parâmetros inválidos")
+	predictResponses["404"] = failure("Time não encontrado")
+	doc.Add("GET", "/api/v1/predict", &openapi.Operation{
+		Tags:    []string{"Previsões"},
+		Summary: "Prever o resultado de um confronto",
+		Parameters: []*openapi.Parameter{
+			{Name: "teamA", In: "query", Required: true, Schema: openapi.String()},
+			{Name: "teamB", In: "query", Required: true, Schema: openapi.String()},
+			region,
+			openapi.Query("bestOf", "Formato da série", &openapi.Schema{Type: "integer", Enum: []interface{}{1, 3, 5}, Default: 1}),
+		},
+		Responses: predictResponses,
+	})
+	simulationResponses := ok("Probabilidades por time", doc.Schema(simulation.Report{}))
+	simulationResponses["400"] = failure("Parâmetros inválidos")
+	simulationResponses["404"] = failure("Nenhuma partida encontrada para a região")
+	doc.Add("GET", "/api/v1/simulations/playoffs", &openapi.Operation{
+		Tags:    []string{"Previsões"},
+		Summary: "Simular as chances de classificação para os playoffs",
+		Parameters: []*openapi.Parameter{
+			{Name: "region", In: "query", Required: true, Schema: openapi.String()},
+			stage,
+			openapi.Query("runs", "Quantidade de simulações", &openapi.Schema{Type: "integer", Default: 10000}),
+			openapi.Query("spots", "Vagas nos playoffs", &openapi.Schema{Type: "integer", Default: 6}),
+			openapi.Query("seed", "Semente para reproduzir o resultado", &openapi.Schema{Type: "integer", Format: "int64"}),
+		},
+		Responses: simulationResponses,
+	})
+
+	// Campeões
+	championParams := []*openapi.Parameter{
+		region,
+		stage,
+		openapi.Query("champion", "Restringir a um campeão", openapi.String()),
+		openapi.Query("position", "Restringir aos pares em que champion jogou nessa posição", openapi.String()),
+		openapi.Query("minGames", "Mínimo de jogos por par", &openapi.Schema{Type: "integer", Default: 3}),
+		openapi.Query("confidence", "Nível de confiança do intervalo", &openapi.Schema{Type: "number", Enum: []interface{}{0.8, 0.9, 0.95, 0.99}, Default: 0.95}),
+	}
+	pairs := openapi.Object(map[string]*openapi.Schema{
+		"minGames":   openapi.Integer(),
+		"confidence": openapi.Number(),
+		"pairs":      openapi.Array(doc.Schema(champions.PairStats{})),
+	})
+	for _, route := range []struct{ path, summary string }{
+		{"/api/v1/champions/synergy", "Sinergia entre campeões do mesmo time"},
+		{"/api/v1/champions/counters", "Confrontos entre campeões na mesma posição"},
+	} {
+		responses := ok("Pares de campeões", pairs)
+		responses["400"] = failure("Parâmetros inválidos")
+		doc.Add("GET", route.path, &openapi.Operation{
+			Tags:       []string{"Campeões"},
+			Summary:    route.summary,
+			Parameters: championParams,
+			Responses:  responses,
+		})
+	}
+
+	// MVPs
+	doc.Add("GET", "/api/v1/mvp", &openapi.Operation{
+		Tags:       []string{"MVPs"},
+		Summary:    "Ranking de MVPs",
+		Parameters: []*openapi.Parameter{region, stage},
+		Responses: ok("Ranking", openapi.Object(map[string]*openapi.Schema{
+			"matches": openapi.Integer(),
+			"counts": openapi.Array(openapi.Object(map[string]*openapi.Schema{
+				"player": openapi.String(),
+				"team":   openapi.String(),
+				"mvps":   openapi.Integer(),
+			})),
+			"ranking": openapi.Array(doc.Schema(mvp.Entry{})),
+		})),
+	})
+
+	// Calendário
+	doc.Add("GET", "/api/v1/schedule", &openapi.Operation{
+		Tags:       []string{"Calendário"},
+		Summary:    "Partidas ainda não disputadas",
+		Parameters: []*openapi.Parameter{region, stage},
+		Responses:  ok("Calendário", openapi.Object(map[string]*openapi.Schema{"schedule": openapi.Array(scheduled)})),
+	})
+
+	// Fantasy
+	rulesetParam := openapi.Query("ruleset", "Nome do ruleset", &openapi.Schema{Type: "string", Default: models.DefaultRulesetName})
+	playerScore := doc.Schema(fantasy.PlayerScore{})
+	fantasyTotal := doc.Schema(fantasy.Total{})
+	withRuleset := func(responses map[string]*openapi.Response) map[string]*openapi.Response {
+		responses["404"] = failure("Ruleset ou registro não encontrado")
+		return responses
+	}
+	doc.Add("GET", "/api/v1/fantasy/rulesets", &openapi.Operation{
+		Tags:      []string{"Fantasy"},
+		Summary:   "Listar rulesets de fantasy",
+		Responses: ok("Rulesets", openapi.Object(map[string]*openapi.Schema{"rulesets": openapi.Array(ruleset)})),
+	})
+	doc.Add("GET", "/api/v1/fantasy/rulesets/:name", &openapi.Operation{
+		Tags:      []string{"Fantasy"},
+		Summary:   "Obter um ruleset de fantasy",
+		Responses: withRuleset(ok("Ruleset", ruleset)),
+	})
+	doc.Add("GET", "/api/v1/fantasy/matches/:matchId", &openapi.Operation{
+		Tags:       []string{"Fantasy"},
+		Summary:    "Pontos de fantasy dos jogadores de uma partida",
+		Parameters: []*openapi.Parameter{rulesetParam},
+		Responses: withRuleset(ok("Pontos por jogador", openapi.Object(map[string]*openapi.Schema{
+			"matchId": openapi.String(),
+			"ruleset": openapi.String(),
+			"players": openapi.Array(playerScore),
+		}))),
+	})
+	doc.Add("GET", "/api/v1/fantasy/players/:playerName", &openapi.Operation{
+		Tags:       []string{"Fantasy"},
+		Summary:    "Pontos de fantasy de um jogador",
+		Parameters: []*openapi.Parameter{rulesetParam, region, stage},
+		Responses: withRuleset(ok("Pontos por partida, semana e temporada", openapi.Object(map[string]*openapi.Schema{
+			"player":  openapi.String(),
+			"ruleset": openapi.String(),
+			"matches": openapi.Array(playerScore),
+			"weeks":   openapi.Array(fantasyTotal),
+			"season":  fantasyTotal,
+		}))),
+	})
+	doc.Add("GET", "/api/v1/fantasy/weeks", &openapi.Operation{
+		Tags:    []string{"Fantasy"},
+		Summary: "Pontos de fantasy por semana",
+		Parameters: []*openapi.Parameter{
+			rulesetParam, region, stage,
+			openapi.Query("week", "Semana ISO, ex.: 2025-W15", openapi.String()),
+		},
+		Responses: withRuleset(ok("Pontos por jogador e semana", openapi.Object(map[string]*openapi.Schema{
+			"ruleset": openapi.String(),
+			"weeks":   openapi.Array(fantasyTotal),
+		}))),
+	})
+	doc.Add("GET", "/api/v1/fantasy/season", &openapi.Operation{
+		Tags:       []string{"Fantasy"},
+		Summary:    "Pontos de fantasy na temporada",
+		Parameters: []*openapi.Parameter{rulesetParam, region, stage},
+		Responses: withRuleset(ok("Total por jogador", openapi.Object(map[string]*openapi.Schema{
+			"ruleset": openapi.String(),
+			"players": openapi.Array(fantasyTotal),
+		}))),
+	})
+
+	// Administração
+	doc.Add("POST", "/api/v1/admin/scrape", admin(models.ScopeScrape, &openapi.Operation{
+		Summary:   "Iniciar o scraping manualmente",
+		Responses: map[string]*openapi.Response{"200": openapi.JSON("Scraping iniciado", message)},
+	}))
+	doc.Add("POST", "/api/v1/admin/results", admin(models.ScopeWrite, &openapi.Operation{
+		Summary:     "Adicionar um resultado",
+		RequestBody: openapi.Body(match),
+		Responses: map[string]*openapi.Response{
+			"201": openapi.JSON("Resultado criado", match),
+			"400": failure("Corpo inválido"),
+			"409": failure("Já existe um resultado com o matchId, ativo ou na lixeira"),
+			"422": failure("Campos inválidos, listados em details.fields"),
+			"500": failure("Erro interno"),
+		},
+	}))
+	importResponses := ok("Relatório da importação", doc.Schema(importer.Report{}))
+	importResponses["400"] = failure("Parâmetros ou arquivo inválidos")
+	importResponses["415"] = failure("Formato não informado")
+	doc.Add("POST", "/api/v1/admin/results/bulk", admin(models.ScopeWrite, &openapi.Operation{
+		Summary: "Importar resultados em lote",
+		Parameters: []*openapi.Parameter{
+			openapi.Query("format", "Formato do corpo (padrão: pelo Content-Type)", openapi.Enum("ndjson", "json", "csv")),
+			openapi.Query("dryRun", "Apenas validar", &openapi.Schema{Type: "boolean", Default: false}),
+			openapi.Query("batchSize", "Partidas gravadas por lote", &openapi.Schema{Type: "integer", Default: importer.DefaultBatchSize}),
+		},
+		RequestBody: &openapi.RequestBody{
+			Required: true,
+			Content: map[string]openapi.MediaType{
+				"application/x-ndjson": {Schema: match},
+				"application/json":     {Schema: openapi.Array(match)},
+				"text/csv":             {Schema: openapi.String()},
+			},
+		},
+		Responses: importResponses,
+	}))
+	ifMatch := openapi.Header("If-Match", "ETag da versão lida; a escrita é rejeitada com 412 se o resultado tiver mudado")
+	updateResponses := func() map[string]*openapi.Response {
+		return map[string]*openapi.Response{
+			"200": openapi.JSON("Resultado atualizado, com a nova ETag no header", match),
+			"400": failure("Corpo inválido"),
+			"404": failure("Resultado não encontrado"),
+			"412": failure("O resultado foi alterado desde a versão informada em If-Match"),
+			"422": failure("Campos inválidos, listados em details.fields"),
+			"500": failure("Erro interno"),
+		}
+	}
+	doc.Add("PUT", "/api/v1/admin/results/:matchId", admin(models.ScopeWrite, &openapi.Operation{
+		Summary:     "Substituir um resultado",
+		Description: "Substitui o resultado inteiro. O _id, o createdAt e o matchId são preservados.",
+		Parameters:  []*openapi.Parameter{ifMatch},
+		RequestBody: openapi.Body(match),
+		Responses:   updateResponses(),
+	}))
+	patchResponses := updateResponses()
+	patchResponses["415"] = failure("Content-Type diferente de application/merge-patch+json")
+	doc.Add("PATCH", "/api/v1/admin/results/:matchId", admin(models.ScopeWrite, &openapi.Operation{
+		Summary:     "Alterar campos de um resultado",
+		Description: "Aplica um JSON Merge Patch (RFC 7386): campos omitidos são mantidos e null remove o campo.",
+		Parameters:  []*openapi.Parameter{ifMatch},
+		RequestBody: &openapi.RequestBody{
+			Required: true,
+			Content: map[string]openapi.MediaType{
+				"application/merge-patch+json": {Schema: &openapi.Schema{Type: "object"}},
+				"application/json":             {Schema: &openapi.Schema{Type: "object"}},
+			},
+		},
+		Responses: patchResponses,
+	}))
+	resultDeleteResponses := ok("Resultado movido para a lixeira", message)
+	resultDeleteResponses["404"] = failure("Resultado não encontrado")
+	doc.Add("DELETE", "/api/v1/admin/results/:matchId", admin(models.ScopeWrite, &openapi.Operation{
+		Summary:     "Excluir um resultado",
+		Description: "Move o resultado para a lixeira, de onde pode ser restaurado até o fim do período de retenção.",
+		Responses:   resultDeleteResponses,
+	}))
+	revision := doc.Schema(models.Revision{})
+	historyResponses := ok("Revisões da partida, da mais recente para a mais antiga", openapi.Object(map[string]*openapi.Schema{
+		"matchId":   openapi.String(),
+		"revisions": openapi.Array(revision),
+	}))
+	historyResponses["404"] = failure("Resultado não encontrado")
+	doc.Add("GET", "/api/v1/admin/results/:matchId/history", admin(models.ScopeRead, &openapi.Operation{
+		Summary:     "Histórico de alterações de um resultado",
+		Description: "Cada revisão traz a ação, o autor, a origem e os campos alterados. Os snapshots não são listados.",
+		Parameters: []*openapi.Parameter{
+			openapi.Query("limit", "Quantidade de revisões (padrão: 50, máximo: 500)", openapi.Integer()),
+		},
+		Responses: historyResponses,
+	}))
+	revertResponses := updateResponses()
+	revertResponses["200"] = openapi.JSON("Resultado revertido, com a nova ETag no header", match)
+	revertResponses["400"] = failure("ID de revisão inválido")
+	revertResponses["404"] = failure("Resultado ou revisão não encontrados")
+	doc.Add("POST", "/api/v1/admin/results/:matchId/history/:revisionId/revert", admin(models.ScopeWrite, &openapi.Operation{
+		Summary:     "Reverter um resultado para uma revisão",
+		Description: "Restaura o estado da partida registrado na revisão. A reversão também é registrada no histórico.",
+		Parameters:  []*openapi.Parameter{ifMatch},
+		Responses:   revertResponses,
+	}))
+	doc.Add("GET", "/api/v1/admin/trash/results", admin(models.ScopeRead, &openapi.Operation{
+		Summary: "Listar resultados na lixeira",
+		Parameters: []*openapi.Parameter{
+			openapi.Query("limit", "Itens por página", &openapi.Schema{Type: "integer", Default: 10}),
+			openapi.Query("page", "Página", &openapi.Schema{Type: "integer", Default: 1}),
+		},
+		Responses: ok("Resultados excluídos, do mais recente para o mais antigo", openapi.Object(map[string]*openapi.Schema{
+			"results": openapi.Array(match),
+			"pagination": openapi.Object(map[string]*openapi.Schema{
+				"total": openapi.Integer(),
+				"page":  openapi.Integer(),
+				"limit": openapi.Integer(),
+				"pages": openapi.Integer(),
+			}),
+		})),
+	}))
+	doc.Add("POST", "/api/v1/admin/trash/results/:matchId/restore", admin(models.ScopeWrite, &openapi.Operation{
+		Summary:     "Restaurar um resultado da lixeira",
+		Description: "A restauração fica registrada no histórico com a ação restore.",
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSON("Resultado restaurado, com a nova ETag no header", match),
+			"404": failure("Resultado não está na lixeira"),
+			"409": failure("Já existe um resultado ativo com o mesmo matchId"),
+			"500": failure("Erro interno"),
+		},
+	}))
+	doc.Add("POST", "/api/v1/admin/schedule", admin(models.ScopeWrite, &openapi.Operation{
+		Tags:        []string{"Calendário"},
+		Summary:     "Adicionar ou substituir uma partida do calendário",
+		RequestBody: openapi.Body(scheduled),
+		Responses: map[string]*openapi.Response{
+			"201": openapi.JSON("Partida salva", scheduled),
+			"400": failure("Corpo inválido"),
+			"422": failure("Campos inválidos, listados em details.fields"),
+			"500": failure("Erro interno"),
+		},
+	}))
+	doc.Add("DELETE", "/api/v1/admin/schedule/:matchId", admin(models.ScopeWrite, &openapi.Operation{
+		Tags:      []string{"Calendário"},
+		Summary:   "Remover uma partida do calendário",
+		Responses: ok("Partida removida", message),
+	}))
+	doc.Add("PUT", "/api/v1/admin/fantasy/rulesets/:name", admin(models.ScopeWrite, &openapi.Operation{
+		Tags:        []string{"Fantasy"},
+		Summary:     "Criar ou substituir um ruleset de fantasy",
+		RequestBody: openapi.Body(ruleset),
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSON("Ruleset salvo", ruleset),
+			"400": failure("Corpo inválido"),
+			"500": failure("Erro interno"),
+		},
+	}))
+	doc.Add("DELETE", "/api/v1/admin/fantasy/rulesets/:name", admin(models.ScopeWrite, &openapi.Operation{
+		Tags:      []string{"Fantasy"},
+		Summary:   "Excluir um ruleset de fantasy",
+		Responses: ok("Ruleset excluído", message),
+	}))
+
+	// Webhooks
+	deliveries := openapi.Object(map[string]*openapi.Schema{"deliveries": openapi.Array(delivery)})
+	limit := openapi.Query("limit", "Quantidade máxima de entregas", &openapi.Schema{Type: "integer", Default: 50})
+	doc.Add("POST", "/api/v1/admin/webhooks", admin(models.ScopeAdmin, &openapi.Operation{
+		Tags:        []string{"Webhooks"},
+		Summary:     "Registrar um webhook",
+		RequestBody: openapi.Body(webhook),
+		Responses: map[string]*openapi.Response{
+			"201": openapi.JSON("Webhook criado; o segredo só é exibido nesta resposta", webhook),
+			"400": failure("Corpo inválido"),
+			"500": failure("Erro interno"),
+		},
+	}))
+	doc.Add("GET", "/api/v1/admin/webhooks", admin(models.ScopeAdmin, &openapi.Operation{
+		Tags:      []string{"Webhooks"},
+		Summary:   "Listar webhooks",
+		Responses: ok("Webhooks", openapi.Object(map[string]*openapi.Schema{"webhooks": openapi.Array(webhook)})),
+	}))
+	deleteResponses := ok("Webhook excluído", message)
+	deleteResponses["400"] = failure("ID inválido")
+	deleteResponses["404"] = failure("Webhook não encontrado")
+	doc.Add("DELETE", "/api/v1/admin/webhooks/:id", admin(models.ScopeAdmin, &openapi.Operation{
+		Tags:      []string{"Webhooks"},
+		Summary:   "Excluir um webhook",
+		Responses: deleteResponses,
+	}))
+	listResponses := ok("Entregas da mais recente para a mais antiga", deliveries)
+	listResponses["400"] = failure("webhookId inválido")
+	doc.Add("GET", "/api/v1/admin/webhooks/deliveries", admin(models.ScopeAdmin, &openapi.Operation{
+		Tags:    []string{"Webhooks"},
+		Summary: "Log de entregas",
+		Parameters: []*openapi.Parameter{
+			openapi.Query("webhookId", "Filtrar por webhook", openapi.String()),
+			openapi.Query("status", "Filtrar por situação", openapi.Enum(models.DeliveryPending, models.DeliveryDelivered, models.DeliveryDead)),
+			limit,
+		},
+		Responses: listResponses,
+	}))
+	doc.Add("GET", "/api/v1/admin/webhooks/dead-letters", admin(models.ScopeAdmin, &openapi.Operation{
+		Tags:       []string{"Webhooks"},
+		Summary:    "Entregas que esgotaram as tentativas",
+		Parameters: []*openapi.Parameter{limit},
+		Responses:  ok("Entregas mortas", deliveries),
+	}))
+	retryResponses := ok("Entrega reenfileirada", message)
+	retryResponses["400"] = failure("ID inválido")
+	retryResponses["404"] = failure("Entrega não encontrada na fila de mortas")
+	doc.Add("POST", "/api/v1/admin/webhooks/deliveries/:id/retry", admin(models.ScopeAdmin, &openapi.Operation{
+		Tags:      []string{"Webhooks"},
+		Summary:   "Reenfileirar uma entrega da fila de mortas",
+		Responses: retryResponses,
+	}))
+
+	// API keys
+	apiKey := doc.Schema(models.APIKey{})
+	// A criação e a rotação retornam a API key com o segredo em key
+	issuedSchema := *doc.Components.Schemas["APIKey"]
+	issuedSchema.Properties = map[string]*openapi.Schema{
+		"key": {Type: "string", Description: "Chave completa, exibida apenas nesta resposta"},
+	}
+	for name, property := range doc.Components.Schemas["APIKey"].Properties {
+		issuedSchema.Properties[name] = property
+	}
+	issuedSchema.Required = append([]string{"key"}, issuedSchema.Required...)
+	issued := doc.Define("IssuedAPIKey", &issuedSchema)
+	keyResponses := func(status, description string) map[string]*openapi.Response {
+		return map[string]*openapi.Response{
+			status: openapi.JSON(description, issued),
+			"500":  failure("Erro interno"),
+		}
+	}
+	createKeyResponses := keyResponses("201", "API key criada")
+	createKeyResponses["400"] = failure("Nome, escopos, cota ou expiração inválidos")
+	doc.Add("POST", "/api/v1/admin/keys", admin(models.ScopeAdmin, &openapi.Operation{
+		Tags:    []string{"API keys"},
+		Summary: "Criar uma API key",
+		RequestBody: openapi.Body(openapi.Object(map[string]*openapi.Schema{
+			"name":       openapi.String(),
+			"owner":      {Type: "string", Description: "Registrado como autor no histórico (padrão: name)"},
+			"scopes":     openapi.Array(openapi.Enum(models.Scopes...)),
+			"dailyQuota": {Type: "integer", Description: "Requisições por dia (UTC); 0 não limita"},
+			"expiresAt":  {Type: "string", Format: "date-time"},
+		})),
+		Responses: createKeyResponses,
+	}))
+	doc.Add("GET", "/api/v1/admin/keys", admin(models.ScopeAdmin, &openapi.Operation{
+		Tags:      []string{"API keys"},
+		Summary:   "Listar API keys",
+		Responses: ok("API keys, sem os segredos", openapi.Object(map[string]*openapi.Schema{"apiKeys": openapi.Array(apiKey)})),
+	}))
+	rotateResponses := keyResponses("200", "Novo segredo da API key")
+	rotateResponses["400"] = failure("ID inválido")
+	rotateResponses["404"] = failure("API key não encontrada ou revogada")
+	doc.Add("POST", "/api/v1/admin/keys/:id/rotate", admin(models.ScopeAdmin, &openapi.Operation{
+		Tags:        []string{"API keys"},
+		Summary:     "Rotacionar uma API key",
+		Description: "Gera um novo segredo mantendo nome, escopos e cota. O segredo anterior deixa de valer.",
+		Responses:   rotateResponses,
+	}))
+	revokeResponses := ok("API key revogada", message)
+	revokeResponses["400"] = failure("ID inválido")
+	revokeResponses["404"] = failure("API key não encontrada ou já revogada")
+	doc.Add("DELETE", "/api/v1/admin/keys/:id", admin(models.ScopeAdmin, &openapi.Operation{
+		Tags:      []string{"API keys"},
+		Summary:   "Revogar uma API key",
+		Responses: revokeResponses,
+	}))
+
+	// Todas as rotas da API passam pelo rate limit. As públicas autenticam as
+	// credenciais enviadas para limitar pela API key ou pelo token, mas, se forem
+	// rejeitadas, limitam pelo IP em vez de responder 401.
+	for path, operations := range doc.Paths {
+		if !strings.HasPrefix(path, "/api/v1/") {
+			continue
+		}
+		for _, op := range operations {
+			if _, ok := op.Responses["429"]; !ok {
+				op.Responses["429"] = failure("Limite de requisições esgotado; veja os headers RateLimit-* e Retry-After")
+			}
+		}
+	}
+
+	return doc
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/bulletdev/lta-results-api/config"
+	"github.com/bulletdev/lta-results-api/openapi"
+	"github.com/gin-gonic/gin"
+)
+
+// TestOpenAPISpecMatchesRoutes falha quando uma rota é registrada sem documentação ou
+// quando a especificação descreve uma rota que não existe mais
+func TestOpenAPISpecMatchesRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router, err := SetupRouter(config.Defaults())
+	if err != nil {
+		t.Fatalf("erro ao montar as rotas: %v", err)
+	}
+	var routes []string
+	for _, route := range router.Routes() {
+		routes = append(routes, route.Method+" "+openapi.Path(route.Path))
+	}
+	sort.Strings(routes)
+
+	documented := OpenAPISpec().Operations()
+
+	registered := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		registered[route] = true
+	}
+	inSpec := make(map[string]bool, len(documented))
+	for _, op := range documented {
+		inSpec[op] = true
+	}
+
+	for _, route := range routes {
+		if !inSpec[route] {
+			t.Errorf("rota sem documentação na especificação OpenAPI: %s", route)
+		}
+	}
+	for _, op := range documented {
+		if !registered[op] {
+			t.Errorf("operação documentada sem rota correspondente: %s", op)
+		}
+	}
+}
+
+// TestOpenAPISpecReferences garante que todos os $ref apontam para schemas definidos
+func TestOpenAPISpecReferences(t *testing.T) {
+	doc := OpenAPISpec()
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("erro ao serializar especificação: %v", err)
+	}
+
+	for _, match := range regexp.MustCompile(`"\$ref":"([^"]+)"`).FindAllStringSubmatch(string(data), -1) {
+		name := strings.TrimPrefix(match[1], "#/components/schemas/")
+		if _, ok := doc.Components.Schemas[name]; !ok {
+			t.Errorf("referência para schema inexistente: %s", match[1])
+		}
+	}
+
+	for _, name := range []string{"MatchResult", "Player", "PlayerStats", "TeamStats", "Error"} {
+		if _, ok := doc.Components.Schemas[name]; !ok {
+			t.Errorf("schema %s ausente da especificação", name)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/bulletdev/lta-results-api/models"
+	"github.com/bulletdev/lta-results-api/prediction"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func PredictMatch(c *gin.Context) {
+	teamA := c.Query("teamA")
+	teamB := c.Query("teamB")
+	region := c.Query("region")
+	bestOf, err := strconv.Atoi(c.DefaultQuery("bestOf", "1"))
+	if err != nil || (bestOf != 1 && bestOf != 3 && bestOf != 5) {
+		badRequest(c, "best_of_invalid")
+		return
+	}
+
+	if teamA == "" || teamB == "" || teamA == teamB {
+		badRequest(c, "teams_invalid")
+		return
+	}
+
+	// Construir o modelo com todos os resultados armazenados
+	filter := bson.M{}
+	if region != "" {
+		filter["region"] = region
+	}
+
+	matches, err := models.GetAllMatchResults(filter)
+	if err != nil {
+		storeError(c, err, "results_fetch_failed")
+		return
+	}
+
+	model := prediction.Build(matches)
+	for _, team := range []string{teamA, teamB} {
+		if !model.Known(team) {
+			notFound(c, "team_not_found", team)
+			return
+		}
+	}
+
+	result, err := model.Predict(teamA, teamB, bestOf)
+	if err != nil {
+		badRequest(c, "best_of_invalid")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/ratelimit"
+	"github.com/gin-gonic/gin"
+)
+
+// mustParseLimit interpreta o limite do grupo, já verificado por config.Validate
+func mustParseLimit(group, raw string) ratelimit.Limit {
+	limit, err := ratelimit.ParseLimit(raw)
+	if err != nil {
+		log.Fatalf("Limite do grupo %s inválido: %v", group, err)
+	}
+	return limit
+}
+
+// newRateLimitStore cria o armazenamento dos buckets do backend configurado
+func newRateLimitStore(backend string) ratelimit.Store {
+	store, err := ratelimit.NewStore(backend)
+	if err != nil {
+		log.Fatalf("Erro ao configurar o rate limit: %v", err)
+	}
+	return store
+}
+
+// credentialKey é a chave, no contexto do gin, da credencial autenticada, usada para
+// limitar as requisições por API key ou usuário em vez de por IP
+const credentialKey = "credential"
+
+// RateLimit limita as requisições do grupo com um token bucket por cliente: a
+// credencial autenticada, quando houver, ou o IP. Informa o estado do bucket nos
+// headers RateLimit-* e responde 429 quando ele se esgota.
+func RateLimit(store ratelimit.Store, group string, limit ratelimit.Limit) gin.HandlerFunc {
+	return rateLimit(store, group, limit, func(c *gin.Context) string {
+		if credential := c.GetString(credentialKey); credential != "" {
+			return credential
+		}
+		return "ip:" + c.ClientIP()
+	})
+}
+
+// RateLimitByIP limita as requisições do grupo por IP, mesmo antes da autenticação,
+// para que credenciais inválidas não escapem do limite
+func RateLimitByIP(store ratelimit.Store, group string, limit ratelimit.Limit) gin.HandlerFunc {
+	return rateLimit(store, group, limit, func(c *gin.Context) string {
+		return "ip:" + c.ClientIP()
+	})
+}
+
+func rateLimit(store ratelimit.Store, group string, limit ratelimit.Limit, clientOf func(*gin.Context) string) gin.HandlerFunc {
+	if !limit.Enabled() {
+		log.Printf("Rate limit do grupo %s desativado", group)
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	policy := limit.Policy()
+	return func(c *gin.Context) {
+		client := clientOf(c)
+
+		result, err := store.Take(c.Request.Context(), group+":"+client, limit, time.Now())
+		if err != nil {
+			// Uma falha do armazenamento não deve derrubar a API
+			log.Printf("[%s] Erro no rate limit do grupo %s: %v", c.GetString(requestIDKey), group, err)
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Policy", policy)
+		c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+
+		if !result.Allowed {
+			retryAfter := ceilSeconds(result.RetryAfter)
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			respondError(c, newError(http.StatusTooManyRequests, "rate_limited", retryAfter).with("retryAfter", retryAfter))
+			return
+		}
+		c.Next()
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
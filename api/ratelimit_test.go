@@ -0,0 +1,59 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/ratelimit"
+	"github.com/gin-gonic/gin"
+)
+
+func TestRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	limit := ratelimit.Limit{Requests: 2, Period: time.Minute}
+	router := gin.New()
+	router.Use(RateLimitByIP(ratelimit.NewMemoryStore(), "test", limit))
+	router.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for i := 1; i >= 0; i-- {
+		w := request(router, "/")
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, esperado 200", w.Code)
+		}
+		if got := w.Header().Get("RateLimit-Remaining"); got != strconv.Itoa(i) {
+			t.Errorf("RateLimit-Remaining = %s, esperado %d", got, i)
+		}
+		if got := w.Header().Get("RateLimit-Policy"); got != "2;w=60" {
+			t.Errorf("RateLimit-Policy = %s, esperado 2;w=60", got)
+		}
+	}
+
+	w := request(router, "/")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, esperado 429", w.Code)
+	}
+	if code := errorCode(t, w); code != "rate_limited" {
+		t.Errorf("código = %s, esperado rate_limited", code)
+	}
+	// Um token a cada 30 segundos
+	if got := w.Header().Get("Retry-After"); got != "30" {
+		t.Errorf("Retry-After = %s, esperado 30", got)
+	}
+}
+
+func TestRateLimitDisabled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(RateLimitByIP(ratelimit.NewMemoryStore(), "test", ratelimit.Limit{}))
+	router.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for i := 0; i < 5; i++ {
+		if w := request(router, "/"); w.Code != http.StatusOK || w.Header().Get("RateLimit-Limit") != "" {
+			t.Fatalf("limite desativado aplicado: status %d", w.Code)
+		}
+	}
+}
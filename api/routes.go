@@ -1,13 +1,18 @@
 package api
 
 import (
-	"time"
+	"fmt"
+	"net/http"
 
+	"github.com/bulletdev/lta-results-api/config"
+	"github.com/bulletdev/lta-results-api/models"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRouter() *gin.Engine {
+// SetupRouter monta as rotas da API com a configuração informada, que deve ter passado
+// por config.Validate
+func SetupRouter(cfg *config.Config) (*gin.Engine, error) {
 	// Configurar modo de execução
 	if gin.Mode() == gin.DebugMode {
 		gin.SetMode(gin.DebugMode)
@@ -17,33 +22,42 @@ func SetupRouter() *gin.Engine {
 
 	router := gin.Default()
 
+	// Sem proxies confiáveis o X-Forwarded-For é ignorado, para que o cliente não
+	// escolha o IP usado no rate limit
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("proxies confiáveis inválidos: %w", err)
+	}
+
 	// Configurar CORS
-	config := cors.DefaultConfig()
-	config.AllowAllOrigins = true
-	config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
-	config.AllowHeaders = []string{"Origin", "Content-Type", "Authorization", "X-API-Key"}
-	router.Use(cors.New(config))
+	corsConfig := cors.DefaultConfig()
+	if cfg.CORS.AllowAll() {
+		corsConfig.AllowAllOrigins = true
+	} else {
+		corsConfig.AllowOrigins = cfg.CORS.AllowedOrigins
+	}
+	corsConfig.AllowMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+	corsConfig.AllowHeaders = []string{"Origin", "Content-Type", "Authorization", "X-API-Key", "X-Request-ID", "Accept-Language", "If-Match", "If-None-Match"}
+	corsConfig.ExposeHeaders = []string{"X-Request-ID", "Content-Language", "ETag", "X-Cache", "Retry-After",
+		"RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy"}
+	router.Use(cors.New(corsConfig))
+	router.Use(RequestID())
+
+	// Rotas inexistentes usam o mesmo envelope de erro da API
+	router.NoRoute(func(c *gin.Context) {
+		respondError(c, newError(http.StatusNotFound, "route_not_found"))
+	})
 
 	// Middleware de autenticação para rotas protegidas
-	authMiddleware := AuthMiddleware()
+	authMiddleware, err := AuthMiddleware(cfg.Auth)
+	if err != nil {
+		return nil, err
+	}
 
-	// Health Check
-	router.GET("/health", func(c *gin.Context) {
-		// Adicionar headers importantes
-		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Methods", "GET, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Accept")
-		c.Header("Cache-Control", "no-cache, no-store, must-revalidate")
-		c.Header("Pragma", "no-cache")
-		c.Header("Expires", "0")
-
-		// Responder com status 200 e dados
-		c.JSON(200, gin.H{
-			"status":  "healthy",
-			"time":    time.Now().Format(time.RFC3339),
-			"version": "1.0.0",
-		})
-	})
+	// Health checks: /livez para o processo, /readyz para receber tráfego e /health com
+	// o relatório detalhado das dependências
+	router.GET("/livez", Livez)
+	router.GET("/readyz", Readyz)
+	router.GET("/health", HealthCheck(cfg))
 
 	// Adicionar rota OPTIONS para o health check
 	router.OPTIONS("/health", func(c *gin.Context) {
@@ -53,29 +67,96 @@ func SetupRouter() *gin.Engine {
 		c.Status(204)
 	})
 
-	// Rotas públicas
+	// Especificação OpenAPI e documentação interativa
+	router.GET("/openapi.json", GetOpenAPISpec)
+	router.GET("/docs", GetAPIDocs)
+
+	// Rotas públicas, limitadas pela API key ou pelo token, quando enviados, ou pelo IP.
+	// As credenciais passam antes por um limite por IP, para que as inválidas também
+	// sejam contidas. As estatísticas e análises, mais custosas para o banco, têm um
+	// limite próprio.
+	rateLimits := newRateLimitStore(cfg.RateLimit.Backend)
+	authLimit := RateLimitByIP(rateLimits, "auth", mustParseLimit("auth", cfg.RateLimit.Auth))
+	responseCache := newResponseCache(cfg.Cache)
 	v1 := router.Group("/api/v1")
+	identify := []gin.HandlerFunc{WithCredentials(authLimit), WithCredentials(authMiddleware)}
+	public := v1.Group("", append(identify, RateLimit(rateLimits, "public", mustParseLimit("public", cfg.RateLimit.Public)))...)
+	stats := v1.Group("", append(identify, RateLimit(rateLimits, "stats", mustParseLimit("stats", cfg.RateLimit.Stats)))...)
 	{
 		// Resultados de partidas
-		v1.GET("/results", GetMatchResults)
-		v1.GET("/results/:matchId", GetMatchResultByID)
+		public.GET("/results", CacheResponse(responseCache, cfg.Cache, resultsCacheTags), GetMatchResults)
+		public.GET("/results/:matchId", GetMatchResultByID)
+
+		// Stream de alterações em tempo real
+		public.GET("/stream", StreamEvents)
+		public.GET("/stream/ws", StreamEventsWebSocket)
+
+		// GraphQL
+		public.GET("/graphql", GraphQL)
+		public.POST("/graphql", GraphQL)
 
 		// Estatísticas de jogadores
-		v1.GET("/players/:playerName/stats", GetPlayerStats)
+		stats.GET("/players/:playerName/stats", CacheResponse(responseCache, cfg.Cache, playerCacheTags), GetPlayerStats)
 
 		// Estatísticas de times
-		v1.GET("/teams/:teamName/stats", GetTeamStats)
+		stats.GET("/teams/:teamName/stats", CacheResponse(responseCache, cfg.Cache, teamCacheTags), GetTeamStats)
+
+		// Previsão de confrontos
+		stats.GET("/predict", PredictMatch)
 
-		// Rotas protegidas (admin)
+		// Sinergias e counters de campeões
+		stats.GET("/champions/synergy", GetChampionSynergy)
+		stats.GET("/champions/counters", GetChampionCounters)
+
+		// MVPs
+		stats.GET("/mvp", GetMVPRanking)
+
+		// Calendário e simulações
+		public.GET("/schedule", GetSchedule)
+		stats.GET("/simulations/playoffs", SimulatePlayoffs)
+
+		// Fantasy
+		public.GET("/fantasy/rulesets", GetFantasyRulesets)
+		public.GET("/fantasy/rulesets/:name", GetFantasyRuleset)
+		public.GET("/fantasy/matches/:matchId", GetFantasyMatchPoints)
+		stats.GET("/fantasy/players/:playerName", GetFantasyPlayerPoints)
+		stats.GET("/fantasy/weeks", GetFantasyWeeklyPoints)
+		stats.GET("/fantasy/season", GetFantasySeasonPoints)
+
+		// Rotas protegidas (admin). Cada rota exige um escopo da API key.
 		admin := v1.Group("/admin")
-		admin.Use(authMiddleware)
+		admin.Use(authLimit, authMiddleware, RateLimit(rateLimits, "admin", mustParseLimit("admin", cfg.RateLimit.Admin)))
 		{
-			admin.POST("/scrape", TriggerScraping)
-			admin.POST("/results", CreateMatchResult)
-			admin.PUT("/results/:matchId", UpdateMatchResult)
-			admin.DELETE("/results/:matchId", DeleteMatchResult)
+			read := RequireScope(models.ScopeRead)
+			write := RequireScope(models.ScopeWrite)
+			manage := RequireScope(models.ScopeAdmin)
+
+			admin.POST("/scrape", RequireScope(models.ScopeScrape), TriggerScraping)
+			admin.POST("/results", write, CreateMatchResult)
+			admin.POST("/results/bulk", write, BulkImportMatchResults)
+			admin.PUT("/results/:matchId", write, UpdateMatchResult)
+			admin.PATCH("/results/:matchId", write, PatchMatchResult)
+			admin.DELETE("/results/:matchId", write, DeleteMatchResult)
+			admin.GET("/results/:matchId/history", read, GetMatchResultHistory)
+			admin.POST("/results/:matchId/history/:revisionId/revert", write, RevertMatchResult)
+			admin.GET("/trash/results", read, GetDeletedMatchResults)
+			admin.POST("/trash/results/:matchId/restore", write, RestoreMatchResult)
+			admin.POST("/schedule", write, CreateScheduledMatch)
+			admin.DELETE("/schedule/:matchId", write, DeleteScheduledMatch)
+			admin.PUT("/fantasy/rulesets/:name", write, SaveFantasyRuleset)
+			admin.DELETE("/fantasy/rulesets/:name", write, DeleteFantasyRuleset)
+			admin.POST("/webhooks", manage, CreateWebhook)
+			admin.GET("/webhooks", manage, GetWebhooks)
+			admin.DELETE("/webhooks/:id", manage, DeleteWebhook)
+			admin.GET("/webhooks/deliveries", manage, GetWebhookDeliveries)
+			admin.GET("/webhooks/dead-letters", manage, GetWebhookDeadLetters)
+			admin.POST("/webhooks/deliveries/:id/retry", manage, RetryWebhookDelivery)
+			admin.POST("/keys", manage, CreateAPIKey)
+			admin.GET("/keys", manage, GetAPIKeys)
+			admin.POST("/keys/:id/rotate", manage, RotateAPIKey)
+			admin.DELETE("/keys/:id", manage, RevokeAPIKey)
 		}
 	}
 
-	return router
+	return router, nil
 }
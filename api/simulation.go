@@ -0,0 +1,143 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/models"
+	"github.com/bulletdev/lta-results-api/simulation"
+	"github.com/bulletdev/lta-results-api/validation"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Limites do simulador de playoffs
+const (
+	defaultSimulationRuns = 10000
+	maxSimulationRuns     = 100000
+	defaultPlayoffSpots   = 6
+)
+
+func SimulatePlayoffs(c *gin.Context) {
+	region := c.Query("region")
+	stage := c.Query("stage")
+	if region == "" {
+		badRequest(c, "region_required")
+		return
+	}
+
+	runs, err := strconv.Atoi(c.DefaultQuery("runs", strconv.Itoa(defaultSimulationRuns)))
+	if err != nil || runs < 1 || runs > maxSimulationRuns {
+		badRequest(c, "runs_invalid", maxSimulationRuns)
+		return
+	}
+
+	spots, err := strconv.Atoi(c.DefaultQuery("spots", strconv.Itoa(defaultPlayoffSpots)))
+	if err != nil || spots < 1 {
+		badRequest(c, "spots_invalid")
+		return
+	}
+
+	// Sem seed informada, gerar uma e devolvê-la para permitir reproduzir o resultado
+	seed := time.Now().UnixNano()
+	if raw := c.Query("seed"); raw != "" {
+		seed, err = strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			badRequest(c, "seed_invalid")
+			return
+		}
+	}
+
+	filter := bson.M{"region": region}
+	if stage != "" {
+		filter["tournamentStage"] = stage
+	}
+
+	played, err := models.GetAllMatchResults(filter)
+	if err != nil {
+		storeError(c, err, "results_fetch_failed")
+		return
+	}
+
+	scheduled, err := models.GetScheduledMatches(filter)
+	if err != nil {
+		storeError(c, err, "schedule_fetch_failed")
+		return
+	}
+
+	// Partidas do calendário que já possuem resultado não são simuladas
+	playedIDs := make(map[string]bool, len(played))
+	for _, match := range played {
+		playedIDs[match.MatchID] = true
+	}
+	var remaining []models.ScheduledMatch
+	for _, match := range scheduled {
+		if !playedIDs[match.MatchID] {
+			remaining = append(remaining, match)
+		}
+	}
+
+	if len(played) == 0 && len(remaining) == 0 {
+		notFound(c, "region_matches_not_found")
+		return
+	}
+
+	report := simulation.Run(played, remaining, simulation.Options{
+		Runs:         runs,
+		Seed:         seed,
+		PlayoffSpots: spots,
+	})
+
+	c.JSON(http.StatusOK, report)
+}
+
+func GetSchedule(c *gin.Context) {
+	filter := bson.M{}
+	if region := c.Query("region"); region != "" {
+		filter["region"] = region
+	}
+	if stage := c.Query("stage"); stage != "" {
+		filter["tournamentStage"] = stage
+	}
+
+	matches, err := models.GetScheduledMatches(filter)
+	if err != nil {
+		storeError(c, err, "schedule_fetch_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"schedule": matches})
+}
+
+func CreateScheduledMatch(c *gin.Context) {
+	var match models.ScheduledMatch
+	if err := c.ShouldBindJSON(&match); err != nil {
+		invalidBody(c, err)
+		return
+	}
+
+	if errs := validation.ScheduledMatch(&match); errs != nil {
+		invalidFields(c, errs)
+		return
+	}
+
+	match.CreatedAt = time.Now()
+	if err := models.UpsertScheduledMatch(&match); err != nil {
+		storeError(c, err, "schedule_save_failed")
+		return
+	}
+
+	c.JSON(http.StatusCreated, match)
+}
+
+func DeleteScheduledMatch(c *gin.Context) {
+	matchID := c.Param("matchId")
+
+	if err := models.DeleteScheduledMatch(matchID); err != nil {
+		storeError(c, err, "schedule_delete_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Partida removida do calendário"})
+}
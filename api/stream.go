@@ -0,0 +1,153 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/events"
+	"github.com/gin-gonic/gin"
+	"github.com/gobwas/ws"
+	"github.com/gobwas/ws/wsutil"
+)
+
+// Intervalo entre heartbeats enviados para manter a conexão aberta
+const streamHeartbeat = 15 * time.Second
+
+func StreamEvents(c *gin.Context) {
+	replay, sub := events.Default.Subscribe(streamFilter(c), lastEventID(c))
+	defer sub.Close()
+
+	w := c.Writer
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	w.Flush()
+
+	// Reenviar os eventos perdidos desde o Last-Event-ID informado
+	for _, e := range replay {
+		if err := writeSSE(w, e); err != nil {
+			return
+		}
+	}
+	w.Flush()
+
+	ticker := time.NewTicker(streamHeartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case e, ok := <-sub.C:
+			if !ok {
+				return
+			}
+			if err := writeSSE(w, e); err != nil {
+				return
+			}
+			w.Flush()
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+			w.Flush()
+		}
+	}
+}
+
+func StreamEventsWebSocket(c *gin.Context) {
+	replay, sub := events.Default.Subscribe(streamFilter(c), lastEventID(c))
+	defer sub.Close()
+
+	conn, _, _, err := ws.UpgradeHTTP(c.Request, c.Writer)
+	if err != nil {
+		log.Printf("Erro ao abrir conexão WebSocket: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	// Ler as mensagens do cliente apenas para detectar o fechamento da conexão
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := wsutil.ReadClientData(conn); err != nil {
+				return
+			}
+		}
+	}()
+
+	send := func(e events.Event) error {
+		payload, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		return wsutil.WriteServerText(conn, payload)
+	}
+
+	for _, e := range replay {
+		if err := send(e); err != nil {
+			return
+		}
+	}
+
+	ticker := time.NewTicker(streamHeartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case e, ok := <-sub.C:
+			if !ok {
+				return
+			}
+			if err := send(e); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := wsutil.WriteServerMessage(conn, ws.OpPing, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// streamFilter monta o filtro de inscrição a partir de region e team (repetido ou separado por vírgula)
+func streamFilter(c *gin.Context) events.Filter {
+	filter := events.Filter{Region: c.Query("region")}
+	for _, value := range c.QueryArray("team") {
+		for _, team := range strings.Split(value, ",") {
+			if team = strings.TrimSpace(team); team != "" {
+				filter.Teams = append(filter.Teams, team)
+			}
+		}
+	}
+	return filter
+}
+
+// lastEventID lê o último evento recebido pelo cliente do header Last-Event-ID ou do parâmetro lastEventId
+func lastEventID(c *gin.Context) uint64 {
+	raw := c.GetHeader("Last-Event-ID")
+	if raw == "" {
+		raw = c.Query("lastEventId")
+	}
+	id, _ := strconv.ParseUint(strings.TrimSpace(raw), 10, 64)
+	return id
+}
+
+func writeSSE(w gin.ResponseWriter, e events.Event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, payload)
+	return err
+}
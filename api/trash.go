@@ -0,0 +1,54 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/bulletdev/lta-results-api/models"
+	"github.com/gin-gonic/gin"
+)
+
+// GetDeletedMatchResults lista as partidas na lixeira, com paginação
+func GetDeletedMatchResults(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+
+	results, total, err := models.GetDeletedMatchResults(int64((page-1)*limit), int64(limit))
+	if err != nil {
+		storeError(c, err, "trash_fetch_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"results": results,
+		"pagination": gin.H{
+			"total": total,
+			"page":  page,
+			"limit": limit,
+			"pages": (total + int64(limit) - 1) / int64(limit),
+		},
+	})
+}
+
+// RestoreMatchResult tira uma partida da lixeira
+func RestoreMatchResult(c *gin.Context) {
+	restored, err := models.RestoreMatchResult(c.Param("matchId"), author(c, models.SourceAdmin))
+	if err != nil {
+		if errors.Is(err, models.ErrMatchExists) {
+			respondError(c, newError(http.StatusConflict, "result_exists"))
+			return
+		}
+		lookupError(c, err, "trash_result_not_found", "result_restore_failed")
+		return
+	}
+
+	c.Header("ETag", matchETag(restored))
+	c.JSON(http.StatusOK, restored)
+}
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/events"
+	"github.com/bulletdev/lta-results-api/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func CreateWebhook(c *gin.Context) {
+	var webhook models.Webhook
+	if err := c.ShouldBindJSON(&webhook); err != nil {
+		invalidBody(c, err)
+		return
+	}
+
+	parsed, err := url.Parse(webhook.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		badRequest(c, "webhook_url_invalid")
+		return
+	}
+
+	if len(webhook.Events) == 0 {
+		respondError(c, newError(http.StatusBadRequest, "webhook_events_required").with("validEvents", events.Types))
+		return
+	}
+	for _, eventType := range webhook.Events {
+		if !events.ValidType(eventType) {
+			respondError(c, newError(http.StatusBadRequest, "webhook_event_unknown", eventType).with("validEvents", events.Types))
+			return
+		}
+	}
+
+	// Gerar um segredo quando o administrador não informar um
+	if webhook.Secret == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			respondError(c, newError(http.StatusInternalServerError, "webhook_secret_failed"))
+			return
+		}
+		webhook.Secret = hex.EncodeToString(secret)
+	}
+
+	now := time.Now()
+	webhook.ID = primitive.NewObjectID()
+	webhook.Active = true
+	webhook.CreatedAt = now
+	webhook.UpdatedAt = now
+
+	if err := models.CreateWebhook(&webhook); err != nil {
+		storeError(c, err, "webhook_create_failed")
+		return
+	}
+
+	// O segredo é exibido apenas na criação
+	c.JSON(http.StatusCreated, webhook)
+}
+
+func GetWebhooks(c *gin.Context) {
+	webhooks, err := models.GetWebhooks(bson.M{})
+	if err != nil {
+		storeError(c, err, "webhook_fetch_failed")
+		return
+	}
+
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+
+	c.JSON(http.StatusOK, gin.H{"webhooks": webhooks})
+}
+
+func DeleteWebhook(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		badRequest(c, "invalid_id")
+		return
+	}
+
+	if err := models.DeleteWebhook(id); err != nil {
+		lookupError(c, err, "webhook_not_found", "webhook_delete_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook excluído com sucesso"})
+}
+
+func GetWebhookDeliveries(c *gin.Context) {
+	filter := bson.M{}
+	if status := c.Query("status"); status != "" {
+		filter["status"] = status
+	}
+	if raw := c.Query("webhookId"); raw != "" {
+		id, err := primitive.ObjectIDFromHex(raw)
+		if err != nil {
+			badRequest(c, "webhook_id_invalid")
+			return
+		}
+		filter["webhookId"] = id
+	}
+	listDeliveries(c, filter)
+}
+
+func GetWebhookDeadLetters(c *gin.Context) {
+	listDeliveries(c, bson.M{"status": models.DeliveryDead})
+}
+
+func RetryWebhookDelivery(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		badRequest(c, "invalid_id")
+		return
+	}
+
+	if err := models.RequeueWebhookDelivery(id); err != nil {
+		lookupError(c, err, "dead_letter_not_found", "delivery_requeue_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Entrega reenfileirada com sucesso"})
+}
+
+// listDeliveries responde com as entregas mais recentes que atendem ao filtro
+func listDeliveries(c *gin.Context, filter bson.M) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if limit < 1 || limit > 500 {
+		limit = 50
+	}
+
+	findOptions := options.Find().SetSort(bson.M{"createdAt": -1}).SetLimit(int64(limit))
+	deliveries, err := models.GetWebhookDeliveries(filter, findOptions)
+	if err != nil {
+		storeError(c, err, "deliveries_fetch_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deliveries": deliveries})
+}
@@ -0,0 +1,53 @@
+// Package apikeys mantém as contagens de uso das API keys, que sustentam as cotas
+// diárias: conta os usos em memória, grava-os periodicamente e exclui as contagens dos
+// dias que já passaram do período de retenção.
+package apikeys
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/config"
+	"github.com/bulletdev/lta-results-api/models"
+)
+
+// Intervalo entre as limpezas das contagens antigas
+const purgeInterval = time.Hour
+
+// Start inicia a gravação periódica dos usos e a limpeza das contagens antigas até
+// ctx ser cancelado
+func Start(ctx context.Context, cfg config.APIKeys) {
+	go flushUsage(ctx, cfg.UsageFlushInterval.Duration)
+	go purge(ctx, cfg.UsageRetentionDays)
+	log.Printf("Contagem de uso das API keys iniciada (gravação a cada %s, retenção de %d dias)",
+		cfg.UsageFlushInterval.Duration, cfg.UsageRetentionDays)
+}
+
+func purge(ctx context.Context, days int) {
+	ticker := time.NewTicker(purgeInterval)
+	defer ticker.Stop()
+
+	for {
+		Purge(days)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Purge exclui as contagens de uso com mais de days dias. A do dia corrente, usada
+// pela cota, é sempre mantida.
+func Purge(days int) {
+	purged, err := models.PurgeAPIKeyUsage(time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		log.Printf("Erro ao limpar o uso das API keys: %v", err)
+		return
+	}
+	if purged > 0 {
+		log.Printf("%d contagens de uso de API keys excluídas", purged)
+	}
+}
@@ -0,0 +1,135 @@
+package apikeys
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// usageKey identifica a contagem de uma chave em um dia (UTC)
+type usageKey struct {
+	id  primitive.ObjectID
+	day string
+}
+
+// usage é a contagem de uma chave em um dia: a última lida ou gravada no banco, que
+// inclui a de outras instâncias, e os usos desta instância ainda não gravados
+type usage struct {
+	stored     int64
+	pending    int64
+	lastUsedAt time.Time
+}
+
+// tracker conta os usos das API keys em memória, para que uma requisição não custe
+// gravações no banco. Os usos pendentes são gravados de uma vez por flush.
+type tracker struct {
+	mu    sync.Mutex
+	usage map[usageKey]*usage
+}
+
+var usageTracker = &tracker{usage: make(map[usageKey]*usage)}
+
+// RecordUse conta o uso da chave em now e retorna quantas requisições ela fez no dia
+// (UTC), incluindo esta. A contagem do banco é lida no primeiro uso do dia; depois, os
+// usos de outras instâncias são somados a cada gravação.
+func RecordUse(id primitive.ObjectID, now time.Time) (int64, error) {
+	return usageTracker.record(id, now)
+}
+
+// Flush grava os usos pendentes. É chamado periodicamente por Start e, no
+// desligamento, para não perder os usos do último intervalo.
+func Flush() {
+	usageTracker.flush(time.Now())
+}
+
+func (t *tracker) record(id primitive.ObjectID, now time.Time) (int64, error) {
+	key := usageKey{id, models.APIKeyUsageDay(now)}
+
+	t.mu.Lock()
+	if u, ok := t.usage[key]; ok {
+		defer t.mu.Unlock()
+		return u.add(now), nil
+	}
+	t.mu.Unlock()
+
+	// A leitura é feita fora do lock para não bloquear os usos das outras chaves
+	stored, err := models.GetAPIKeyUsage(id, key.day)
+	if err != nil {
+		return 0, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	u, ok := t.usage[key]
+	if !ok {
+		u = &usage{stored: stored}
+		t.usage[key] = u
+	}
+	return u.add(now), nil
+}
+
+// add conta um uso em now e retorna a contagem do dia
+func (u *usage) add(now time.Time) int64 {
+	u.pending++
+	if now.After(u.lastUsedAt) {
+		u.lastUsedAt = now
+	}
+	return u.stored + u.pending
+}
+
+func (t *tracker) flush(now time.Time) {
+	t.mu.Lock()
+	var keys []usageKey
+	var uses []models.APIKeyUse
+	for key, u := range t.usage {
+		if u.pending > 0 {
+			keys = append(keys, key)
+			uses = append(uses, models.APIKeyUse{KeyID: key.id, Day: key.day, Count: u.pending, LastUsedAt: u.lastUsedAt})
+		}
+	}
+	t.mu.Unlock()
+
+	var counts []int64
+	var err error
+	if len(uses) > 0 {
+		counts, err = models.RecordAPIKeyUses(uses)
+		if err != nil {
+			log.Printf("Erro ao gravar o uso das API keys: %v", err)
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	// Os usos que não foram gravados continuam pendentes para o próximo flush
+	for i, count := range counts {
+		u := t.usage[keys[i]]
+		u.pending -= uses[i].Count
+		u.stored = count
+	}
+	// Apenas a contagem do dia corrente é usada pela cota
+	today := models.APIKeyUsageDay(now)
+	for key, u := range t.usage {
+		if key.day != today && u.pending == 0 {
+			delete(t.usage, key)
+		}
+	}
+}
+
+// flushUsage grava os usos pendentes a cada interval até ctx ser cancelado
+func flushUsage(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			Flush()
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package apikeys
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/config"
+	"github.com/bulletdev/lta-results-api/database"
+	"github.com/bulletdev/lta-results-api/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func openStore(t *testing.T) {
+	t.Helper()
+
+	cfg := config.Defaults().Database
+	cfg.Backend = config.BackendEmbedded
+	cfg.Path = filepath.Join(t.TempDir(), "apikeys.db")
+	if err := database.Connect(cfg); err != nil {
+		t.Fatalf("erro ao abrir o armazenamento: %v", err)
+	}
+	t.Cleanup(database.Close)
+}
+
+func newTracker() *tracker {
+	return &tracker{usage: make(map[usageKey]*usage)}
+}
+
+// stored lê a contagem gravada da chave no dia de now
+func stored(t *testing.T, id primitive.ObjectID, now time.Time) int64 {
+	t.Helper()
+
+	count, err := models.GetAPIKeyUsage(id, models.APIKeyUsageDay(now))
+	if err != nil {
+		t.Fatalf("erro ao ler o uso: %v", err)
+	}
+	return count
+}
+
+func record(t *testing.T, tr *tracker, id primitive.ObjectID, now time.Time) int64 {
+	t.Helper()
+
+	count, err := tr.record(id, now)
+	if err != nil {
+		t.Fatalf("erro ao contar o uso: %v", err)
+	}
+	return count
+}
+
+func TestRecordWritesOnFlush(t *testing.T) {
+	openStore(t)
+
+	key := &models.APIKey{ID: primitive.NewObjectID(), Name: "parceiro", CreatedAt: time.Now()}
+	if err := models.CreateAPIKey(key); err != nil {
+		t.Fatalf("erro ao criar a chave: %v", err)
+	}
+
+	now := time.Date(2025, 4, 12, 15, 0, 0, 0, time.UTC)
+	tr := newTracker()
+	for i := int64(1); i <= 3; i++ {
+		if got := record(t, tr, key.ID, now.Add(time.Duration(i)*time.Second)); got != i {
+			t.Errorf("uso %d contado como %d", i, got)
+		}
+	}
+	if got := stored(t, key.ID, now); got != 0 {
+		t.Errorf("usos gravados antes do flush: %d", got)
+	}
+
+	tr.flush(now)
+	if got := stored(t, key.ID, now); got != 3 {
+		t.Errorf("contagem gravada = %d, esperado 3", got)
+	}
+	keys, err := models.GetAPIKeys()
+	if err != nil {
+		t.Fatalf("erro ao listar as chaves: %v", err)
+	}
+	if want := now.Add(3 * time.Second); len(keys) != 1 || keys[0].LastUsedAt == nil || !keys[0].LastUsedAt.Equal(want) {
+		t.Errorf("lastUsedAt = %v, esperado %v", keys[0].LastUsedAt, want)
+	}
+
+	// Um flush sem usos pendentes não grava de novo
+	tr.flush(now)
+	if got := stored(t, key.ID, now); got != 3 {
+		t.Errorf("contagem gravada = %d após flush sem usos, esperado 3", got)
+	}
+}
+
+func TestRecordIncludesOtherInstances(t *testing.T) {
+	openStore(t)
+
+	id := primitive.NewObjectID()
+	now := time.Date(2025, 4, 12, 15, 0, 0, 0, time.UTC)
+	a, b := newTracker(), newTracker()
+
+	// O primeiro uso do dia lê a contagem gravada
+	record(t, a, id, now)
+	record(t, a, id, now)
+	a.flush(now)
+	if got := record(t, b, id, now); got != 3 {
+		t.Errorf("primeiro uso em outra instância contado como %d, esperado 3", got)
+	}
+
+	// Depois, os usos das outras instâncias só chegam no flush seguinte
+	record(t, b, id, now)
+	b.flush(now)
+	if got := record(t, a, id, now); got != 3 {
+		t.Errorf("uso antes do flush contado como %d, esperado 3", got)
+	}
+	a.flush(now)
+	if got := record(t, a, id, now); got != 6 {
+		t.Errorf("uso após o flush contado como %d, esperado 6", got)
+	}
+}
+
+func TestRecordStartsNewDay(t *testing.T) {
+	openStore(t)
+
+	id := primitive.NewObjectID()
+	day := time.Date(2025, 4, 12, 23, 59, 0, 0, time.UTC)
+	next := day.Add(2 * time.Minute)
+	tr := newTracker()
+
+	record(t, tr, id, day)
+	record(t, tr, id, day)
+	if got := record(t, tr, id, next); got != 1 {
+		t.Errorf("primeiro uso do dia seguinte contado como %d, esperado 1", got)
+	}
+
+	// Os usos do dia anterior ainda são gravados, e depois descartados da memória
+	tr.flush(next)
+	if got := stored(t, id, day); got != 2 {
+		t.Errorf("contagem do dia anterior = %d, esperado 2", got)
+	}
+	if _, ok := tr.usage[usageKey{id, models.APIKeyUsageDay(day)}]; ok {
+		t.Error("contagem do dia anterior mantida em memória após o flush")
+	}
+}
@@ -0,0 +1,46 @@
+// Package buildinfo expõe a versão e o commit do binário, injetados no build com
+//
+//	go build -ldflags "-X github.com/bulletdev/lta-results-api/buildinfo.Version=v1.2.0 \
+//	  -X github.com/bulletdev/lta-results-api/buildinfo.Commit=$(git rev-parse HEAD)"
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Valores definidos via -ldflags -X; vazios, são lidos das informações de VCS do Go
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildTime = ""
+)
+
+// Info descreve o binário em execução
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildTime string `json:"buildTime,omitempty"`
+	GoVersion string `json:"goVersion"`
+}
+
+// Get retorna as informações do build. Sem -ldflags, o commit e a data vêm das
+// informações de VCS que o go build grava quando compila dentro de um repositório git.
+func Get() Info {
+	info := Info{Version: Version, Commit: Commit, BuildTime: BuildTime, GoVersion: runtime.Version()}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range bi.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = setting.Value
+				}
+			case "vcs.time":
+				if info.BuildTime == "" {
+					info.BuildTime = setting.Value
+				}
+			}
+		}
+	}
+	return info
+}
@@ -0,0 +1,60 @@
+// Package cache guarda respostas já calculadas da API, identificadas por tags para que
+// as alterações em uma partida invalidem apenas as respostas afetadas por ela.
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Tags usadas para invalidar as respostas
+const (
+	// TagAll marca todas as entradas; invalidá-la esvazia o cache
+	TagAll = "*"
+	// TagResults marca as listagens sem filtro, afetadas por qualquer partida
+	TagResults = "results"
+)
+
+// RegionTag marca as respostas de uma região
+func RegionTag(region string) string { return "region:" + strings.ToLower(region) }
+
+// TeamTag marca as respostas de um time
+func TeamTag(team string) string { return "team:" + strings.ToLower(team) }
+
+// PlayerTag marca as respostas de um jogador
+func PlayerTag(player string) string { return "player:" + strings.ToLower(player) }
+
+// MatchTag marca as respostas de uma partida
+func MatchTag(matchID string) string { return "match:" + matchID }
+
+// Entry é uma resposta guardada
+type Entry struct {
+	Body        []byte
+	ContentType string
+	ETag        string
+	Tags        []string
+	StoredAt    time.Time
+}
+
+// Store guarda as entradas. Get retorna nil quando a chave não existe ou expirou.
+type Store interface {
+	Get(ctx context.Context, key string) (*Entry, error)
+	Set(ctx context.Context, key string, entry *Entry, ttl time.Duration) error
+	Invalidate(ctx context.Context, tags ...string) error
+}
+
+// ErrUnknownBackend indica um backend de Store não suportado
+var ErrUnknownBackend = errors.New("backend de cache desconhecido")
+
+// NewStore cria o Store do backend informado. Apenas "memory" é embutido; backends
+// compartilhados implementam Store e são passados diretamente para o middleware.
+func NewStore(backend string, maxEntries int) (Store, error) {
+	switch backend {
+	case "", "memory":
+		return NewMemoryStore(maxEntries), nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownBackend, backend)
+}
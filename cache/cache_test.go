@@ -0,0 +1,148 @@
+package cache
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/events"
+)
+
+func entry(body string, tags ...string) *Entry {
+	return &Entry{Body: []byte(body), Tags: tags, StoredAt: time.Now()}
+}
+
+// cached lista as chaves que ainda têm entrada
+func cached(t *testing.T, store Store, keys ...string) []string {
+	t.Helper()
+
+	var found []string
+	for _, key := range keys {
+		e, err := store.Get(context.Background(), key)
+		if err != nil {
+			t.Fatalf("erro ao ler %s: %v", key, err)
+		}
+		if e != nil {
+			found = append(found, key)
+		}
+	}
+	return found
+}
+
+func TestMemoryStoreInvalidate(t *testing.T) {
+	ctx := context.Background()
+	keys := []string{"results", "sul", "loud", "robo"}
+
+	tests := []struct {
+		name string
+		tags []string
+		want []string
+	}{
+		{"tag de região", []string{RegionTag("SUL")}, []string{"results", "loud", "robo"}},
+		{"tag compartilhada", []string{TeamTag("LOUD")}, []string{"results", "robo"}},
+		{"várias tags", []string{TagResults, PlayerTag("Robo")}, []string{"sul", "loud"}},
+		{"tag sem entradas", []string{TeamTag("RED")}, keys},
+		{"todas", []string{TagAll}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMemoryStore(10)
+			store.Set(ctx, "results", entry("1", TagResults), time.Minute)
+			store.Set(ctx, "sul", entry("2", RegionTag("sul"), TeamTag("loud")), time.Minute)
+			store.Set(ctx, "loud", entry("3", TeamTag("LOUD")), time.Minute)
+			store.Set(ctx, "robo", entry("4", PlayerTag("robo")), time.Minute)
+
+			if err := store.Invalidate(ctx, tt.tags...); err != nil {
+				t.Fatalf("erro ao invalidar: %v", err)
+			}
+			if got := cached(t, store, keys...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("entradas restantes = %v, esperado %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryStoreReplaceDropsOldTags(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(10)
+
+	store.Set(ctx, "a", entry("1", TeamTag("loud")), time.Minute)
+	store.Set(ctx, "a", entry("2", TeamTag("red")), time.Minute)
+
+	// A tag da versão substituída não invalida mais a chave
+	store.Invalidate(ctx, TeamTag("loud"))
+	if got := cached(t, store, "a"); len(got) != 1 {
+		t.Fatal("entrada invalidada pela tag da versão anterior")
+	}
+	if len(store.tags[TeamTag("loud")]) != 0 {
+		t.Error("referência da tag anterior mantida")
+	}
+}
+
+func TestMemoryStoreExpiresAndEvicts(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(2)
+
+	store.Set(ctx, "expirada", entry("1"), -time.Second)
+	if got := cached(t, store, "expirada"); got != nil {
+		t.Errorf("entrada expirada retornada")
+	}
+
+	// Ao atingir o limite, a entrada mais antiga é descartada
+	now := time.Now()
+	store.Set(ctx, "antiga", &Entry{StoredAt: now.Add(-time.Minute)}, time.Minute)
+	store.Set(ctx, "recente", &Entry{StoredAt: now}, time.Minute)
+	store.Set(ctx, "nova", &Entry{StoredAt: now}, time.Minute)
+	if got := cached(t, store, "antiga", "recente", "nova"); !reflect.DeepEqual(got, []string{"recente", "nova"}) {
+		t.Errorf("entradas após o descarte = %v", got)
+	}
+}
+
+func TestEventTags(t *testing.T) {
+	e := events.Event{
+		Type:    events.MatchUpdated,
+		MatchID: "sul-1",
+		Region:  "sul",
+		Teams:   []string{"LOUD", "paiN Gaming"},
+		Players: []string{"Robo"},
+	}
+	got := EventTags(e)
+	want := []string{TagResults, "match:sul-1", "region:sul", "team:loud", "team:pain gaming", "player:robo"}
+	sort.Strings(got)
+	sort.Strings(want)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("EventTags = %v, esperado %v", got, want)
+	}
+
+	e.Type = events.ScrapeFailed
+	if tags := EventTags(e); tags != nil {
+		t.Errorf("evento que não altera partidas gerou tags %v", tags)
+	}
+}
+
+func TestWatchInvalidatesOnEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := NewMemoryStore(10)
+	store.Set(ctx, "loud", entry("1", TeamTag("loud")), time.Minute)
+	store.Set(ctx, "red", entry("2", TeamTag("red")), time.Minute)
+
+	broker := events.NewBroker(10)
+	Watch(ctx, store, broker)
+
+	// A inscrição é feita em segundo plano; publica até a invalidação ocorrer
+	deadline := time.Now().Add(2 * time.Second)
+	for len(cached(t, store, "loud")) > 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("entrada não invalidada pelo evento")
+		}
+		broker.Publish(events.Event{Type: events.MatchCreated, MatchID: "sul-1", Teams: []string{"LOUD"}})
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := cached(t, store, "red"); len(got) != 1 {
+		t.Error("entrada de outro time invalidada")
+	}
+}
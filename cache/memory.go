@@ -0,0 +1,126 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultMaxEntries é o limite padrão de entradas do MemoryStore
+const DefaultMaxEntries = 1000
+
+// MemoryStore guarda as entradas na memória do processo. Ao atingir o limite de
+// entradas, descarta as expiradas e, se preciso, as mais antigas.
+type MemoryStore struct {
+	mu         sync.Mutex
+	maxEntries int
+	items      map[string]*memoryItem
+	tags       map[string]map[string]struct{}
+}
+
+type memoryItem struct {
+	entry   *Entry
+	expires time.Time
+}
+
+// NewMemoryStore cria um Store em memória com até maxEntries entradas
+func NewMemoryStore(maxEntries int) *MemoryStore {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMaxEntries
+	}
+	return &MemoryStore{
+		maxEntries: maxEntries,
+		items:      make(map[string]*memoryItem),
+		tags:       make(map[string]map[string]struct{}),
+	}
+}
+
+// Get retorna a entrada da chave, se ainda válida
+func (s *MemoryStore) Get(_ context.Context, key string) (*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, ok := s.items[key]
+	if !ok {
+		return nil, nil
+	}
+	if time.Now().After(item.expires) {
+		s.remove(key)
+		return nil, nil
+	}
+	return item.entry, nil
+}
+
+// Set guarda a entrada por ttl
+func (s *MemoryStore) Set(_ context.Context, key string, entry *Entry, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.remove(key)
+	if len(s.items) >= s.maxEntries {
+		s.evict()
+	}
+
+	s.items[key] = &memoryItem{entry: entry, expires: time.Now().Add(ttl)}
+	for _, tag := range tagsOf(entry) {
+		keys, ok := s.tags[tag]
+		if !ok {
+			keys = make(map[string]struct{})
+			s.tags[tag] = keys
+		}
+		keys[key] = struct{}{}
+	}
+	return nil
+}
+
+// Invalidate remove as entradas marcadas com qualquer uma das tags
+func (s *MemoryStore) Invalidate(_ context.Context, tags ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, tag := range tags {
+		for key := range s.tags[tag] {
+			s.remove(key)
+		}
+	}
+	return nil
+}
+
+// remove apaga a entrada e suas referências nas tags; deve ser chamado com s.mu travado
+func (s *MemoryStore) remove(key string) {
+	item, ok := s.items[key]
+	if !ok {
+		return
+	}
+	delete(s.items, key)
+	for _, tag := range tagsOf(item.entry) {
+		delete(s.tags[tag], key)
+		if len(s.tags[tag]) == 0 {
+			delete(s.tags, tag)
+		}
+	}
+}
+
+// evict abre espaço para uma entrada nova; deve ser chamado com s.mu travado
+func (s *MemoryStore) evict() {
+	now := time.Now()
+	oldestKey, oldest := "", time.Time{}
+	for key, item := range s.items {
+		if now.After(item.expires) {
+			s.remove(key)
+			continue
+		}
+		if oldestKey == "" || item.entry.StoredAt.Before(oldest) {
+			oldestKey, oldest = key, item.entry.StoredAt
+		}
+	}
+	if len(s.items) >= s.maxEntries && oldestKey != "" {
+		s.remove(oldestKey)
+	}
+}
+
+// tagsOf retorna as tags da entrada mais TagAll
+func tagsOf(entry *Entry) []string {
+	tags := make([]string, 0, len(entry.Tags)+1)
+	return append(append(tags, entry.Tags...), TagAll)
+}
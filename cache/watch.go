@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"context"
+	"log"
+
+	"github.com/bulletdev/lta-results-api/events"
+)
+
+// Watch invalida as respostas afetadas por cada alteração de partida publicada no
+// broker, até ctx ser cancelado. Se a inscrição for encerrada por atraso, eventos podem
+// ter sido perdidos e o cache inteiro é invalidado antes de se inscrever novamente.
+func Watch(ctx context.Context, store Store, broker *events.Broker) {
+	go func() {
+		for {
+			_, sub := broker.Subscribe(events.Filter{}, 0)
+			if !consume(ctx, store, sub) {
+				sub.Close()
+				return
+			}
+			log.Println("Inscrição do cache no broker encerrada; invalidando todas as respostas")
+			invalidate(ctx, store, TagAll)
+		}
+	}()
+}
+
+// consume processa os eventos até a inscrição ser encerrada, retornando false quando
+// ctx é cancelado
+func consume(ctx context.Context, store Store, sub *events.Subscription) bool {
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case e, ok := <-sub.C:
+			if !ok {
+				return true
+			}
+			if tags := EventTags(e); len(tags) > 0 {
+				invalidate(ctx, store, tags...)
+			}
+		}
+	}
+}
+
+func invalidate(ctx context.Context, store Store, tags ...string) {
+	if err := store.Invalidate(ctx, tags...); err != nil {
+		log.Printf("Erro ao invalidar o cache (%v): %v", tags, err)
+	}
+}
+
+// EventTags retorna as tags das respostas afetadas pelo evento: as listagens, a região,
+// os times e os jogadores da partida, antes e depois da alteração
+func EventTags(e events.Event) []string {
+	switch e.Type {
+	case events.MatchCreated, events.MatchUpdated, events.MatchDeleted:
+	default:
+		return nil
+	}
+
+	tags := []string{TagResults, MatchTag(e.MatchID)}
+	if e.Region != "" {
+		tags = append(tags, RegionTag(e.Region))
+	}
+	for _, team := range e.Teams {
+		tags = append(tags, TeamTag(team))
+	}
+	for _, player := range e.Players {
+		tags = append(tags, PlayerTag(player))
+	}
+	return tags
+}
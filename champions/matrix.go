@@ -0,0 +1,203 @@
+package champions
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/bulletdev/lta-results-api/models"
+)
+
+// Options filtra e configura o cálculo das matrizes
+type Options struct {
+	Champion   string
+	Position   string
+	MinGames   int
+	Confidence float64
+}
+
+// PairStats representa o desempenho de um par de campeões
+type PairStats struct {
+	Champion string  `json:"champion"`
+	Other    string  `json:"other"`
+	Position string  `json:"position,omitempty"`
+	Games    int     `json:"games"`
+	Wins     int     `json:"wins"`
+	WinRate  float64 `json:"winRate"`
+	CILower  float64 `json:"ciLower"`
+	CIUpper  float64 `json:"ciUpper"`
+}
+
+// Valores de z para os níveis de confiança suportados
+var zScores = map[float64]float64{
+	0.80: 1.2816,
+	0.90: 1.6449,
+	0.95: 1.9600,
+	0.99: 2.5758,
+}
+
+// ZScore retorna o valor de z para o nível de confiança ou erro se não suportado
+func ZScore(confidence float64) (float64, error) {
+	z, ok := zScores[confidence]
+	if !ok {
+		return 0, fmt.Errorf("nível de confiança não suportado: %v (use 0.8, 0.9, 0.95 ou 0.99)", confidence)
+	}
+	return z, nil
+}
+
+// Synergy calcula a taxa de vitória de pares de campeões jogando no mesmo time. Com
+// Position, cada par é registrado a partir do campeão que jogou nessa posição, que
+// aparece em champion.
+func Synergy(matches []models.MatchResult, opts Options) ([]PairStats, error) {
+	z, err := ZScore(opts.Confidence)
+	if err != nil {
+		return nil, err
+	}
+
+	position := strings.ToUpper(opts.Position)
+	pairs := make(map[string]*PairStats)
+	for _, match := range matches {
+		for _, team := range []string{match.TeamA, match.TeamB} {
+			roster := teamPlayers(match, team)
+			won := strings.EqualFold(match.Winner, team)
+
+			for i := 0; i < len(roster); i++ {
+				for j := i + 1; j < len(roster); j++ {
+					a, b := roster[i], roster[j]
+					if position != "" {
+						if strings.EqualFold(a.Position, position) {
+							record(pairs, a.Champion, b.Champion, position, won)
+						}
+						if strings.EqualFold(b.Position, position) {
+							record(pairs, b.Champion, a.Champion, position, won)
+						}
+						continue
+					}
+					first, second := a.Champion, b.Champion
+					if second < first {
+						first, second = second, first
+					}
+					record(pairs, first, second, "", won)
+				}
+			}
+		}
+	}
+
+	// Com a posição, o par deixa de ser simétrico: o campeão consultado deve ser o da posição
+	return collect(pairs, opts, z, position == ""), nil
+}
+
+// Counters calcula a taxa de vitória de cada campeão contra o oponente da mesma posição
+func Counters(matches []models.MatchResult, opts Options) ([]PairStats, error) {
+	z, err := ZScore(opts.Confidence)
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := make(map[string]*PairStats)
+	for _, match := range matches {
+		rosterA := teamPlayers(match, match.TeamA)
+		rosterB := teamPlayers(match, match.TeamB)
+
+		for _, a := range rosterA {
+			for _, b := range rosterB {
+				if a.Position == "" || !strings.EqualFold(a.Position, b.Position) {
+					continue
+				}
+				position := strings.ToUpper(a.Position)
+
+				// Cada confronto é registrado nas duas direções
+				record(pairs, a.Champion, b.Champion, position, strings.EqualFold(match.Winner, match.TeamA))
+				record(pairs, b.Champion, a.Champion, position, strings.EqualFold(match.Winner, match.TeamB))
+			}
+		}
+	}
+
+	return collect(pairs, opts, z, false), nil
+}
+
+// WilsonInterval calcula o intervalo de confiança de Wilson para uma proporção
+func WilsonInterval(wins, games int, z float64) (float64, float64) {
+	if games == 0 {
+		return 0, 0
+	}
+
+	n := float64(games)
+	p := float64(wins) / n
+	denominator := 1 + z*z/n
+	center := (p + z*z/(2*n)) / denominator
+	margin := z * math.Sqrt(p*(1-p)/n+z*z/(4*n*n)) / denominator
+
+	return math.Max(0, center-margin), math.Min(1, center+margin)
+}
+
+// teamPlayers retorna os jogadores de um time que têm campeão informado
+func teamPlayers(match models.MatchResult, team string) []models.Player {
+	var roster []models.Player
+	for _, player := range match.Players {
+		if strings.EqualFold(player.Team, team) && player.Champion != "" {
+			roster = append(roster, player)
+		}
+	}
+	return roster
+}
+
+func record(pairs map[string]*PairStats, champion, other, position string, won bool) {
+	key := champion + "|" + other + "|" + position
+	stats, ok := pairs[key]
+	if !ok {
+		stats = &PairStats{Champion: champion, Other: other, Position: position}
+		pairs[key] = stats
+	}
+	stats.Games++
+	if won {
+		stats.Wins++
+	}
+}
+
+// collect aplica os filtros, calcula as taxas e ordena os pares
+func collect(pairs map[string]*PairStats, opts Options, z float64, symmetric bool) []PairStats {
+	result := make([]PairStats, 0)
+
+	for _, stats := range pairs {
+		if stats.Games < opts.MinGames {
+			continue
+		}
+		if opts.Position != "" && !strings.EqualFold(stats.Position, opts.Position) {
+			continue
+		}
+		if opts.Champion != "" {
+			switch {
+			case strings.EqualFold(stats.Champion, opts.Champion):
+			case symmetric && strings.EqualFold(stats.Other, opts.Champion):
+				// Na sinergia o campeão consultado sempre aparece primeiro
+				stats.Champion, stats.Other = stats.Other, stats.Champion
+			default:
+				continue
+			}
+		}
+
+		lower, upper := WilsonInterval(stats.Wins, stats.Games, z)
+		stats.WinRate = round(float64(stats.Wins) / float64(stats.Games) * 100)
+		stats.CILower = round(lower * 100)
+		stats.CIUpper = round(upper * 100)
+		result = append(result, *stats)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].WinRate != result[j].WinRate {
+			return result[i].WinRate > result[j].WinRate
+		}
+		if result[i].Games != result[j].Games {
+			return result[i].Games > result[j].Games
+		}
+		return result[i].Champion+result[i].Other < result[j].Champion+result[j].Other
+	})
+
+	return result
+}
+
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
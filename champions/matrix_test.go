@@ -0,0 +1,151 @@
+package champions
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bulletdev/lta-results-api/models"
+)
+
+func TestWilsonInterval(t *testing.T) {
+	tests := []struct {
+		name         string
+		wins, games  int
+		z            float64
+		lower, upper float64
+	}{
+		{"sem jogos", 0, 0, 1.96, 0, 0},
+		{"8 de 10 a 95%", 8, 10, 1.96, 0.4902, 0.9433},
+		{"metade a 95%", 50, 100, 1.96, 0.4038, 0.5962},
+		{"nenhuma vitória", 0, 5, 1.96, 0, 0.4345},
+		{"todas as vitórias", 5, 5, 1.96, 0.5655, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lower, upper := WilsonInterval(tt.wins, tt.games, tt.z)
+			if math.Abs(lower-tt.lower) > 1e-4 || math.Abs(upper-tt.upper) > 1e-4 {
+				t.Errorf("WilsonInterval(%d, %d) = [%.4f, %.4f], esperado [%.4f, %.4f]",
+					tt.wins, tt.games, lower, upper, tt.lower, tt.upper)
+			}
+		})
+	}
+
+	// Mais confiança alarga o intervalo
+	lower90, upper90 := WilsonInterval(8, 10, 1.6449)
+	lower99, upper99 := WilsonInterval(8, 10, 2.5758)
+	if !(lower99 < lower90 && upper99 > upper90) {
+		t.Errorf("intervalo a 99%% [%v, %v] não contém o de 90%% [%v, %v]", lower99, upper99, lower90, upper90)
+	}
+}
+
+func TestZScore(t *testing.T) {
+	if z, err := ZScore(0.95); err != nil || z != 1.96 {
+		t.Errorf("ZScore(0.95) = %v, %v", z, err)
+	}
+	for _, confidence := range []float64{0, 0.5, 0.975, 1} {
+		if _, err := ZScore(confidence); err == nil {
+			t.Errorf("ZScore(%v) aceito", confidence)
+		}
+	}
+}
+
+// games monta partidas entre LOUD e RED com os mesmos campeões, alternando o vencedor
+// com a caixa do nome diferente da do time
+func games(winners ...string) []models.MatchResult {
+	var matches []models.MatchResult
+	for _, winner := range winners {
+		matches = append(matches, models.MatchResult{
+			TeamA:  "LOUD",
+			TeamB:  "RED",
+			Winner: winner,
+			Players: []models.Player{
+				{Team: "LOUD", Position: "mid", Champion: "Ahri"},
+				{Team: "loud", Position: "JNG", Champion: "Vi"},
+				{Team: "RED", Position: "MID", Champion: "Azir"},
+				{Team: "RED", Position: "jng", Champion: "Sejuani"},
+				// Sem campeão o jogador é ignorado
+				{Team: "RED", Position: "TOP"},
+			},
+		})
+	}
+	return matches
+}
+
+func find(t *testing.T, stats []PairStats, champion, other string) PairStats {
+	t.Helper()
+
+	for _, s := range stats {
+		if s.Champion == champion && s.Other == other {
+			return s
+		}
+	}
+	t.Fatalf("par %s/%s ausente em %+v", champion, other, stats)
+	return PairStats{}
+}
+
+func TestSynergy(t *testing.T) {
+	matches := games("loud", "LOUD", "RED")
+
+	stats, err := Synergy(matches, Options{Confidence: 0.95})
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if len(stats) != 2 {
+		t.Fatalf("%d pares, esperado 2: %+v", len(stats), stats)
+	}
+	if ahri := find(t, stats, "Ahri", "Vi"); ahri.Games != 3 || ahri.Wins != 2 || ahri.WinRate != 66.67 {
+		t.Errorf("Ahri/Vi = %+v, esperado 2 vitórias em 3", ahri)
+	}
+
+	// O campeão consultado aparece primeiro mesmo que o par esteja em outra ordem
+	stats, err = Synergy(matches, Options{Champion: "vi", Confidence: 0.95})
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if len(stats) != 1 || stats[0].Champion != "Vi" || stats[0].Other != "Ahri" {
+		t.Errorf("sinergia de Vi = %+v", stats)
+	}
+
+	// Com a posição, o par é registrado a partir do campeão que jogou nela
+	stats, err = Synergy(matches, Options{Position: "jng", Confidence: 0.95})
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if sejuani := find(t, stats, "Sejuani", "Azir"); sejuani.Position != "JNG" || sejuani.Wins != 1 {
+		t.Errorf("Sejuani/Azir na selva = %+v", sejuani)
+	}
+	if len(stats) != 2 {
+		t.Errorf("%d pares na selva, esperado 2: %+v", len(stats), stats)
+	}
+}
+
+func TestCounters(t *testing.T) {
+	matches := games("LOUD", "red", "RED", "RED")
+
+	stats, err := Counters(matches, Options{MinGames: 1, Confidence: 0.9})
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if len(stats) != 4 {
+		t.Fatalf("%d confrontos, esperado 4: %+v", len(stats), stats)
+	}
+
+	azir := find(t, stats, "Azir", "Ahri")
+	if azir.Position != "MID" || azir.Games != 4 || azir.Wins != 3 || azir.WinRate != 75 {
+		t.Errorf("Azir contra Ahri = %+v", azir)
+	}
+	if ahri := find(t, stats, "Ahri", "Azir"); ahri.Wins != 1 {
+		t.Errorf("Ahri contra Azir = %+v, esperado 1 vitória", ahri)
+	}
+	if azir.CILower >= azir.WinRate || azir.CIUpper <= azir.WinRate {
+		t.Errorf("intervalo [%v, %v] não contém a taxa %v", azir.CILower, azir.CIUpper, azir.WinRate)
+	}
+
+	// Os pares com menos jogos que o mínimo são descartados
+	if stats, _ := Counters(matches, Options{MinGames: 5, Confidence: 0.9}); len(stats) != 0 {
+		t.Errorf("pares abaixo do mínimo de jogos: %+v", stats)
+	}
+	if _, err := Counters(matches, Options{Confidence: 0.5}); err == nil {
+		t.Error("nível de confiança não suportado aceito")
+	}
+}
@@ -2,8 +2,10 @@ package main
 
 import (
 	"context"
+	"flag"
 	_ "fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -11,46 +13,129 @@ import (
 	"time"
 
 	"github.com/bulletdev/lta-results-api/api"
+	"github.com/bulletdev/lta-results-api/apikeys"
+	"github.com/bulletdev/lta-results-api/config"
 	"github.com/bulletdev/lta-results-api/database"
+	"github.com/bulletdev/lta-results-api/events"
+	"github.com/bulletdev/lta-results-api/grpcapi"
+	"github.com/bulletdev/lta-results-api/models"
+	"github.com/bulletdev/lta-results-api/outbox"
+	"github.com/bulletdev/lta-results-api/retention"
 	"github.com/bulletdev/lta-results-api/scraper"
+	"github.com/bulletdev/lta-results-api/webhooks"
 )
 
 func main() {
+	configFile := flag.String("config", "", "Arquivo de configuração YAML ou TOML (padrão: CONFIG_FILE)")
+	printConfig := flag.Bool("print-config", false, "Imprimir a configuração efetiva, sem os segredos, e sair")
+	flag.Parse()
+
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		log.Fatalf("Erro ao carregar a configuração: %v", err)
+	}
+	if *printConfig {
+		out, err := cfg.Redacted().YAML()
+		if err != nil {
+			log.Fatalf("Erro ao imprimir a configuração: %v", err)
+		}
+		os.Stdout.Write(out)
+		return
+	}
+
 	// Conectar ao banco de dados
-	if err := database.Connect(); err != nil {
+	if err := database.Connect(cfg.Database); err != nil {
 		log.Fatalf("Erro ao conectar ao banco de dados: %v", err)
 	}
 	defer database.Close()
 
+	// Índices das consultas e das restrições de unicidade. Sem eles a API aceitaria
+	// dados repetidos, então uma falha impede a inicialização.
+	if err := models.EnsureIndexes(); err != nil {
+		log.Fatalf("Erro ao criar índices: %v. Corrija os documentos repetidos apontados no erro e reinicie a API", err)
+	}
+
 	// Configurar API
-	router := api.SetupRouter()
+	router, err := api.SetupRouter(cfg)
+	if err != nil {
+		log.Fatalf("Erro ao configurar as rotas: %v", err)
+	}
 	server := &http.Server{
-		Addr:    ":" + os.Getenv("PORT"),
+		Addr:    ":" + cfg.Server.Port,
 		Handler: router,
 	}
 
 	// Configurar cron job para scraping
+	scraper.Configure(cfg.Scraper)
 	go scraper.ScheduleScraping()
 
+	// Publicar os eventos do outbox no broker usado pelos streams e webhooks
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	outbox.Register("broker", func(e events.Event) error {
+		events.Publish(e)
+		return nil
+	})
+	events.Default.SetBackfill(outbox.Replay)
+	// Cada webhook inscrito recebe uma entrega persistida; se a gravação falhar, o
+	// evento continua no outbox e é reenviado
+	outbox.Register("webhooks", webhooks.Enqueue)
+	outbox.Start(workersCtx)
+
+	// Iniciar o envio de webhooks
+	webhooks.Start(workersCtx)
+
+	// Excluir definitivamente as partidas que passaram do período de retenção na lixeira
+	retention.Start(workersCtx, cfg.Trash.RetentionDays)
+
+	// Excluir as contagens de uso das API keys dos dias que já não contam para a cota
+	apikeys.Start(workersCtx, cfg.APIKeys)
+
 	// Iniciar servidor em uma goroutine
 	go func() {
-		log.Printf("Servidor API iniciado na porta %s", os.Getenv("PORT"))
+		log.Printf("Servidor API iniciado na porta %s", cfg.Server.Port)
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Erro ao iniciar servidor: %v", err)
 		}
 	}()
 
+	// Iniciar o servidor gRPC em uma porta separada
+	grpcPort := cfg.Server.GRPCPort
+	grpcServer := grpcapi.NewGRPCServer(events.Default)
+	go func() {
+		listener, err := net.Listen("tcp", ":"+grpcPort)
+		if err != nil {
+			log.Fatalf("Erro ao abrir porta gRPC: %v", err)
+		}
+		log.Printf("Servidor gRPC iniciado na porta %s", grpcPort)
+		if err := grpcServer.Serve(listener); err != nil {
+			log.Fatalf("Erro ao iniciar servidor gRPC: %v", err)
+		}
+	}()
+
 	// Configurar graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Println("Desligando servidor...")
 
+	// Parar os workers em segundo plano
+	stopWorkers()
+
+	// Encerrar os streams abertos para que o shutdown não aguarde essas conexões
+	events.Default.Close()
+
+	// Os streams WatchResults já foram encerrados junto com o broker
+	grpcServer.GracefulStop()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 	if err := server.Shutdown(ctx); err != nil {
 		log.Fatalf("Erro ao desligar servidor: %v", err)
 	}
 
+	// Gravar os usos das API keys contados desde a última gravação
+	apikeys.Flush()
+
 	log.Println("Servidor encerrado com sucesso")
 }
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/bulletdev/lta-results-api/config"
+	"github.com/bulletdev/lta-results-api/database"
+	"github.com/bulletdev/lta-results-api/models"
+	"github.com/bulletdev/lta-results-api/prediction"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func main() {
+	region := flag.String("region", "", "Região a ser avaliada (sul, norte). Vazio avalia todas")
+	asJSON := flag.Bool("json", false, "Imprimir o relatório em JSON")
+	configFile := flag.String("config", "", "Arquivo de configuração YAML ou TOML (padrão: CONFIG_FILE)")
+	flag.Parse()
+
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		log.Fatalf("Erro ao carregar a configuração: %v", err)
+	}
+
+	// Conectar ao banco de dados
+	if err := database.Connect(cfg.Database); err != nil {
+		log.Fatalf("Erro ao conectar ao banco de dados: %v", err)
+	}
+	defer database.Close()
+
+	filter := bson.M{}
+	if *region != "" {
+		filter["region"] = *region
+	}
+
+	matches, err := models.GetAllMatchResults(filter)
+	if err != nil {
+		log.Fatalf("Erro ao buscar resultados: %v", err)
+	}
+
+	report := prediction.Backtest(matches)
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			log.Fatalf("Erro ao gerar relatório: %v", err)
+		}
+		return
+	}
+
+	fmt.Printf("Partidas avaliadas: %d (ignoradas: %d)\n", report.Matches, report.Skipped)
+	fmt.Printf("Brier score:        %.4f\n", report.BrierScore)
+	fmt.Printf("Log loss:           %.4f\n", report.LogLoss)
+	fmt.Printf("Acurácia:           %.2f%%\n", report.Accuracy*100)
+	fmt.Println()
+	fmt.Println("Calibração (prob. prevista x frequência observada):")
+	for _, bucket := range report.Calibration {
+		if bucket.Count == 0 {
+			continue
+		}
+		fmt.Printf("  %.1f-%.1f  n=%-4d prevista=%.3f observada=%.3f\n",
+			bucket.Lower, bucket.Upper, bucket.Count, bucket.Predicted, bucket.Observed)
+	}
+}
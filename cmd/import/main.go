@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/bulletdev/lta-results-api/config"
+	"github.com/bulletdev/lta-results-api/database"
+	"github.com/bulletdev/lta-results-api/importer"
+	"github.com/bulletdev/lta-results-api/models"
+)
+
+func main() {
+	file := flag.String("file", "", "Arquivo com as partidas (.ndjson, .json ou .csv)")
+	format := flag.String("format", "", "Formato do arquivo: ndjson, json ou csv (padrão: pela extensão)")
+	dryRun := flag.Bool("dry-run", false, "Apenas validar, sem gravar no banco")
+	batchSize := flag.Int("batch-size", importer.DefaultBatchSize, "Quantidade de partidas gravadas por lote")
+	actor := flag.String("actor", os.Getenv("USER"), "Responsável pela importação, registrado no histórico das partidas")
+	configFile := flag.String("config", "", "Arquivo de configuração YAML ou TOML (padrão: CONFIG_FILE)")
+	flag.Parse()
+
+	if *file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if *format == "" {
+		*format = importer.DetectFormat(*file)
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		log.Fatalf("Erro ao abrir arquivo: %v", err)
+	}
+	defer f.Close()
+
+	records, err := importer.Parse(f, *format)
+	if err != nil {
+		log.Fatalf("Erro ao ler arquivo: %v", err)
+	}
+
+	// A validação em dry-run não precisa do banco de dados
+	if !*dryRun {
+		cfg, err := config.Load(*configFile)
+		if err != nil {
+			log.Fatalf("Erro ao carregar a configuração: %v", err)
+		}
+		if err := database.Connect(cfg.Database); err != nil {
+			log.Fatalf("Erro ao conectar ao banco de dados: %v", err)
+		}
+		defer database.Close()
+
+		// O upsert pelo matchId depende do índice único, que pode ainda não existir se a
+		// importação rodar antes da primeira inicialização da API
+		if err := models.EnsureIndexes(); err != nil {
+			database.Close()
+			log.Fatalf("Erro ao criar índices: %v", err)
+		}
+	}
+
+	report := importer.Run(records, importer.Options{
+		DryRun:    *dryRun,
+		BatchSize: *batchSize,
+		Author:    models.Author{Actor: *actor, Source: models.SourceImport},
+	})
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		log.Fatalf("Erro ao gerar relatório: %v", err)
+	}
+
+	if len(report.Errors) > 0 {
+		database.Close()
+		os.Exit(1)
+	}
+}
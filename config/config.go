@@ -0,0 +1,388 @@
+// Package config reúne a configuração da aplicação. Os valores vêm, em ordem de
+// prioridade, das variáveis de ambiente, do arquivo .env, de um arquivo YAML ou TOML
+// opcional e dos padrões definidos em Defaults.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/oidc"
+	"github.com/bulletdev/lta-results-api/ratelimit"
+	"github.com/robfig/cron/v3"
+)
+
+// Config é a configuração completa da aplicação. A tag env indica a variável de
+// ambiente de cada campo e secret marca os valores ocultados por Redacted.
+type Config struct {
+	Server    Server    `yaml:"server" toml:"server"`
+	Database  Database  `yaml:"database" toml:"database"`
+	Auth      Auth      `yaml:"auth" toml:"auth"`
+	CORS      CORS      `yaml:"cors" toml:"cors"`
+	RateLimit RateLimit `yaml:"rateLimit" toml:"rateLimit"`
+	Cache     Cache     `yaml:"cache" toml:"cache"`
+	Scraper   Scraper   `yaml:"scraper" toml:"scraper"`
+	Trash     Trash     `yaml:"trash" toml:"trash"`
+	APIKeys   APIKeys   `yaml:"apiKeys" toml:"apiKeys"`
+}
+
+// Server configura as portas dos servidores HTTP e gRPC
+type Server struct {
+	Port     string `yaml:"port" toml:"port" env:"PORT"`
+	GRPCPort string `yaml:"grpcPort" toml:"grpcPort" env:"GRPC_PORT"`
+	// TrustedProxies lista os IPs ou redes (CIDR) dos proxies cujo X-Forwarded-For é
+	// usado para identificar o cliente. Vazio, o IP é sempre o da conexão.
+	TrustedProxies []string `yaml:"trustedProxies" toml:"trustedProxies" env:"TRUSTED_PROXIES"`
+}
+
+// Backends de armazenamento suportados
+const (
+	BackendMongo    = "mongodb"
+	BackendEmbedded = "embedded"
+)
+
+// Database configura o armazenamento dos dados
+type Database struct {
+	// Backend escolhe entre o MongoDB e o armazenamento embutido em um arquivo local,
+	// para instalações de um único nó sem MongoDB
+	Backend string `yaml:"backend" toml:"backend" env:"DATABASE_BACKEND"`
+	// URI é a connection string completa do MongoDB (mongodb:// ou mongodb+srv://).
+	// Sem ela, é montada a URI do Atlas com Username, Password e Cluster.
+	URI            string   `yaml:"uri" toml:"uri" env:"MONGODB_URI" secret:"true"`
+	Username       string   `yaml:"username" toml:"username" env:"MONGODB_USERNAME"`
+	Password       string   `yaml:"password" toml:"password" env:"MONGODB_PASSWORD" secret:"true"`
+	Cluster        string   `yaml:"cluster" toml:"cluster" env:"MONGODB_CLUSTER"`
+	Name           string   `yaml:"name" toml:"name" env:"MONGODB_DATABASE"`
+	ConnectTimeout Duration `yaml:"connectTimeout" toml:"connectTimeout" env:"MONGODB_CONNECT_TIMEOUT"`
+	// MaxPoolSize e MinPoolSize limitam as conexões abertas com o MongoDB; 0 usa o
+	// padrão do driver
+	MaxPoolSize     int      `yaml:"maxPoolSize" toml:"maxPoolSize" env:"MONGODB_MAX_POOL_SIZE"`
+	MinPoolSize     int      `yaml:"minPoolSize" toml:"minPoolSize" env:"MONGODB_MIN_POOL_SIZE"`
+	MaxConnIdleTime Duration `yaml:"maxConnIdleTime" toml:"maxConnIdleTime" env:"MONGODB_MAX_CONN_IDLE_TIME"`
+	TLS             TLS      `yaml:"tls" toml:"tls"`
+	// AllowStandalone aceita um mongod standalone, em que as gravações não usam
+	// transações; sem ele a conexão com um servidor assim é recusada
+	AllowStandalone bool `yaml:"allowStandalone" toml:"allowStandalone" env:"MONGODB_ALLOW_STANDALONE"`
+	// Path é o arquivo do armazenamento embutido
+	Path string `yaml:"path" toml:"path" env:"DATABASE_PATH"`
+}
+
+// TLS configura a conexão cifrada com o MongoDB. Conexões mongodb+srv:// já usam TLS.
+type TLS struct {
+	Enabled bool `yaml:"enabled" toml:"enabled" env:"MONGODB_TLS"`
+	// CAFile é o certificado da autoridade que assinou o do servidor
+	CAFile string `yaml:"caFile" toml:"caFile" env:"MONGODB_TLS_CA_FILE"`
+	// CertificateKeyFile é o PEM com o certificado e a chave do cliente, para
+	// autenticação mútua
+	CertificateKeyFile string `yaml:"certificateKeyFile" toml:"certificateKeyFile" env:"MONGODB_TLS_CERTIFICATE_KEY_FILE"`
+	// Insecure desativa a verificação do certificado do servidor; use apenas em testes
+	Insecure bool `yaml:"insecure" toml:"insecure" env:"MONGODB_TLS_INSECURE"`
+}
+
+// Auth configura a autenticação das rotas administrativas
+type Auth struct {
+	AdminAPIKey string `yaml:"adminApiKey" toml:"adminApiKey" env:"ADMIN_API_KEY" secret:"true"`
+	OIDC        OIDC   `yaml:"oidc" toml:"oidc"`
+}
+
+// OIDC configura a autenticação por token do provedor de identidade
+type OIDC struct {
+	Issuer     string `yaml:"issuer" toml:"issuer" env:"OIDC_ISSUER"`
+	Audience   string `yaml:"audience" toml:"audience" env:"OIDC_AUDIENCE"`
+	JWKSURL    string `yaml:"jwksUrl" toml:"jwksUrl" env:"OIDC_JWKS_URL"`
+	JWKSFile   string `yaml:"jwksFile" toml:"jwksFile" env:"OIDC_JWKS_FILE"`
+	RolesClaim string `yaml:"rolesClaim" toml:"rolesClaim" env:"OIDC_ROLES_CLAIM"`
+	// RoleScopes usa o formato "papel=escopo,escopo;papel=escopo"
+	RoleScopes string `yaml:"roleScopes" toml:"roleScopes" env:"OIDC_ROLE_SCOPES"`
+}
+
+// Verifier retorna a configuração usada pelo pacote oidc
+func (o OIDC) Verifier() (oidc.Config, error) {
+	roleScopes, err := oidc.ParseRoleScopes(o.RoleScopes)
+	if err != nil {
+		return oidc.Config{}, err
+	}
+	return oidc.Config{
+		Issuer:     strings.TrimRight(o.Issuer, "/"),
+		Audience:   o.Audience,
+		JWKSURL:    o.JWKSURL,
+		JWKSFile:   o.JWKSFile,
+		RolesClaim: o.RolesClaim,
+		RoleScopes: roleScopes,
+	}, nil
+}
+
+// CORS configura as origens autorizadas a chamar a API pelo navegador
+type CORS struct {
+	// AllowedOrigins aceita "*" para liberar qualquer origem
+	AllowedOrigins []string `yaml:"allowedOrigins" toml:"allowedOrigins" env:"CORS_ALLOWED_ORIGINS"`
+}
+
+// AllowAll indica se qualquer origem é aceita
+func (c CORS) AllowAll() bool {
+	for _, origin := range c.AllowedOrigins {
+		if origin == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// RateLimit configura os limites de requisições de cada grupo de rotas, no formato
+// de ratelimit.ParseLimit
+type RateLimit struct {
+	Backend string `yaml:"backend" toml:"backend" env:"RATE_LIMIT_BACKEND"`
+	Public  string `yaml:"public" toml:"public" env:"RATE_LIMIT_PUBLIC"`
+	Stats   string `yaml:"stats" toml:"stats" env:"RATE_LIMIT_STATS"`
+	Admin   string `yaml:"admin" toml:"admin" env:"RATE_LIMIT_ADMIN"`
+	// Auth limita por IP as requisições com credenciais, antes de validá-las
+	Auth string `yaml:"auth" toml:"auth" env:"RATE_LIMIT_AUTH"`
+}
+
+// Cache configura o cache de respostas
+type Cache struct {
+	Backend    string   `yaml:"backend" toml:"backend" env:"CACHE_BACKEND"`
+	TTL        Duration `yaml:"ttl" toml:"ttl" env:"CACHE_TTL"`
+	MaxAge     int      `yaml:"maxAge" toml:"maxAge" env:"CACHE_MAX_AGE"`
+	MaxEntries int      `yaml:"maxEntries" toml:"maxEntries" env:"CACHE_MAX_ENTRIES"`
+}
+
+// Scraper configura as fontes e o agendamento do scraping
+type Scraper struct {
+	// Sources associa cada região à página com os seus resultados
+	Sources       map[string]string `yaml:"sources" toml:"sources" env:"SCRAPER_SOURCES"`
+	Schedule      string            `yaml:"schedule" toml:"schedule" env:"SCRAPER_SCHEDULE"`
+	Timeout       Duration          `yaml:"timeout" toml:"timeout" env:"SCRAPER_TIMEOUT"`
+	MaxRetries    int               `yaml:"maxRetries" toml:"maxRetries" env:"SCRAPER_MAX_RETRIES"`
+	RetryDelay    Duration          `yaml:"retryDelay" toml:"retryDelay" env:"SCRAPER_RETRY_DELAY"`
+	ChromeDataDir string            `yaml:"chromeDataDir" toml:"chromeDataDir" env:"SCRAPER_CHROME_DATA_DIR"`
+}
+
+// Trash configura a lixeira de resultados excluídos
+type Trash struct {
+	// RetentionDays é o tempo na lixeira antes da exclusão definitiva; 0 a desativa
+	RetentionDays int `yaml:"retentionDays" toml:"retentionDays" env:"TRASH_RETENTION_DAYS"`
+}
+
+// APIKeys configura as contagens de uso das API keys
+type APIKeys struct {
+	// UsageRetentionDays é quantos dias de contagem de uso são mantidos além do
+	// corrente, o único usado pela cota
+	UsageRetentionDays int `yaml:"usageRetentionDays" toml:"usageRetentionDays" env:"API_KEY_USAGE_RETENTION_DAYS"`
+	// UsageFlushInterval é o intervalo entre as gravações dos usos contados em memória.
+	// Os usos de outras instâncias chegam à cota com até esse atraso.
+	UsageFlushInterval Duration `yaml:"usageFlushInterval" toml:"usageFlushInterval" env:"API_KEY_USAGE_FLUSH_INTERVAL"`
+}
+
+// Defaults retorna a configuração padrão
+func Defaults() *Config {
+	return &Config{
+		Server: Server{Port: "8080", GRPCPort: "9090"},
+		Database: Database{
+			Backend:        BackendMongo,
+			ConnectTimeout: Duration{10 * time.Second},
+			Path:           "data/lta.db",
+		},
+		Auth: Auth{OIDC: OIDC{RolesClaim: "roles"}},
+		CORS: CORS{AllowedOrigins: []string{"*"}},
+		RateLimit: RateLimit{
+			Backend: "memory",
+			// Listagens, stream, GraphQL, calendário e fantasy
+			Public: "120/1m",
+			// Estatísticas, previsões e simulações, que agregam muitas partidas no banco
+			Stats: "30/1m,10",
+			// Rotas administrativas, limitadas pela credencial
+			Admin: "600/1m",
+			// Requisições com credenciais, por IP, antes da autenticação
+			Auth: "600/1m",
+		},
+		Cache: Cache{
+			Backend:    "memory",
+			TTL:        Duration{10 * time.Minute},
+			MaxAge:     60,
+			MaxEntries: 1000,
+		},
+		Scraper: Scraper{
+			Sources: map[string]string{
+				"sul":   "https://maisesports.com.br/campeonatos/league-of-legends-lta-sul-split-2-2025/",
+				"norte": "https://maisesports.com.br/campeonatos/league-of-legends-lta-norte-split-2-2025/",
+			},
+			// Diariamente às 2h da manhã
+			Schedule:      "0 2 * * *",
+			Timeout:       Duration{180 * time.Second},
+			MaxRetries:    3,
+			RetryDelay:    Duration{5 * time.Second},
+			ChromeDataDir: "/app/chrome-data",
+		},
+		Trash:   Trash{RetentionDays: 30},
+		APIKeys: APIKeys{UsageRetentionDays: 7, UsageFlushInterval: Duration{10 * time.Second}},
+	}
+}
+
+// Validate verifica os valores da configuração, retornando todos os problemas juntos
+func (c *Config) Validate() error {
+	var problems []string
+	add := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	for _, port := range []struct{ name, value string }{
+		{"server.port", c.Server.Port},
+		{"server.grpcPort", c.Server.GRPCPort},
+	} {
+		if n, err := strconv.Atoi(port.value); err != nil || n < 1 || n > 65535 {
+			add("%s deve ser uma porta entre 1 e 65535", port.name)
+		}
+	}
+
+	switch c.Database.Backend {
+	case BackendMongo:
+		if c.Database.URI != "" && !strings.HasPrefix(c.Database.URI, "mongodb://") && !strings.HasPrefix(c.Database.URI, "mongodb+srv://") {
+			add("database.uri deve começar com mongodb:// ou mongodb+srv://")
+		}
+	case BackendEmbedded:
+		if c.Database.Path == "" {
+			add("database.path é obrigatório no backend %s", BackendEmbedded)
+		}
+	default:
+		add("database.backend deve ser %s ou %s", BackendMongo, BackendEmbedded)
+	}
+	if c.Database.ConnectTimeout.Duration <= 0 {
+		add("database.connectTimeout deve ser positivo")
+	}
+	if c.Database.MaxPoolSize < 0 || c.Database.MinPoolSize < 0 {
+		add("database.maxPoolSize e database.minPoolSize não podem ser negativos")
+	} else if c.Database.MaxPoolSize > 0 && c.Database.MinPoolSize > c.Database.MaxPoolSize {
+		add("database.minPoolSize não pode ser maior que database.maxPoolSize")
+	}
+	if c.Database.MaxConnIdleTime.Duration < 0 {
+		add("database.maxConnIdleTime não pode ser negativo")
+	}
+
+	for _, proxy := range c.Server.TrustedProxies {
+		if !validProxy(proxy) {
+			add("server.trustedProxies: IP ou CIDR inválido %q", proxy)
+		}
+	}
+
+	if oidcConfig, err := c.Auth.OIDC.Verifier(); err != nil {
+		add("auth.oidc.roleScopes: %v", err)
+	} else if oidcConfig.Enabled() {
+		for _, u := range []struct{ name, value string }{
+			{"issuer", c.Auth.OIDC.Issuer},
+			{"jwksUrl", c.Auth.OIDC.JWKSURL},
+		} {
+			if u.value != "" && !validURL(u.value) {
+				add("auth.oidc.%s: URL inválida %q", u.name, u.value)
+			}
+		}
+		// Exige a audiência e carrega o JWKS local, para que o erro apareça aqui e não
+		// ao montar as rotas
+		if _, err := oidc.NewVerifier(oidcConfig); err != nil {
+			add("auth.oidc: %v", err)
+		}
+	}
+
+	if len(c.CORS.AllowedOrigins) == 0 {
+		add("cors.allowedOrigins deve ter ao menos uma origem ou *")
+	}
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin != "*" && !validURL(origin) {
+			add("cors.allowedOrigins: origem inválida %q", origin)
+		}
+	}
+
+	for _, limit := range []struct{ name, value string }{
+		{"public", c.RateLimit.Public},
+		{"stats", c.RateLimit.Stats},
+		{"admin", c.RateLimit.Admin},
+		{"auth", c.RateLimit.Auth},
+	} {
+		if _, err := ratelimit.ParseLimit(limit.value); err != nil {
+			add("rateLimit.%s: %v", limit.name, err)
+		}
+	}
+
+	if c.Cache.TTL.Duration < 0 {
+		add("cache.ttl não pode ser negativo")
+	}
+	if c.Cache.MaxAge < 0 {
+		add("cache.maxAge não pode ser negativo")
+	}
+	if c.Cache.MaxEntries < 0 {
+		add("cache.maxEntries não pode ser negativo")
+	}
+
+	if len(c.Scraper.Sources) == 0 {
+		add("scraper.sources deve ter ao menos uma região")
+	}
+	for region, source := range c.Scraper.Sources {
+		if !validURL(source) {
+			add("scraper.sources.%s: URL inválida %q", region, source)
+		}
+	}
+	if _, err := cron.ParseStandard(c.Scraper.Schedule); err != nil {
+		add("scraper.schedule: %v", err)
+	}
+	if c.Scraper.Timeout.Duration <= 0 {
+		add("scraper.timeout deve ser positivo")
+	}
+	if c.Scraper.MaxRetries < 1 {
+		add("scraper.maxRetries deve ser ao menos 1")
+	}
+	if c.Scraper.RetryDelay.Duration < 0 {
+		add("scraper.retryDelay não pode ser negativo")
+	}
+
+	if c.Trash.RetentionDays < 0 {
+		add("trash.retentionDays não pode ser negativo")
+	}
+	if c.APIKeys.UsageRetentionDays < 0 {
+		add("apiKeys.usageRetentionDays não pode ser negativo")
+	}
+	if c.APIKeys.UsageFlushInterval.Duration <= 0 {
+		add("apiKeys.usageFlushInterval deve ser positivo")
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return errors.New("configuração inválida: " + strings.Join(problems, "; "))
+}
+
+// validURL aceita URLs http ou https com host
+func validURL(raw string) bool {
+	parsed, err := url.Parse(raw)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+// validProxy aceita um IP ou uma rede em notação CIDR
+func validProxy(raw string) bool {
+	if _, _, err := net.ParseCIDR(raw); err == nil {
+		return true
+	}
+	return net.ParseIP(raw) != nil
+}
+
+// Duration é um time.Duration lido e escrito como texto, como "10s" ou "5m"
+type Duration struct {
+	time.Duration
+}
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	d.Duration = parsed
+	return nil
+}
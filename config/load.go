@@ -0,0 +1,184 @@
+package config
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Load monta a configuração: parte dos padrões, aplica o arquivo em path (ou em
+// CONFIG_FILE, quando path é vazio), o .env do diretório atual e as variáveis de
+// ambiente, e valida o resultado. O .env não sobrescreve variáveis já definidas.
+func Load(path string) (*Config, error) {
+	cfg := Defaults()
+
+	if path == "" {
+		path = os.Getenv("CONFIG_FILE")
+	}
+	if path != "" {
+		if err := loadFile(cfg, path); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("erro ao ler .env: %w", err)
+	}
+	if err := applyEnv(reflect.ValueOf(cfg).Elem()); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// loadFile aplica um arquivo YAML (.yaml ou .yml) ou TOML (.toml) sobre cfg. Campos
+// ausentes no arquivo mantêm o valor atual; as fontes do scraper, quando presentes,
+// substituem as padrão em vez de se somarem a elas.
+func loadFile(cfg *Config, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("erro ao ler o arquivo de configuração: %w", err)
+	}
+
+	sources := cfg.Scraper.Sources
+	cfg.Scraper.Sources = nil
+	defer func() {
+		if cfg.Scraper.Sources == nil {
+			cfg.Scraper.Sources = sources
+		}
+	}()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(cfg)
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+	case ".toml":
+		err = toml.NewDecoder(bytes.NewReader(data)).DisallowUnknownFields().Decode(cfg)
+	default:
+		return fmt.Errorf("formato do arquivo de configuração não suportado: %s (use .yaml, .yml ou .toml)", path)
+	}
+	if err != nil {
+		return fmt.Errorf("erro no arquivo de configuração %s: %w", path, err)
+	}
+	return nil
+}
+
+var textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// applyEnv preenche os campos com tag env a partir das variáveis definidas
+func applyEnv(v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field, value := t.Field(i), v.Field(i)
+
+		name := field.Tag.Get("env")
+		if name == "" {
+			if value.Kind() == reflect.Struct {
+				if err := applyEnv(value); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		raw, ok := os.LookupEnv(name)
+		if !ok || raw == "" {
+			continue
+		}
+		if err := setValue(value, strings.TrimSpace(raw)); err != nil {
+			return fmt.Errorf("%s inválido: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// setValue converte o texto de uma variável de ambiente para o tipo do campo. Listas
+// e mapas usam vírgulas, como "a,b" e "sul=https://...,norte=https://...".
+func setValue(value reflect.Value, raw string) error {
+	if value.Addr().Type().Implements(textUnmarshaler) {
+		return value.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw))
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(n))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+	case reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		value.Set(reflect.ValueOf(items))
+	case reflect.Map:
+		entries := make(map[string]string)
+		for _, item := range strings.Split(raw, ",") {
+			key, val, ok := strings.Cut(item, "=")
+			if !ok {
+				return fmt.Errorf("use chave=valor separados por vírgula")
+			}
+			entries[strings.TrimSpace(key)] = strings.TrimSpace(val)
+		}
+		value.Set(reflect.ValueOf(entries))
+	default:
+		return fmt.Errorf("tipo não suportado: %s", value.Type())
+	}
+	return nil
+}
+
+// Redacted retorna uma cópia da configuração com os campos secretos ocultados, para
+// exibição e logs
+func (c *Config) Redacted() *Config {
+	copied := *c
+	redact(reflect.ValueOf(&copied).Elem())
+	return &copied
+}
+
+func redact(v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field, value := t.Field(i), v.Field(i)
+		switch {
+		case field.Tag.Get("secret") == "true" && value.Kind() == reflect.String && value.String() != "":
+			value.SetString("******")
+		case value.Kind() == reflect.Struct:
+			redact(value)
+		}
+	}
+}
+
+// YAML serializa a configuração no formato aceito por Load
+func (c *Config) YAML() ([]byte, error) {
+	return yaml.Marshal(c)
+}
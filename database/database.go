@@ -2,66 +2,149 @@ package database
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
-	"os"
 	"time"
 
+	"github.com/bulletdev/lta-results-api/config"
+	bolterrors "go.etcd.io/bbolt/errors"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
-	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
 )
 
-var client *mongo.Client
+// Collection reúne as operações usadas pelos modelos. *mongo.Collection a implementa
+// diretamente; o armazenamento embutido a emula sobre um arquivo local.
+type Collection interface {
+	Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (*mongo.Cursor, error)
+	FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) *mongo.SingleResult
+	FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) *mongo.SingleResult
+	CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error)
+	Distinct(ctx context.Context, fieldName string, filter interface{}, opts ...*options.DistinctOptions) ([]interface{}, error)
+	InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error)
+	UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
+	ReplaceOne(ctx context.Context, filter interface{}, replacement interface{}, opts ...*options.ReplaceOptions) (*mongo.UpdateResult, error)
+	BulkWrite(ctx context.Context, models []mongo.WriteModel, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error)
+	DeleteOne(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error)
+	DeleteMany(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error)
+}
 
-// Connect estabelece conexão com o MongoDB
-func Connect() error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+// Index descreve um índice de uma coleção
+type Index struct {
+	Collection string
+	Keys       bson.D
+	Unique     bool
+}
 
-	// Obter credenciais das variáveis de ambiente
-	username := os.Getenv("MONGODB_USERNAME")
-	password := os.Getenv("MONGODB_PASSWORD")
-	cluster := os.Getenv("MONGODB_CLUSTER")
+// store é o armazenamento aberto por Connect
+type store interface {
+	collection(name string) Collection
+	withTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	ensureIndex(ctx context.Context, index Index) error
+	ping(ctx context.Context) error
+	close(ctx context.Context) error
+}
 
-	if username == "" || password == "" || cluster == "" {
-		log.Fatal("Variáveis de ambiente MONGODB_USERNAME, MONGODB_PASSWORD e MONGODB_CLUSTER são necessárias")
-	}
+var current store
 
-	// Configurar ServerAPI
-	serverAPI := options.ServerAPI(options.ServerAPIVersion1)
-	uri := "mongodb+srv://" + username + ":" + password + "@" + cluster + "/?retryWrites=true&w=majority&appName=lta-results"
-	opts := options.Client().ApplyURI(uri).SetServerAPIOptions(serverAPI)
+// Connect abre o armazenamento configurado: o MongoDB ou o arquivo local do backend
+// embutido
+func Connect(cfg config.Database) error {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ConnectTimeout.Duration)
+	defer cancel()
 
 	var err error
-	client, err = mongo.Connect(ctx, opts)
-	if err != nil {
-		return err
+	switch cfg.Backend {
+	case "", config.BackendMongo:
+		current, err = connectMongo(ctx, cfg)
+		if err == nil {
+			log.Println("Conexão com o MongoDB estabelecida com sucesso")
+		}
+	case config.BackendEmbedded:
+		current, err = openEmbedded(ctx, cfg.Path)
+		if err == nil {
+			log.Printf("Armazenamento embutido aberto em %s", cfg.Path)
+		}
+	default:
+		err = fmt.Errorf("backend de armazenamento desconhecido: %s", cfg.Backend)
 	}
-
-	// Verificar a conexão
-	if err := client.Ping(ctx, readpref.Primary()); err != nil {
-		return err
-	}
-
-	log.Println("Conexão com o MongoDB estabelecida com sucesso")
-	return nil
+	return err
 }
 
-// Close encerra a conexão com o MongoDB
+// Close encerra a conexão com o armazenamento
 func Close() {
-	if client == nil {
+	if current == nil {
 		return
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	if err := client.Disconnect(ctx); err != nil {
-		log.Printf("Erro ao fechar conexão com o MongoDB: %v", err)
+	if err := current.close(ctx); err != nil {
+		log.Printf("Erro ao fechar o armazenamento: %v", err)
 	}
+	current = nil
 }
 
 // GetCollection retorna uma coleção específica
-func GetCollection(name string) *mongo.Collection {
-	return client.Database(os.Getenv("MONGODB_DATABASE")).Collection(name)
+func GetCollection(name string) Collection {
+	return current.collection(name)
+}
+
+// WithTransaction executa fn dentro de uma transação. Em um mongod standalone, aceito
+// com MONGODB_ALLOW_STANDALONE, fn é executada sem transação.
+func WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return current.withTransaction(ctx, fn)
+}
+
+// EnsureIndexes cria os índices que ainda não existem. Um índice que falha, como um
+// único sobre dados já repetidos, não impede a criação dos demais; os erros são
+// retornados juntos.
+func EnsureIndexes(indexes []Index) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var errs []error
+	for _, index := range indexes {
+		if err := current.ensureIndex(ctx, index); err != nil {
+			errs = append(errs, fmt.Errorf("%s %v: %w", index.Collection, indexFields(index.Keys), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// indexFields lista os campos do índice
+func indexFields(keys bson.D) []string {
+	fields := make([]string, len(keys))
+	for i, key := range keys {
+		fields[i] = key.Key
+	}
+	return fields
+}
+
+// Ping verifica se o armazenamento está respondendo
+func Ping(ctx context.Context) error {
+	if current == nil {
+		return errors.New("armazenamento não conectado")
+	}
+	return current.ping(ctx)
+}
+
+// IsUnavailable indica se o erro foi causado pela indisponibilidade do banco
+// (timeout, falha de rede ou nenhum servidor disponível), e não pela operação em si
+func IsUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, mongo.ErrClientDisconnected) ||
+		errors.Is(err, bolterrors.ErrDatabaseNotOpen) || errors.Is(err, bolterrors.ErrTimeout) {
+		return true
+	}
+	var selectionErr topology.ServerSelectionError
+	if errors.As(err, &selectionErr) {
+		return true
+	}
+	return mongo.IsTimeout(err) || mongo.IsNetworkError(err)
 }
@@ -0,0 +1,824 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	bolterrors "go.etcd.io/bbolt/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// embeddedStore guarda os dados em um único arquivo BoltDB, para instalações de um nó
+// sem MongoDB. Cada coleção é um bucket com os documentos em BSON, indexados pelo _id;
+// as consultas percorrem a coleção inteira e entendem os operadores usados pelos
+// modelos. As transações do BoltDB são serializadas, então WithTransaction é atômico.
+type embeddedStore struct {
+	db *bolt.DB
+
+	// unique guarda os campos dos índices únicos de cada coleção, verificados a cada
+	// gravação. As definições também ficam no arquivo e são carregadas ao abri-lo.
+	mu     sync.RWMutex
+	unique map[string][][]string
+}
+
+// Buckets internos, que não colidem com as coleções: as definições dos índices únicos
+// e, para cada índice, os valores em uso com a chave do documento que os usa
+const (
+	indexesBucket      = "_indexes"
+	uniqueBucketPrefix = "_unique."
+)
+
+// indexDefinition é a definição de um índice único gravada no arquivo
+type indexDefinition struct {
+	Collection string   `bson:"collection"`
+	Fields     []string `bson:"fields"`
+}
+
+// txKey guarda no contexto a transação aberta por WithTransaction
+type txKey struct{}
+
+func openEmbedded(ctx context.Context, path string) (*embeddedStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+
+	// O arquivo fica travado enquanto aberto; espera outro processo liberá-lo até o
+	// timeout da conexão
+	timeout := 10 * time.Second
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: timeout})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao abrir %s: %w", path, err)
+	}
+
+	store := &embeddedStore{db: db, unique: make(map[string][][]string)}
+	if err := store.loadIndexes(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("erro ao carregar os índices de %s: %w", path, err)
+	}
+	return store, nil
+}
+
+// loadIndexes carrega as definições dos índices únicos criados em aberturas anteriores
+func (s *embeddedStore) loadIndexes() error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(indexesBucket))
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(_, v []byte) error {
+			var def indexDefinition
+			if err := bson.Unmarshal(v, &def); err != nil {
+				return err
+			}
+			s.unique[def.Collection] = append(s.unique[def.Collection], def.Fields)
+			return nil
+		})
+	})
+}
+
+func (s *embeddedStore) collection(name string) Collection {
+	return &embeddedCollection{db: s.db, name: []byte(name), store: s}
+}
+
+func (s *embeddedStore) withTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*bolt.Tx); ok {
+		return fn(ctx)
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// ensureIndex cria os índices únicos, que passam a ser verificados nas gravações. Os
+// demais não têm efeito, pois as consultas sempre percorrem a coleção inteira.
+func (s *embeddedStore) ensureIndex(ctx context.Context, index Index) error {
+	if !index.Unique {
+		return nil
+	}
+	fields := indexFields(index.Keys)
+	for _, existing := range s.uniqueFields(index.Collection) {
+		if equalFields(existing, fields) {
+			return nil
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	name := uniqueBucketName(index.Collection, fields)
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket(name); err != nil && !errors.Is(err, bolterrors.ErrBucketNotFound) {
+			return err
+		}
+		values, err := tx.CreateBucket(name)
+		if err != nil {
+			return err
+		}
+
+		// Como no MongoDB, o índice não é criado sobre valores já repetidos
+		if docs := tx.Bucket([]byte(index.Collection)); docs != nil {
+			err := docs.ForEach(func(k, v []byte) error {
+				var doc bson.M
+				if err := bson.Unmarshal(v, &doc); err != nil {
+					return err
+				}
+				value, err := uniqueKey(doc, fields)
+				if err != nil {
+					return err
+				}
+				if values.Get(value) != nil {
+					return duplicateKeyError(fields, uniqueValues(doc, fields))
+				}
+				return values.Put(value, k)
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		definitions, err := tx.CreateBucketIfNotExists([]byte(indexesBucket))
+		if err != nil {
+			return err
+		}
+		data, err := bson.Marshal(indexDefinition{Collection: index.Collection, Fields: fields})
+		if err != nil {
+			return err
+		}
+		return definitions.Put(name, data)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.unique[index.Collection] = append(s.unique[index.Collection], fields)
+	return nil
+}
+
+// uniqueBucketName é o nome do bucket com os valores do índice único
+func uniqueBucketName(collection string, fields []string) []byte {
+	return []byte(uniqueBucketPrefix + collection + "." + strings.Join(fields, ","))
+}
+
+func (s *embeddedStore) uniqueFields(name string) [][]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.unique[name]
+}
+
+// ping abre uma transação de leitura, que falha quando o arquivo já foi fechado
+func (s *embeddedStore) ping(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.db.View(func(*bolt.Tx) error { return nil })
+}
+
+func (s *embeddedStore) close(context.Context) error {
+	return s.db.Close()
+}
+
+// embeddedCollection implementa Collection sobre um bucket
+type embeddedCollection struct {
+	db    *bolt.DB
+	name  []byte
+	store *embeddedStore
+}
+
+// bucket é o bucket aberto para gravação, com os índices únicos da coleção
+type bucket struct {
+	*bolt.Bucket
+	name   string
+	unique [][]string
+}
+
+// record é um documento lido do bucket
+type record struct {
+	key []byte
+	doc bson.M
+}
+
+// view executa fn com o bucket da coleção, que é nil enquanto ela estiver vazia
+func (c *embeddedCollection) view(ctx context.Context, fn func(b *bolt.Bucket) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if tx, ok := ctx.Value(txKey{}).(*bolt.Tx); ok {
+		return fn(tx.Bucket(c.name))
+	}
+	return c.db.View(func(tx *bolt.Tx) error {
+		return fn(tx.Bucket(c.name))
+	})
+}
+
+// update executa fn com o bucket da coleção, criado se preciso, na transação do
+// contexto ou em uma nova
+func (c *embeddedCollection) update(ctx context.Context, fn func(b *bucket) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	run := func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(c.name)
+		if err != nil {
+			return err
+		}
+		return fn(&bucket{Bucket: b, name: string(c.name), unique: c.store.uniqueFields(string(c.name))})
+	}
+	if tx, ok := ctx.Value(txKey{}).(*bolt.Tx); ok {
+		return run(tx)
+	}
+	return c.db.Update(run)
+}
+
+// query retorna os documentos que atendem ao filtro, na ordem do _id
+func query(b *bolt.Bucket, filter interface{}) ([]record, error) {
+	f, err := toDoc(filter)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, nil
+	}
+
+	var records []record
+	err = b.ForEach(func(k, v []byte) error {
+		var doc bson.M
+		if err := bson.Unmarshal(v, &doc); err != nil {
+			return err
+		}
+		ok, err := matches(doc, f)
+		if ok {
+			records = append(records, record{key: append([]byte(nil), k...), doc: doc})
+		}
+		return err
+	})
+	return records, err
+}
+
+// put grava o documento sob a chave derivada do _id, recusando valores repetidos nos
+// índices únicos
+func put(b *bucket, doc bson.M) ([]byte, error) {
+	key, err := documentKey(doc["_id"])
+	if err != nil {
+		return nil, err
+	}
+
+	var previous bson.M
+	if data := b.Get(key); data != nil && len(b.unique) > 0 {
+		if err := bson.Unmarshal(data, &previous); err != nil {
+			return nil, err
+		}
+	}
+	if err := indexDocument(b, key, previous, doc); err != nil {
+		return nil, err
+	}
+
+	data, err := bson.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return key, b.Put(key, data)
+}
+
+// indexEntry é a alteração de um documento em um índice único
+type indexEntry struct {
+	values        *bolt.Bucket
+	previous, key []byte
+}
+
+// indexDocument atualiza os índices únicos da gravação de doc, que substitui previous (nil em
+// uma inserção). Todos os índices são verificados antes de qualquer alteração, para que
+// uma chave duplicada não os deixe pela metade.
+func indexDocument(b *bucket, key []byte, previous, doc bson.M) error {
+	entries := make([]indexEntry, 0, len(b.unique))
+	for _, fields := range b.unique {
+		values := b.Tx().Bucket(uniqueBucketName(b.name, fields))
+		if values == nil {
+			return fmt.Errorf("índice único de %s %v não encontrado", b.name, fields)
+		}
+		value, err := uniqueKey(doc, fields)
+		if err != nil {
+			return err
+		}
+		if owner := values.Get(value); owner != nil && !bytes.Equal(owner, key) {
+			return duplicateKeyError(fields, uniqueValues(doc, fields))
+		}
+
+		entry := indexEntry{values: values, key: value}
+		if previous != nil {
+			if entry.previous, err = uniqueKey(previous, fields); err != nil {
+				return err
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	for _, entry := range entries {
+		if entry.previous != nil && !bytes.Equal(entry.previous, entry.key) {
+			if err := entry.values.Delete(entry.previous); err != nil {
+				return err
+			}
+		}
+		if err := entry.values.Put(entry.key, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unindexDocument remove dos índices únicos os valores do documento excluído
+func unindexDocument(b *bucket, key []byte, doc bson.M) error {
+	for _, fields := range b.unique {
+		values := b.Tx().Bucket(uniqueBucketName(b.name, fields))
+		if values == nil {
+			continue
+		}
+		value, err := uniqueKey(doc, fields)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(values.Get(value), key) {
+			if err := values.Delete(value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// uniqueKey codifica os valores dos campos do índice como chave no bucket de valores.
+// Números de tipos diferentes, iguais para o MongoDB, têm a mesma chave.
+func uniqueKey(doc bson.M, fields []string) ([]byte, error) {
+	values := uniqueValues(doc, fields)
+	for i, value := range values {
+		switch value.(type) {
+		case int32, int64, float64:
+			values[i] = toFloat(value)
+		}
+	}
+	return bson.Marshal(bson.M{"v": values})
+}
+
+// uniqueValues retorna os valores dos campos do índice; um campo ausente vale null,
+// como no MongoDB
+func uniqueValues(doc bson.M, fields []string) []interface{} {
+	values := make([]interface{}, len(fields))
+	for i, field := range fields {
+		values[i] = first(doc, field)
+	}
+	return values
+}
+
+func equalFields(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// documentKey usa os bytes do ObjectID como chave, mantendo a ordem de criação
+func documentKey(id interface{}) ([]byte, error) {
+	oid, ok := id.(primitive.ObjectID)
+	if !ok {
+		return nil, fmt.Errorf("o armazenamento embutido exige _id do tipo ObjectID, recebido %T", id)
+	}
+	return oid[:], nil
+}
+
+// sortRecords ordena os documentos pela especificação de Sort
+func sortRecords(records []record, spec interface{}) error {
+	if spec == nil {
+		return nil
+	}
+	keys, err := sortKeys(spec)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		for _, key := range keys {
+			if cmp := compareValues(first(records[i].doc, key.field), first(records[j].doc, key.field)); cmp != 0 {
+				return cmp*key.order < 0
+			}
+		}
+		return false
+	})
+	return nil
+}
+
+// page aplica skip e limit
+func page(records []record, skip, limit *int64) []record {
+	if skip != nil && *skip > 0 {
+		if *skip >= int64(len(records)) {
+			return nil
+		}
+		records = records[*skip:]
+	}
+	if limit != nil && *limit != 0 {
+		n := *limit
+		if n < 0 {
+			n = -n
+		}
+		if n < int64(len(records)) {
+			records = records[:n]
+		}
+	}
+	return records
+}
+
+func (c *embeddedCollection) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (*mongo.Cursor, error) {
+	opt := options.MergeFindOptions(opts...)
+
+	var docs []interface{}
+	err := c.view(ctx, func(b *bolt.Bucket) error {
+		records, err := query(b, filter)
+		if err != nil {
+			return err
+		}
+		if err := sortRecords(records, opt.Sort); err != nil {
+			return err
+		}
+		for _, r := range page(records, opt.Skip, opt.Limit) {
+			doc, err := project(r.doc, opt.Projection)
+			if err != nil {
+				return err
+			}
+			docs = append(docs, doc)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mongo.NewCursorFromDocuments(docs, nil, nil)
+}
+
+func (c *embeddedCollection) FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) *mongo.SingleResult {
+	opt := options.MergeFindOneOptions(opts...)
+
+	var found bson.M
+	err := c.view(ctx, func(b *bolt.Bucket) error {
+		records, err := query(b, filter)
+		if err != nil {
+			return err
+		}
+		if err := sortRecords(records, opt.Sort); err != nil {
+			return err
+		}
+		if records = page(records, opt.Skip, nil); len(records) > 0 {
+			found, err = project(records[0].doc, opt.Projection)
+		}
+		return err
+	})
+	return singleResult(found, err)
+}
+
+// singleResult devolve o documento ou mongo.ErrNoDocuments, como o driver
+func singleResult(doc bson.M, err error) *mongo.SingleResult {
+	if err == nil && doc == nil {
+		err = mongo.ErrNoDocuments
+	}
+	if err != nil {
+		return mongo.NewSingleResultFromDocument(bson.D{}, err, nil)
+	}
+	return mongo.NewSingleResultFromDocument(doc, nil, nil)
+}
+
+func (c *embeddedCollection) FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) *mongo.SingleResult {
+	opt := options.MergeFindOneAndUpdateOptions(opts...)
+
+	var result bson.M
+	err := c.update(ctx, func(b *bucket) error {
+		records, err := query(b.Bucket, filter)
+		if err != nil {
+			return err
+		}
+		if err := sortRecords(records, opt.Sort); err != nil {
+			return err
+		}
+
+		var before, after bson.M
+		switch {
+		case len(records) > 0:
+			before = records[0].doc
+			if after, err = applyUpdate(clone(before), update, false); err != nil {
+				return err
+			}
+			if _, err := put(b, after); err != nil {
+				return err
+			}
+		case opt.Upsert != nil && *opt.Upsert:
+			if after, err = upsertDocument(filter, update); err != nil {
+				return err
+			}
+			if err := insert(b, after); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+
+		result = before
+		if opt.ReturnDocument != nil && *opt.ReturnDocument == options.After {
+			result = after
+		}
+		return nil
+	})
+	return singleResult(result, err)
+}
+
+func (c *embeddedCollection) CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error) {
+	opt := options.MergeCountOptions(opts...)
+
+	var count int64
+	err := c.view(ctx, func(b *bolt.Bucket) error {
+		records, err := query(b, filter)
+		count = int64(len(page(records, opt.Skip, opt.Limit)))
+		return err
+	})
+	return count, err
+}
+
+func (c *embeddedCollection) Distinct(ctx context.Context, fieldName string, filter interface{}, _ ...*options.DistinctOptions) ([]interface{}, error) {
+	var values []interface{}
+	err := c.view(ctx, func(b *bolt.Bucket) error {
+		records, err := query(b, filter)
+		if err != nil {
+			return err
+		}
+		for _, r := range records {
+			for _, value := range lookup(r.doc, fieldName) {
+				// Arrays contribuem com os seus elementos, como no MongoDB
+				if _, isArray := value.(primitive.A); isArray {
+					continue
+				}
+				if !containsValue(values, value) {
+					values = append(values, value)
+				}
+			}
+		}
+		return nil
+	})
+	return values, err
+}
+
+func (c *embeddedCollection) InsertOne(ctx context.Context, document interface{}, _ ...*options.InsertOneOptions) (*mongo.InsertOneResult, error) {
+	doc, err := toDoc(document)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.update(ctx, func(b *bucket) error {
+		return insert(b, doc)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &mongo.InsertOneResult{InsertedID: doc["_id"]}, nil
+}
+
+// insert grava um documento novo, gerando o _id quando ausente
+func insert(b *bucket, doc bson.M) error {
+	if _, ok := doc["_id"]; !ok {
+		doc["_id"] = primitive.NewObjectID()
+	}
+	key, err := documentKey(doc["_id"])
+	if err != nil {
+		return err
+	}
+	if b.Get(key) != nil {
+		return duplicateKeyError([]string{"_id"}, []interface{}{doc["_id"]})
+	}
+	_, err = put(b, doc)
+	return err
+}
+
+// duplicateKeyError reproduz o erro do MongoDB, reconhecido por mongo.IsDuplicateKeyError
+func duplicateKeyError(fields []string, values []interface{}) error {
+	return mongo.WriteException{WriteErrors: []mongo.WriteError{{
+		Code:    11000,
+		Message: fmt.Sprintf("E11000 duplicate key error: %v %v", fields, values),
+	}}}
+}
+
+func (c *embeddedCollection) UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	opt := options.MergeUpdateOptions(opts...)
+	result := &mongo.UpdateResult{}
+	err := c.update(ctx, func(b *bucket) error {
+		return updateOne(b, filter, update, opt.Upsert != nil && *opt.Upsert, false, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *embeddedCollection) ReplaceOne(ctx context.Context, filter interface{}, replacement interface{}, opts ...*options.ReplaceOptions) (*mongo.UpdateResult, error) {
+	opt := options.MergeReplaceOptions(opts...)
+	result := &mongo.UpdateResult{}
+	err := c.update(ctx, func(b *bucket) error {
+		return updateOne(b, filter, replacement, opt.Upsert != nil && *opt.Upsert, true, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// updateOne altera ou substitui o primeiro documento do filtro, inserindo um novo
+// quando nenhum é encontrado e upsert é verdadeiro
+func updateOne(b *bucket, filter, update interface{}, upsert, replace bool, result *mongo.UpdateResult) error {
+	records, err := query(b.Bucket, filter)
+	if err != nil {
+		return err
+	}
+
+	if len(records) == 0 {
+		if !upsert {
+			return nil
+		}
+		var doc bson.M
+		if replace {
+			doc, err = replaceDocument(nil, filter, update)
+		} else {
+			doc, err = upsertDocument(filter, update)
+		}
+		if err != nil {
+			return err
+		}
+		if err := insert(b, doc); err != nil {
+			return err
+		}
+		result.UpsertedCount = 1
+		result.UpsertedID = doc["_id"]
+		return nil
+	}
+
+	current := records[0]
+	var next bson.M
+	if replace {
+		next, err = replaceDocument(current.doc, filter, update)
+	} else {
+		next, err = applyUpdate(clone(current.doc), update, false)
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := put(b, next); err != nil {
+		return err
+	}
+	result.MatchedCount = 1
+	if compareValues(current.doc, next) != 0 {
+		result.ModifiedCount = 1
+	}
+	return nil
+}
+
+// replaceDocument monta o documento substituto, preservando o _id do atual
+func replaceDocument(current bson.M, filter, replacement interface{}) (bson.M, error) {
+	next, err := toDoc(replacement)
+	if err != nil {
+		return nil, err
+	}
+	for key := range next {
+		if len(key) > 0 && key[0] == '$' {
+			return nil, fmt.Errorf("o documento substituto não pode ter operadores: %s", key)
+		}
+	}
+
+	if current != nil {
+		if id, ok := next["_id"]; ok && compareValues(id, current["_id"]) != 0 {
+			return nil, errors.New("o _id de um documento não pode ser alterado")
+		}
+		next["_id"] = current["_id"]
+		return next, nil
+	}
+
+	if _, ok := next["_id"]; !ok {
+		base, err := equalityFields(filter)
+		if err != nil {
+			return nil, err
+		}
+		if id, ok := base["_id"]; ok {
+			next["_id"] = id
+		}
+	}
+	return next, nil
+}
+
+// upsertDocument monta o documento inserido por um upsert: os campos de igualdade do
+// filtro com a atualização aplicada
+func upsertDocument(filter, update interface{}) (bson.M, error) {
+	doc, err := equalityFields(filter)
+	if err != nil {
+		return nil, err
+	}
+	return applyUpdate(doc, update, true)
+}
+
+func (c *embeddedCollection) BulkWrite(ctx context.Context, models []mongo.WriteModel, _ ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error) {
+	result := &mongo.BulkWriteResult{UpsertedIDs: make(map[int64]interface{})}
+
+	// As operações são aplicadas em ordem e, como estão em uma única transação, uma
+	// falha desfaz as anteriores
+	err := c.update(ctx, func(b *bucket) error {
+		for i, model := range models {
+			var res mongo.UpdateResult
+			var err error
+			switch m := model.(type) {
+			case *mongo.InsertOneModel:
+				var doc bson.M
+				if doc, err = toDoc(m.Document); err == nil {
+					if err = insert(b, doc); err == nil {
+						result.InsertedCount++
+					}
+				}
+			case *mongo.UpdateOneModel:
+				err = updateOne(b, m.Filter, m.Update, m.Upsert != nil && *m.Upsert, false, &res)
+			case *mongo.ReplaceOneModel:
+				err = updateOne(b, m.Filter, m.Replacement, m.Upsert != nil && *m.Upsert, true, &res)
+			case *mongo.DeleteOneModel:
+				var deleted int64
+				deleted, err = deleteRecords(b, m.Filter, true)
+				result.DeletedCount += deleted
+			default:
+				err = fmt.Errorf("operação %T não suportada pelo armazenamento embutido", model)
+			}
+			if err != nil {
+				return err
+			}
+
+			result.MatchedCount += res.MatchedCount
+			result.ModifiedCount += res.ModifiedCount
+			result.UpsertedCount += res.UpsertedCount
+			if res.UpsertedID != nil {
+				result.UpsertedIDs[int64(i)] = res.UpsertedID
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *embeddedCollection) DeleteOne(ctx context.Context, filter interface{}, _ ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	return c.delete(ctx, filter, true)
+}
+
+func (c *embeddedCollection) DeleteMany(ctx context.Context, filter interface{}, _ ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	return c.delete(ctx, filter, false)
+}
+
+func (c *embeddedCollection) delete(ctx context.Context, filter interface{}, one bool) (*mongo.DeleteResult, error) {
+	var deleted int64
+	err := c.update(ctx, func(b *bucket) error {
+		var err error
+		deleted, err = deleteRecords(b, filter, one)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &mongo.DeleteResult{DeletedCount: deleted}, nil
+}
+
+func deleteRecords(b *bucket, filter interface{}, one bool) (int64, error) {
+	records, err := query(b.Bucket, filter)
+	if err != nil {
+		return 0, err
+	}
+	if one && len(records) > 1 {
+		records = records[:1]
+	}
+	for _, r := range records {
+		if err := unindexDocument(b, r.key, r.doc); err != nil {
+			return 0, err
+		}
+		if err := b.Delete(r.key); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(records)), nil
+}
@@ -0,0 +1,347 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// openTestStore abre um armazenamento embutido em um diretório temporário
+func openTestStore(t *testing.T) *embeddedStore {
+	t.Helper()
+
+	store, err := openEmbedded(context.Background(), filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("erro ao abrir o armazenamento: %v", err)
+	}
+	t.Cleanup(func() { store.close(context.Background()) })
+	return store
+}
+
+// findAll retorna os documentos do filtro sem o _id, na ordem de gravação
+func findAll(t *testing.T, collection Collection, filter interface{}) []bson.M {
+	t.Helper()
+
+	ctx := context.Background()
+	cursor, err := collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 0}))
+	if err != nil {
+		t.Fatalf("erro na consulta: %v", err)
+	}
+	var docs []bson.M
+	if err := cursor.All(ctx, &docs); err != nil {
+		t.Fatalf("erro ao ler os documentos: %v", err)
+	}
+	return docs
+}
+
+func TestUpdateOne(t *testing.T) {
+	ctx := context.Background()
+	upsert := options.Update().SetUpsert(true)
+
+	tests := []struct {
+		name   string
+		filter bson.M
+		update bson.M
+		opts   *options.UpdateOptions
+		want   []bson.M
+		result mongo.UpdateResult
+	}{
+		{
+			name:   "atualiza o documento do filtro",
+			filter: bson.M{"matchId": "sul-1"},
+			update: bson.M{"$set": bson.M{"winner": "paiN"}, "$inc": bson.M{"version": 1}},
+			want:   []bson.M{{"matchId": "sul-1", "region": "sul", "version": int32(2), "winner": "paiN"}},
+			result: mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1},
+		},
+		{
+			name:   "sem alteração não conta como modificado",
+			filter: bson.M{"matchId": "sul-1"},
+			update: bson.M{"$set": bson.M{"region": "sul"}},
+			want:   []bson.M{{"matchId": "sul-1", "region": "sul", "version": int32(1)}},
+			result: mongo.UpdateResult{MatchedCount: 1},
+		},
+		{
+			name:   "sem upsert não insere",
+			filter: bson.M{"matchId": "sul-2"},
+			update: bson.M{"$set": bson.M{"winner": "paiN"}},
+			want:   []bson.M{{"matchId": "sul-1", "region": "sul", "version": int32(1)}},
+		},
+		{
+			// Os campos de igualdade do filtro, inclusive $eq e caminhos aninhados, formam
+			// a base do documento inserido; as condições com outros operadores não
+			name: "upsert a partir dos campos de igualdade do filtro",
+			filter: bson.M{
+				"matchId":     "sul-2",
+				"region":      bson.M{"$eq": "sul"},
+				"stats.split": 2,
+				"version":     bson.M{"$gte": 1},
+			},
+			update: bson.M{"$set": bson.M{"winner": "LOUD"}, "$setOnInsert": bson.M{"createdBy": "scraper"}, "$inc": bson.M{"version": 1}},
+			opts:   upsert,
+			want: []bson.M{
+				{"matchId": "sul-1", "region": "sul", "version": int32(1)},
+				{"matchId": "sul-2", "region": "sul", "stats": bson.M{"split": int32(2)}, "winner": "LOUD", "createdBy": "scraper", "version": int32(1)},
+			},
+			result: mongo.UpdateResult{UpsertedCount: 1},
+		},
+		{
+			name:   "upsert de documento existente ignora $setOnInsert",
+			filter: bson.M{"matchId": "sul-1"},
+			update: bson.M{"$set": bson.M{"winner": "paiN"}, "$setOnInsert": bson.M{"createdBy": "scraper"}},
+			opts:   upsert,
+			want:   []bson.M{{"matchId": "sul-1", "region": "sul", "version": int32(1), "winner": "paiN"}},
+			result: mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collection := openTestStore(t).collection("matches")
+			if _, err := collection.InsertOne(ctx, bson.M{"matchId": "sul-1", "region": "sul", "version": 1}); err != nil {
+				t.Fatalf("erro ao inserir: %v", err)
+			}
+
+			var opts []*options.UpdateOptions
+			if tt.opts != nil {
+				opts = append(opts, tt.opts)
+			}
+			res, err := collection.UpdateOne(ctx, tt.filter, tt.update, opts...)
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+
+			if res.UpsertedID != nil {
+				if _, ok := res.UpsertedID.(primitive.ObjectID); !ok {
+					t.Errorf("UpsertedID = %v, esperado um ObjectID", res.UpsertedID)
+				}
+				res.UpsertedID = nil
+			}
+			if *res != tt.result {
+				t.Errorf("resultado = %+v, esperado %+v", *res, tt.result)
+			}
+			if got := findAll(t, collection, bson.M{}); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("documentos = %v, esperado %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplaceOne(t *testing.T) {
+	ctx := context.Background()
+	collection := openTestStore(t).collection("matches")
+
+	id := primitive.NewObjectID()
+	if _, err := collection.InsertOne(ctx, bson.M{"_id": id, "matchId": "sul-1", "winner": "paiN"}); err != nil {
+		t.Fatalf("erro ao inserir: %v", err)
+	}
+
+	// O substituto sem _id mantém o do documento atual
+	res, err := collection.ReplaceOne(ctx, bson.M{"matchId": "sul-1"}, bson.M{"matchId": "sul-1", "winner": "LOUD"})
+	if err != nil {
+		t.Fatalf("erro ao substituir: %v", err)
+	}
+	if res.MatchedCount != 1 || res.ModifiedCount != 1 {
+		t.Errorf("resultado = %+v, esperado 1 encontrado e 1 modificado", *res)
+	}
+
+	var replaced bson.M
+	if err := collection.FindOne(ctx, bson.M{"_id": id}).Decode(&replaced); err != nil {
+		t.Fatalf("documento substituído não encontrado pelo _id: %v", err)
+	}
+	if want := (bson.M{"_id": id, "matchId": "sul-1", "winner": "LOUD"}); !reflect.DeepEqual(replaced, want) {
+		t.Errorf("documento = %v, esperado %v", replaced, want)
+	}
+
+	// Um _id diferente no substituto é recusado
+	if _, err := collection.ReplaceOne(ctx, bson.M{"_id": id}, bson.M{"_id": primitive.NewObjectID()}); err == nil {
+		t.Error("substituição que altera o _id aceita")
+	}
+
+	// No upsert, o _id do filtro é o do documento inserido
+	newID := primitive.NewObjectID()
+	res, err = collection.ReplaceOne(ctx, bson.M{"_id": newID}, bson.M{"matchId": "sul-2"}, options.Replace().SetUpsert(true))
+	if err != nil {
+		t.Fatalf("erro no upsert: %v", err)
+	}
+	if res.UpsertedCount != 1 || res.UpsertedID != newID {
+		t.Errorf("upsert = %+v, esperado o _id %s", *res, newID.Hex())
+	}
+}
+
+func TestBulkWriteUpsertedIDs(t *testing.T) {
+	ctx := context.Background()
+	collection := openTestStore(t).collection("matches")
+
+	if _, err := collection.InsertOne(ctx, bson.M{"matchId": "sul-2", "version": 1}); err != nil {
+		t.Fatalf("erro ao inserir: %v", err)
+	}
+
+	upsert := func(matchID string) mongo.WriteModel {
+		return mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"matchId": matchID}).
+			SetUpdate(bson.M{"$inc": bson.M{"version": 1}}).
+			SetUpsert(true)
+	}
+	res, err := collection.BulkWrite(ctx, []mongo.WriteModel{
+		upsert("sul-1"),
+		upsert("sul-2"),
+		mongo.NewDeleteOneModel().SetFilter(bson.M{"matchId": "inexistente"}),
+		upsert("sul-3"),
+	})
+	if err != nil {
+		t.Fatalf("erro no BulkWrite: %v", err)
+	}
+
+	// Os índices de UpsertedIDs são as posições das operações que inseriram
+	if len(res.UpsertedIDs) != 2 || res.UpsertedIDs[0] == nil || res.UpsertedIDs[3] == nil {
+		t.Fatalf("UpsertedIDs = %v, esperado as posições 0 e 3", res.UpsertedIDs)
+	}
+	if res.UpsertedCount != 2 || res.MatchedCount != 1 || res.ModifiedCount != 1 || res.DeletedCount != 0 {
+		t.Errorf("resultado = %+v", *res)
+	}
+	for i, matchID := range map[int64]string{0: "sul-1", 3: "sul-3"} {
+		var doc bson.M
+		if err := collection.FindOne(ctx, bson.M{"_id": res.UpsertedIDs[i]}).Decode(&doc); err != nil {
+			t.Fatalf("documento da posição %d não encontrado: %v", i, err)
+		}
+		if doc["matchId"] != matchID {
+			t.Errorf("posição %d inseriu %v, esperado %s", i, doc["matchId"], matchID)
+		}
+	}
+}
+
+func TestBulkWriteRollsBackOnError(t *testing.T) {
+	ctx := context.Background()
+	store := openTestStore(t)
+	if err := store.ensureIndex(ctx, Index{Collection: "matches", Keys: bson.D{{Key: "matchId", Value: 1}}, Unique: true}); err != nil {
+		t.Fatalf("erro ao criar o índice: %v", err)
+	}
+	collection := store.collection("matches")
+
+	_, err := collection.BulkWrite(ctx, []mongo.WriteModel{
+		mongo.NewInsertOneModel().SetDocument(bson.M{"matchId": "sul-1"}),
+		mongo.NewInsertOneModel().SetDocument(bson.M{"matchId": "sul-1"}),
+	})
+	if !mongo.IsDuplicateKeyError(err) {
+		t.Fatalf("erro = %v, esperado erro de chave duplicada", err)
+	}
+	if docs := findAll(t, collection, bson.M{}); len(docs) != 0 {
+		t.Errorf("%d documentos após a falha, esperado 0", len(docs))
+	}
+}
+
+func TestUniqueIndex(t *testing.T) {
+	ctx := context.Background()
+	store := openTestStore(t)
+	collection := store.collection("matches")
+
+	if _, err := collection.InsertOne(ctx, bson.M{"matchId": "sul-1", "region": "sul"}); err != nil {
+		t.Fatalf("erro ao inserir: %v", err)
+	}
+	if _, err := collection.InsertOne(ctx, bson.M{"region": "norte"}); err != nil {
+		t.Fatalf("erro ao inserir: %v", err)
+	}
+
+	index := Index{Collection: "matches", Keys: bson.D{{Key: "matchId", Value: 1}}, Unique: true}
+	if err := store.ensureIndex(ctx, index); err != nil {
+		t.Fatalf("erro ao criar o índice: %v", err)
+	}
+
+	if _, err := collection.InsertOne(ctx, bson.M{"matchId": "sul-1"}); !mongo.IsDuplicateKeyError(err) {
+		t.Errorf("inserção repetida: erro = %v, esperado chave duplicada", err)
+	}
+	// Um campo ausente vale null, que também se repete
+	if _, err := collection.InsertOne(ctx, bson.M{"region": "sul"}); !mongo.IsDuplicateKeyError(err) {
+		t.Errorf("segundo documento sem matchId: erro = %v, esperado chave duplicada", err)
+	}
+	_, err := collection.UpdateOne(ctx, bson.M{"region": "norte"}, bson.M{"$set": bson.M{"matchId": "sul-1"}})
+	if !mongo.IsDuplicateKeyError(err) {
+		t.Errorf("atualização para um matchId existente: erro = %v, esperado chave duplicada", err)
+	}
+	// Regravar o próprio documento não conflita com ele mesmo
+	if _, err := collection.UpdateOne(ctx, bson.M{"matchId": "sul-1"}, bson.M{"$set": bson.M{"winner": "paiN"}}); err != nil {
+		t.Errorf("atualização do mesmo documento recusada: %v", err)
+	}
+
+	// O índice não é criado sobre valores já repetidos
+	if _, err := collection.InsertOne(ctx, bson.M{"matchId": "sul-2", "region": "sul"}); err != nil {
+		t.Fatalf("erro ao inserir: %v", err)
+	}
+	regionIndex := Index{Collection: "matches", Keys: bson.D{{Key: "region", Value: 1}}, Unique: true}
+	if err := store.ensureIndex(ctx, regionIndex); !mongo.IsDuplicateKeyError(err) {
+		t.Errorf("índice sobre valores repetidos: erro = %v, esperado chave duplicada", err)
+	}
+}
+
+func TestUniqueIndexMaintenance(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "test.db")
+	store, err := openEmbedded(ctx, path)
+	if err != nil {
+		t.Fatalf("erro ao abrir o armazenamento: %v", err)
+	}
+
+	index := Index{Collection: "keys", Keys: bson.D{{Key: "hash", Value: 1}}, Unique: true}
+	if err := store.ensureIndex(ctx, index); err != nil {
+		t.Fatalf("erro ao criar o índice: %v", err)
+	}
+	collection := store.collection("keys")
+	for _, hash := range []string{"a", "b"} {
+		if _, err := collection.InsertOne(ctx, bson.M{"hash": hash}); err != nil {
+			t.Fatalf("erro ao inserir: %v", err)
+		}
+	}
+
+	// Trocar o valor libera o anterior
+	if _, err := collection.UpdateOne(ctx, bson.M{"hash": "a"}, bson.M{"$set": bson.M{"hash": "c"}}); err != nil {
+		t.Fatalf("erro ao atualizar: %v", err)
+	}
+	if _, err := collection.InsertOne(ctx, bson.M{"hash": "a"}); err != nil {
+		t.Errorf("valor liberado pela atualização recusado: %v", err)
+	}
+
+	// Excluir também
+	if _, err := collection.DeleteOne(ctx, bson.M{"hash": "b"}); err != nil {
+		t.Fatalf("erro ao excluir: %v", err)
+	}
+	if _, err := collection.InsertOne(ctx, bson.M{"hash": "b"}); err != nil {
+		t.Errorf("valor liberado pela exclusão recusado: %v", err)
+	}
+
+	// Números de tipos diferentes com o mesmo valor se repetem
+	if _, err := collection.InsertOne(ctx, bson.M{"hash": int32(7)}); err != nil {
+		t.Fatalf("erro ao inserir: %v", err)
+	}
+	if _, err := collection.InsertOne(ctx, bson.M{"hash": 7.0}); !mongo.IsDuplicateKeyError(err) {
+		t.Errorf("número repetido com outro tipo: erro = %v, esperado chave duplicada", err)
+	}
+
+	// Também dentro de uma transação
+	err = store.withTransaction(ctx, func(ctx context.Context) error {
+		_, err := collection.InsertOne(ctx, bson.M{"hash": "c"})
+		return err
+	})
+	if !mongo.IsDuplicateKeyError(err) {
+		t.Errorf("inserção repetida na transação: erro = %v, esperado chave duplicada", err)
+	}
+
+	// O índice continua valendo depois de reabrir o arquivo, sem novo ensureIndex
+	store.close(ctx)
+	store, err = openEmbedded(ctx, path)
+	if err != nil {
+		t.Fatalf("erro ao reabrir o armazenamento: %v", err)
+	}
+	t.Cleanup(func() { store.close(ctx) })
+
+	if _, err := store.collection("keys").InsertOne(ctx, bson.M{"hash": "c"}); !mongo.IsDuplicateKeyError(err) {
+		t.Errorf("inserção repetida após reabrir: erro = %v, esperado chave duplicada", err)
+	}
+	if err := store.ensureIndex(ctx, index); err != nil {
+		t.Errorf("recriar um índice existente: %v", err)
+	}
+}
@@ -0,0 +1,188 @@
+package database
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+
+	"github.com/bulletdev/lta-results-api/config"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
+)
+
+// mongoStore guarda os dados em um MongoDB, seja no Atlas, em um mongod local ou em um
+// replica set próprio
+type mongoStore struct {
+	client *mongo.Client
+	name   string
+	// transactions indica se o servidor é um replica set ou um mongos; em um mongod
+	// standalone as gravações são feitas sem transação
+	transactions bool
+}
+
+func connectMongo(ctx context.Context, cfg config.Database) (*mongoStore, error) {
+	opts := options.Client()
+	uri := cfg.URI
+	if uri == "" {
+		if cfg.Username == "" || cfg.Password == "" || cfg.Cluster == "" {
+			return nil, errors.New("informe MONGODB_URI ou MONGODB_USERNAME, MONGODB_PASSWORD e MONGODB_CLUSTER")
+		}
+		uri = "mongodb+srv://" + url.UserPassword(cfg.Username, cfg.Password).String() + "@" + cfg.Cluster + "/?retryWrites=true&w=majority&appName=lta-results"
+		// A Stable API é suportada pelo Atlas, mas não por servidores anteriores ao 5.0
+		opts.SetServerAPIOptions(options.ServerAPI(options.ServerAPIVersion1))
+	}
+	opts.ApplyURI(uri)
+
+	name := cfg.Name
+	if name == "" {
+		// O banco pode vir no caminho da URI, como em mongodb://localhost:27017/lta
+		parsed, err := connstring.ParseAndValidate(uri)
+		if err != nil {
+			return nil, err
+		}
+		if name = parsed.Database; name == "" {
+			return nil, errors.New("informe MONGODB_DATABASE ou o banco no caminho da MONGODB_URI")
+		}
+	}
+
+	if cfg.MaxPoolSize > 0 {
+		opts.SetMaxPoolSize(uint64(cfg.MaxPoolSize))
+	}
+	if cfg.MinPoolSize > 0 {
+		opts.SetMinPoolSize(uint64(cfg.MinPoolSize))
+	}
+	if cfg.MaxConnIdleTime.Duration > 0 {
+		opts.SetMaxConnIdleTime(cfg.MaxConnIdleTime.Duration)
+	}
+
+	tlsConfig, err := newTLSConfig(cfg.TLS)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		opts.SetTLSConfig(tlsConfig)
+	}
+
+	client, err := mongo.Connect(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verificar a conexão
+	if err := client.Ping(ctx, readpref.Primary()); err != nil {
+		client.Disconnect(context.Background())
+		return nil, err
+	}
+
+	// Sem transações, uma falha no meio de uma gravação deixa a partida sem a revisão
+	// ou o evento correspondente, então o modo standalone precisa ser aceito
+	// explicitamente
+	transactions, err := supportsTransactions(ctx, client)
+	if err != nil {
+		client.Disconnect(context.Background())
+		return nil, err
+	}
+	if !transactions {
+		if !cfg.AllowStandalone {
+			client.Disconnect(context.Background())
+			return nil, errors.New("o MongoDB é um mongod standalone, sem suporte a transações; use um replica set (mesmo de um único nó) ou defina MONGODB_ALLOW_STANDALONE=true para gravar sem transações")
+		}
+		log.Println("ATENÇÃO: o MongoDB é um mongod standalone; as gravações serão feitas sem transações e uma falha pode deixar partidas sem a revisão ou o evento correspondente")
+	}
+
+	return &mongoStore{client: client, name: name, transactions: transactions}, nil
+}
+
+// supportsTransactions consulta o servidor pelo comando hello: replica sets informam o
+// setName e os roteadores de um cluster shardado respondem msg "isdbgrid"
+func supportsTransactions(ctx context.Context, client *mongo.Client) (bool, error) {
+	var reply struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	admin := client.Database("admin")
+	err := admin.RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&reply)
+	if err != nil {
+		// Servidores anteriores ao 4.4.2 só conhecem o isMaster
+		if err := admin.RunCommand(ctx, bson.D{{Key: "isMaster", Value: 1}}).Decode(&reply); err != nil {
+			return false, fmt.Errorf("erro ao identificar a topologia do MongoDB: %w", err)
+		}
+	}
+	return reply.SetName != "" || reply.Msg == "isdbgrid", nil
+}
+
+// newTLSConfig monta a configuração TLS, ou nil quando ela não foi pedida e vale a da URI
+func newTLSConfig(cfg config.TLS) (*tls.Config, error) {
+	if !cfg.Enabled && cfg.CAFile == "" && cfg.CertificateKeyFile == "" && !cfg.Insecure {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: cfg.Insecure}
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler MONGODB_TLS_CA_FILE: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("nenhum certificado válido em %s", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if cfg.CertificateKeyFile != "" {
+		pem, err := os.ReadFile(cfg.CertificateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler MONGODB_TLS_CERTIFICATE_KEY_FILE: %w", err)
+		}
+		// O arquivo traz o certificado e a chave juntos, como no tlsCertificateKeyFile da URI
+		cert, err := tls.X509KeyPair(pem, pem)
+		if err != nil {
+			return nil, fmt.Errorf("certificado do cliente inválido: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+func (s *mongoStore) collection(name string) Collection {
+	return s.client.Database(s.name).Collection(name)
+}
+
+func (s *mongoStore) withTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if !s.transactions {
+		return fn(ctx)
+	}
+
+	session, err := s.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessCtx)
+	})
+	return err
+}
+
+func (s *mongoStore) ensureIndex(ctx context.Context, index Index) error {
+	model := mongo.IndexModel{Keys: index.Keys, Options: options.Index().SetUnique(index.Unique)}
+	_, err := s.client.Database(s.name).Collection(index.Collection).Indexes().CreateOne(ctx, model)
+	return err
+}
+
+func (s *mongoStore) ping(ctx context.Context) error {
+	return s.client.Ping(ctx, readpref.Primary())
+}
+
+func (s *mongoStore) close(ctx context.Context) error {
+	return s.client.Disconnect(ctx)
+}
@@ -0,0 +1,584 @@
+package database
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Este arquivo interpreta, para o armazenamento embutido, o subconjunto da linguagem
+// de consultas do MongoDB usado pelos modelos: igualdade (inclusive em campos de
+// arrays, como players.name), $or, $and, $nor, $eq, $ne, $in, $nin, $gt, $gte, $lt,
+// $lte e $exists nos filtros e $set, $unset, $inc e $setOnInsert nas atualizações.
+
+// toDoc converte filtros, atualizações e documentos para bson.M passando pelo BSON,
+// para que os valores sejam comparados com os mesmos tipos dos documentos gravados
+func toDoc(v interface{}) (bson.M, error) {
+	if v == nil {
+		return bson.M{}, nil
+	}
+	data, err := bson.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var doc bson.M
+	if err := bson.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// asDoc trata documentos aninhados, que podem vir como bson.M ou bson.D
+func asDoc(v interface{}) (bson.M, bool) {
+	switch d := v.(type) {
+	case bson.M:
+		return d, true
+	case bson.D:
+		return d.Map(), true
+	}
+	return nil, false
+}
+
+func clone(doc bson.M) bson.M {
+	copied, _ := toDoc(doc)
+	return copied
+}
+
+// matches indica se o documento atende ao filtro
+func matches(doc, filter bson.M) (bool, error) {
+	for key, cond := range filter {
+		var ok bool
+		var err error
+		switch key {
+		case "$or", "$and", "$nor":
+			ok, err = matchLogical(doc, key, cond)
+		default:
+			if strings.HasPrefix(key, "$") {
+				return false, fmt.Errorf("operador %s não suportado pelo armazenamento embutido", key)
+			}
+			ok, err = matchField(lookup(doc, key), cond)
+		}
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func matchLogical(doc bson.M, op string, cond interface{}) (bool, error) {
+	clauses, ok := cond.(primitive.A)
+	if !ok {
+		return false, fmt.Errorf("%s espera uma lista de filtros", op)
+	}
+
+	matched := 0
+	for _, clause := range clauses {
+		sub, ok := asDoc(clause)
+		if !ok {
+			return false, fmt.Errorf("%s espera uma lista de filtros", op)
+		}
+		ok, err := matches(doc, sub)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			matched++
+		}
+	}
+
+	switch op {
+	case "$or":
+		return matched > 0, nil
+	case "$and":
+		return matched == len(clauses), nil
+	default:
+		return matched == 0, nil
+	}
+}
+
+// matchField aplica a condição aos valores encontrados no caminho do campo
+func matchField(values []interface{}, cond interface{}) (bool, error) {
+	ops, ok := asDoc(cond)
+	if !ok || !isOperatorDoc(ops) {
+		return equalsAny(values, cond), nil
+	}
+
+	for op, arg := range ops {
+		var ok bool
+		switch op {
+		case "$eq":
+			ok = equalsAny(values, arg)
+		case "$ne":
+			ok = !equalsAny(values, arg)
+		case "$in", "$nin":
+			list, isList := arg.(primitive.A)
+			if !isList {
+				return false, fmt.Errorf("%s espera uma lista", op)
+			}
+			for _, candidate := range list {
+				if equalsAny(values, candidate) {
+					ok = true
+					break
+				}
+			}
+			if op == "$nin" {
+				ok = !ok
+			}
+		case "$gt", "$gte", "$lt", "$lte":
+			for _, value := range values {
+				if typeClass(value) != typeClass(arg) {
+					continue
+				}
+				cmp := compareValues(value, arg)
+				if (op == "$gt" && cmp > 0) || (op == "$gte" && cmp >= 0) ||
+					(op == "$lt" && cmp < 0) || (op == "$lte" && cmp <= 0) {
+					ok = true
+					break
+				}
+			}
+		case "$exists":
+			exists, _ := arg.(bool)
+			ok = (len(values) > 0) == exists
+		default:
+			return false, fmt.Errorf("operador %s não suportado pelo armazenamento embutido", op)
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func isOperatorDoc(doc bson.M) bool {
+	if len(doc) == 0 {
+		return false
+	}
+	for key := range doc {
+		if !strings.HasPrefix(key, "$") {
+			return false
+		}
+	}
+	return true
+}
+
+// equalsAny compara como o MongoDB: null também atende a campos ausentes e um array
+// atende se ele próprio ou algum elemento for igual ao valor
+func equalsAny(values []interface{}, want interface{}) bool {
+	if want == nil && len(values) == 0 {
+		return true
+	}
+	return containsValue(values, want)
+}
+
+func containsValue(values []interface{}, want interface{}) bool {
+	for _, value := range values {
+		if typeClass(value) == typeClass(want) && compareValues(value, want) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// lookup retorna os valores do caminho com pontos, percorrendo arrays de documentos.
+// Quando o valor final é um array, retorna o array e cada um dos seus elementos.
+func lookup(doc bson.M, path string) []interface{} {
+	head, rest, nested := strings.Cut(path, ".")
+	value, ok := doc[head]
+	if !ok {
+		return nil
+	}
+
+	if !nested {
+		if array, isArray := value.(primitive.A); isArray {
+			return append([]interface{}{value}, array...)
+		}
+		return []interface{}{value}
+	}
+
+	var values []interface{}
+	if array, isArray := value.(primitive.A); isArray {
+		for _, element := range array {
+			if sub, ok := asDoc(element); ok {
+				values = append(values, lookup(sub, rest)...)
+			}
+		}
+		return values
+	}
+	if sub, ok := asDoc(value); ok {
+		return lookup(sub, rest)
+	}
+	return nil
+}
+
+// first retorna o valor usado para ordenar pelo campo
+func first(doc bson.M, path string) interface{} {
+	if values := lookup(doc, path); len(values) > 0 {
+		return values[0]
+	}
+	return nil
+}
+
+// typeClass agrupa os tipos BSON na ordem de comparação do MongoDB
+func typeClass(v interface{}) int {
+	switch v.(type) {
+	case nil, primitive.Null, primitive.Undefined:
+		return 1
+	case int32, int64, float64, primitive.Decimal128:
+		return 2
+	case string, primitive.Symbol:
+		return 3
+	case bson.M, bson.D:
+		return 4
+	case primitive.A:
+		return 5
+	case primitive.Binary:
+		return 6
+	case primitive.ObjectID:
+		return 7
+	case bool:
+		return 8
+	case primitive.DateTime:
+		return 9
+	case primitive.Timestamp:
+		return 10
+	case primitive.Regex:
+		return 11
+	}
+	return 12
+}
+
+// compareValues ordena dois valores BSON, primeiro pelo tipo e depois pelo conteúdo
+func compareValues(a, b interface{}) int {
+	ca, cb := typeClass(a), typeClass(b)
+	if ca != cb {
+		return ca - cb
+	}
+
+	switch x := a.(type) {
+	case int32, int64, float64:
+		fa, fb := toFloat(x), toFloat(b)
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		}
+		return 0
+	case string:
+		y, _ := b.(string)
+		return strings.Compare(x, y)
+	case primitive.ObjectID:
+		y := b.(primitive.ObjectID)
+		return bytes.Compare(x[:], y[:])
+	case bool:
+		y := b.(bool)
+		switch {
+		case x == y:
+			return 0
+		case !x:
+			return -1
+		}
+		return 1
+	case primitive.DateTime:
+		y := b.(primitive.DateTime)
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	case primitive.A:
+		y := b.(primitive.A)
+		for i := 0; i < len(x) && i < len(y); i++ {
+			if cmp := compareValues(x[i], y[i]); cmp != 0 {
+				return cmp
+			}
+		}
+		return len(x) - len(y)
+	case bson.M, bson.D:
+		return compareDocs(a, b)
+	case nil, primitive.Null, primitive.Undefined:
+		return 0
+	}
+
+	// Demais tipos são comparados pela representação em BSON
+	_, da, _ := bson.MarshalValue(a)
+	_, db, _ := bson.MarshalValue(b)
+	return bytes.Compare(da, db)
+}
+
+func compareDocs(a, b interface{}) int {
+	x, _ := asDoc(a)
+	y, _ := asDoc(b)
+	keys := make(map[string]bool, len(x)+len(y))
+	for key := range x {
+		keys[key] = true
+	}
+	for key := range y {
+		keys[key] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	for _, key := range sorted {
+		vx, okx := x[key]
+		vy, oky := y[key]
+		switch {
+		case okx && !oky:
+			return 1
+		case !okx && oky:
+			return -1
+		}
+		if cmp := compareValues(vx, vy); cmp != 0 {
+			return cmp
+		}
+	}
+	return 0
+}
+
+func toFloat(v interface{}) float64 {
+	switch n := v.(type) {
+	case int32:
+		return float64(n)
+	case int64:
+		return float64(n)
+	case float64:
+		return n
+	}
+	return 0
+}
+
+// project aplica uma projeção de inclusão ({"campo": 1}) ou de exclusão
+// ({"campo": 0}). Na inclusão, o _id é mantido a menos que seja excluído.
+func project(doc bson.M, spec interface{}) (bson.M, error) {
+	if spec == nil {
+		return doc, nil
+	}
+	fields, err := toDoc(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	include, exclude := bson.M{}, []string{}
+	including, keepID := false, true
+	for path, flag := range fields {
+		on := toFloatAny(flag) != 0
+		if b, ok := flag.(bool); ok {
+			on = b
+		}
+		switch {
+		case path == "_id" && !on:
+			keepID = false
+		case on:
+			including = true
+			if values := lookup(doc, path); len(values) > 0 {
+				setPath(include, path, values[0])
+			}
+		default:
+			exclude = append(exclude, path)
+		}
+	}
+
+	// Sem campos incluídos a projeção é de exclusão, mesmo quando só exclui o _id
+	if including {
+		if len(exclude) > 0 {
+			return nil, fmt.Errorf("a projeção não pode misturar inclusão e exclusão")
+		}
+		if id, ok := doc["_id"]; ok && keepID {
+			include["_id"] = id
+		}
+		return include, nil
+	}
+
+	projected := clone(doc)
+	for _, path := range exclude {
+		unsetPath(projected, path)
+	}
+	if !keepID {
+		delete(projected, "_id")
+	}
+	return projected, nil
+}
+
+type sortKey struct {
+	field string
+	order int
+}
+
+// sortKeys lê a especificação de Sort, como bson.M{"date": -1} ou bson.D
+func sortKeys(spec interface{}) ([]sortKey, error) {
+	var pairs bson.D
+	switch s := spec.(type) {
+	case bson.D:
+		pairs = s
+	case bson.M:
+		if len(s) > 1 {
+			return nil, fmt.Errorf("ordenação por vários campos exige bson.D")
+		}
+		for field, order := range s {
+			pairs = append(pairs, bson.E{Key: field, Value: order})
+		}
+	default:
+		doc, err := toDoc(spec)
+		if err != nil {
+			return nil, err
+		}
+		return sortKeys(doc)
+	}
+
+	keys := make([]sortKey, 0, len(pairs))
+	for _, pair := range pairs {
+		order := 1
+		if toFloatAny(pair.Value) < 0 {
+			order = -1
+		}
+		keys = append(keys, sortKey{field: pair.Key, order: order})
+	}
+	return keys, nil
+}
+
+func toFloatAny(v interface{}) float64 {
+	switch n := v.(type) {
+	case int:
+		return float64(n)
+	case int32, int64, float64:
+		return toFloat(n)
+	}
+	return 0
+}
+
+// applyUpdate aplica os operadores da atualização ao documento. $setOnInsert só vale
+// quando o documento está sendo criado por um upsert.
+func applyUpdate(doc bson.M, update interface{}, inserting bool) (bson.M, error) {
+	ops, err := toDoc(update)
+	if err != nil {
+		return nil, err
+	}
+	if len(ops) == 0 || !isOperatorDoc(ops) {
+		return nil, fmt.Errorf("a atualização deve usar operadores como $set; use ReplaceOne para substituir o documento")
+	}
+
+	// $setOnInsert antes de $set, para que $set prevaleça em campos repetidos
+	for _, op := range []string{"$setOnInsert", "$set", "$unset", "$inc"} {
+		fields, ok := ops[op]
+		if !ok {
+			continue
+		}
+		values, ok := asDoc(fields)
+		if !ok {
+			return nil, fmt.Errorf("%s espera um documento", op)
+		}
+		for path, value := range values {
+			switch op {
+			case "$setOnInsert":
+				if inserting {
+					setPath(doc, path, value)
+				}
+			case "$set":
+				setPath(doc, path, value)
+			case "$unset":
+				unsetPath(doc, path)
+			case "$inc":
+				current := first(doc, path)
+				if (current != nil && typeClass(current) != 2) || typeClass(value) != 2 {
+					return nil, fmt.Errorf("$inc exige valores numéricos em %s", path)
+				}
+				setPath(doc, path, addNumbers(current, value))
+			}
+		}
+		delete(ops, op)
+	}
+	for op := range ops {
+		return nil, fmt.Errorf("operador de atualização %s não suportado pelo armazenamento embutido", op)
+	}
+	return doc, nil
+}
+
+func setPath(doc bson.M, path string, value interface{}) {
+	head, rest, nested := strings.Cut(path, ".")
+	if !nested {
+		doc[head] = value
+		return
+	}
+	sub, ok := asDoc(doc[head])
+	if !ok {
+		sub = bson.M{}
+	}
+	setPath(sub, rest, value)
+	doc[head] = sub
+}
+
+func unsetPath(doc bson.M, path string) {
+	head, rest, nested := strings.Cut(path, ".")
+	if !nested {
+		delete(doc, head)
+		return
+	}
+	if sub, ok := asDoc(doc[head]); ok {
+		unsetPath(sub, rest)
+		doc[head] = sub
+	}
+}
+
+// addNumbers soma mantendo inteiros como inteiros, como o $inc do MongoDB
+func addNumbers(a, b interface{}) interface{} {
+	switch {
+	case a == nil:
+		return b
+	case isFloat(a) || isFloat(b):
+		return toFloat(a) + toFloat(b)
+	}
+	sum := toInt64(a) + toInt64(b)
+	_, a32 := a.(int32)
+	_, b32 := b.(int32)
+	if a32 && b32 && sum >= -1<<31 && sum < 1<<31 {
+		return int32(sum)
+	}
+	return sum
+}
+
+func toInt64(v interface{}) int64 {
+	switch n := v.(type) {
+	case int32:
+		return int64(n)
+	case int64:
+		return n
+	}
+	return 0
+}
+
+func isFloat(v interface{}) bool {
+	_, ok := v.(float64)
+	return ok
+}
+
+// equalityFields retorna os campos de igualdade do filtro, que formam a base do
+// documento inserido por um upsert
+func equalityFields(filter interface{}) (bson.M, error) {
+	f, err := toDoc(filter)
+	if err != nil {
+		return nil, err
+	}
+	doc := bson.M{}
+	for key, value := range f {
+		if strings.HasPrefix(key, "$") {
+			continue
+		}
+		if sub, ok := asDoc(value); ok && isOperatorDoc(sub) {
+			if eq, ok := sub["$eq"]; ok {
+				setPath(doc, key, eq)
+			}
+			continue
+		}
+		setPath(doc, key, value)
+	}
+	return doc, nil
+}
@@ -0,0 +1,238 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// mustDoc normaliza o valor pelo BSON, como os filtros e documentos gravados
+func mustDoc(t *testing.T, v interface{}) bson.M {
+	t.Helper()
+
+	doc, err := toDoc(v)
+	if err != nil {
+		t.Fatalf("erro ao converter %v: %v", v, err)
+	}
+	return doc
+}
+
+func TestMatches(t *testing.T) {
+	doc := mustDoc(t, bson.M{
+		"matchId": "sul-1",
+		"region":  "sul",
+		"winner":  nil,
+		"scoreA":  2,
+		"tags":    []string{"final", "bo5"},
+		"players": []bson.M{
+			{"name": "Robo", "team": "paiN", "kills": 4},
+			{"name": "Tinowns", "team": "paiN", "kills": 7},
+		},
+		"stats": bson.M{"duration": 1800},
+	})
+
+	tests := []struct {
+		name   string
+		filter bson.M
+		want   bool
+	}{
+		{"igualdade", bson.M{"region": "sul"}, true},
+		{"igualdade diferente", bson.M{"region": "norte"}, false},
+		{"número com outro tipo inteiro", bson.M{"scoreA": int64(2)}, true},
+		{"campo aninhado", bson.M{"stats.duration": 1800}, true},
+
+		// null atende a campos nulos e ausentes; $exists distingue os dois
+		{"null em campo nulo", bson.M{"winner": nil}, true},
+		{"null em campo ausente", bson.M{"mvp": nil}, true},
+		{"null em campo com valor", bson.M{"region": nil}, false},
+		{"$exists em campo nulo", bson.M{"winner": bson.M{"$exists": true}}, true},
+		{"$exists em campo ausente", bson.M{"mvp": bson.M{"$exists": true}}, false},
+		{"$exists false em campo ausente", bson.M{"mvp": bson.M{"$exists": false}}, true},
+		{"$ne null em campo nulo", bson.M{"winner": bson.M{"$ne": nil}}, false},
+		{"$ne null em campo ausente", bson.M{"mvp": bson.M{"$ne": nil}}, false},
+		{"$ne null em campo com valor", bson.M{"region": bson.M{"$ne": nil}}, true},
+
+		// $in com nil também atende a campos ausentes
+		{"$in com nil em campo ausente", bson.M{"deletedAt": bson.M{"$in": bson.A{nil}}}, true},
+		{"$in com nil em campo nulo", bson.M{"winner": bson.M{"$in": bson.A{nil, "paiN"}}}, true},
+		{"$in com nil em campo com valor", bson.M{"region": bson.M{"$in": bson.A{nil, "norte"}}}, false},
+		{"$in com o valor", bson.M{"region": bson.M{"$in": bson.A{nil, "sul"}}}, true},
+		{"$nin com nil em campo ausente", bson.M{"deletedAt": bson.M{"$nin": bson.A{nil}}}, false},
+
+		// Caminhos que passam por arrays de documentos
+		{"players.name de um jogador", bson.M{"players.name": "Tinowns"}, true},
+		{"players.name ausente", bson.M{"players.name": "Brance"}, false},
+		{"players.name com $in", bson.M{"players.name": bson.M{"$in": bson.A{"Brance", "Robo"}}}, true},
+		{"players.kills com $gt", bson.M{"players.kills": bson.M{"$gt": 6}}, true},
+		{"players.kills com $gt sem jogador", bson.M{"players.kills": bson.M{"$gt": 7}}, false},
+		{"$ne em players.name", bson.M{"players.name": bson.M{"$ne": "Robo"}}, false},
+
+		// Arrays de valores atendem pelo array ou por um elemento
+		{"elemento do array", bson.M{"tags": "bo5"}, true},
+		{"array inteiro", bson.M{"tags": bson.A{"final", "bo5"}}, true},
+		{"array em outra ordem", bson.M{"tags": bson.A{"bo5", "final"}}, false},
+
+		// Operadores lógicos e comparações
+		{"$or", bson.M{"$or": bson.A{bson.M{"region": "norte"}, bson.M{"players.team": "paiN"}}}, true},
+		{"$and", bson.M{"$and": bson.A{bson.M{"region": "sul"}, bson.M{"scoreA": bson.M{"$gte": 3}}}}, false},
+		{"$nor", bson.M{"$nor": bson.A{bson.M{"region": "norte"}}}, true},
+		{"intervalo", bson.M{"scoreA": bson.M{"$gt": 1, "$lt": 3}}, true},
+		{"comparação entre tipos diferentes", bson.M{"region": bson.M{"$gt": 1}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := matches(doc, mustDoc(t, tt.filter))
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("matches(%v) = %v, esperado %v", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchesUnsupportedOperator(t *testing.T) {
+	doc := mustDoc(t, bson.M{"region": "sul"})
+
+	for _, filter := range []bson.M{
+		{"region": bson.M{"$regex": "^s"}},
+		{"$where": "this.region == 'sul'"},
+		{"region": bson.M{"$in": "sul"}},
+	} {
+		if _, err := matches(doc, mustDoc(t, filter)); err == nil {
+			t.Errorf("matches(%v) aceitou um filtro não suportado", filter)
+		}
+	}
+}
+
+func TestApplyUpdate(t *testing.T) {
+	tests := []struct {
+		name      string
+		doc       bson.M
+		update    bson.M
+		inserting bool
+		want      bson.M
+	}{
+		{
+			name:   "$set e campo aninhado",
+			doc:    bson.M{"a": 1},
+			update: bson.M{"$set": bson.M{"a": 2, "b.c": "x"}},
+			want:   bson.M{"a": 2, "b": bson.M{"c": "x"}},
+		},
+		{
+			name:   "$unset de campo aninhado e ausente",
+			doc:    bson.M{"a": 1, "b": bson.M{"c": 1, "d": 2}},
+			update: bson.M{"$unset": bson.M{"b.c": "", "z": ""}},
+			want:   bson.M{"a": 1, "b": bson.M{"d": 2}},
+		},
+		{
+			name:   "$inc em campo existente e ausente",
+			doc:    bson.M{"version": int32(1)},
+			update: bson.M{"$inc": bson.M{"version": int32(1), "count": int32(1)}},
+			want:   bson.M{"version": int32(2), "count": int32(1)},
+		},
+		{
+			name:   "$inc com float",
+			doc:    bson.M{"score": int32(1)},
+			update: bson.M{"$inc": bson.M{"score": 0.5}},
+			want:   bson.M{"score": 1.5},
+		},
+		{
+			name:   "$setOnInsert ignorado na atualização",
+			doc:    bson.M{"a": 1},
+			update: bson.M{"$setOnInsert": bson.M{"createdAt": "hoje"}},
+			want:   bson.M{"a": 1},
+		},
+		{
+			name:      "$setOnInsert na inserção, com $set prevalecendo",
+			doc:       bson.M{},
+			update:    bson.M{"$setOnInsert": bson.M{"a": 1, "b": 1}, "$set": bson.M{"b": 2}},
+			inserting: true,
+			want:      bson.M{"a": 1, "b": 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := applyUpdate(mustDoc(t, tt.doc), tt.update, tt.inserting)
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+			if want := mustDoc(t, tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("applyUpdate = %v, esperado %v", got, want)
+			}
+		})
+	}
+}
+
+func TestApplyUpdateErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		doc    bson.M
+		update interface{}
+	}{
+		{"documento sem operadores", bson.M{}, bson.M{"a": 1}},
+		{"atualização vazia", bson.M{}, bson.M{}},
+		{"operador não suportado", bson.M{}, bson.M{"$push": bson.M{"tags": "x"}}},
+		{"$inc em texto", bson.M{"a": "x"}, bson.M{"$inc": bson.M{"a": 1}}},
+		{"$inc com texto", bson.M{"a": 1}, bson.M{"$inc": bson.M{"a": "1"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := applyUpdate(mustDoc(t, tt.doc), tt.update, false); err == nil {
+				t.Error("atualização inválida aceita")
+			}
+		})
+	}
+}
+
+func TestProject(t *testing.T) {
+	doc := mustDoc(t, bson.M{"_id": 1, "matchId": "sul-1", "snapshot": bson.M{"winner": "paiN"}, "region": "sul"})
+
+	tests := []struct {
+		name string
+		spec bson.M
+		want bson.M
+	}{
+		{"inclusão mantém o _id", bson.M{"matchId": 1}, bson.M{"_id": 1, "matchId": "sul-1"}},
+		{"inclusão sem o _id", bson.M{"matchId": 1, "_id": 0}, bson.M{"matchId": "sul-1"}},
+		{"inclusão de campo aninhado", bson.M{"snapshot.winner": 1}, bson.M{"_id": 1, "snapshot": bson.M{"winner": "paiN"}}},
+		{"inclusão de campo ausente", bson.M{"mvp": 1, "_id": 0}, bson.M{}},
+		{"exclusão", bson.M{"snapshot": 0}, bson.M{"_id": 1, "matchId": "sul-1", "region": "sul"}},
+		{"exclusão apenas do _id", bson.M{"_id": 0}, bson.M{"matchId": "sul-1", "snapshot": bson.M{"winner": "paiN"}, "region": "sul"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := project(clone(doc), tt.spec)
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+			if want := mustDoc(t, tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("project = %v, esperado %v", got, want)
+			}
+		})
+	}
+
+	if _, err := project(doc, bson.M{"matchId": 1, "region": 0}); err == nil {
+		t.Error("projeção misturando inclusão e exclusão aceita")
+	}
+}
+
+func TestEqualityFields(t *testing.T) {
+	filter := bson.M{
+		"matchId":   "sul-1",
+		"stats.map": "rift",
+		"region":    bson.M{"$eq": "sul"},
+		"version":   bson.M{"$gt": 1},
+		"$or":       bson.A{bson.M{"teamA": "paiN"}},
+	}
+	got, err := equalityFields(filter)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	want := bson.M{"matchId": "sul-1", "stats": bson.M{"map": "rift"}, "region": "sul"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("equalityFields = %v, esperado %v", got, want)
+	}
+}
@@ -0,0 +1,230 @@
+package events
+
+import (
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Tipos de evento emitidos pela aplicação
+const (
+	MatchCreated = "match.created"
+	MatchUpdated = "match.updated"
+	MatchDeleted = "match.deleted"
+	ScrapeFailed = "scrape.failed"
+)
+
+// Types lista todos os tipos de evento conhecidos
+var Types = []string{MatchCreated, MatchUpdated, MatchDeleted, ScrapeFailed}
+
+// Tamanhos padrão do histórico e da fila de cada assinante
+const (
+	defaultHistorySize = 1000
+	subscriberBuffer   = 64
+)
+
+// Event representa uma alteração nos dados publicada pela aplicação. O ID é o número de
+// sequência do evento no outbox, que não recomeça quando o processo reinicia.
+type Event struct {
+	ID      uint64      `json:"id"`
+	Type    string      `json:"type"`
+	MatchID string      `json:"matchId,omitempty"`
+	Region  string      `json:"region,omitempty"`
+	Teams   []string    `json:"teams,omitempty"`
+	Players []string    `json:"players,omitempty"`
+	Data    interface{} `json:"data,omitempty"`
+	Time    time.Time   `json:"time"`
+}
+
+// Filter restringe os eventos entregues a um assinante
+type Filter struct {
+	Region string
+	Teams  []string
+}
+
+// Matches indica se o evento atende ao filtro
+func (f Filter) Matches(e Event) bool {
+	if f.Region != "" && !strings.EqualFold(f.Region, e.Region) {
+		return false
+	}
+	if len(f.Teams) == 0 {
+		return true
+	}
+	for _, wanted := range f.Teams {
+		for _, team := range e.Teams {
+			if strings.EqualFold(wanted, team) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Subscription é a inscrição de um consumidor no broker
+type Subscription struct {
+	C      <-chan Event
+	ch     chan Event
+	filter Filter
+	broker *Broker
+	once   sync.Once
+}
+
+// Close cancela a inscrição
+func (s *Subscription) Close() {
+	s.broker.remove(s)
+}
+
+// Backfill busca até limit eventos posteriores a after em um armazenamento persistente
+type Backfill func(after uint64, limit int) ([]Event, error)
+
+// Broker distribui eventos aos assinantes e mantém um histórico recente para retomada
+type Broker struct {
+	mu          sync.Mutex
+	history     []Event
+	historySize int
+	backfill    Backfill
+	subscribers map[*Subscription]struct{}
+}
+
+// NewBroker cria um broker que guarda os últimos historySize eventos
+func NewBroker(historySize int) *Broker {
+	return &Broker{
+		historySize: historySize,
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// ValidType indica se o tipo de evento é conhecido
+func ValidType(eventType string) bool {
+	for _, t := range Types {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Default é o broker usado pela aplicação
+var Default = NewBroker(defaultHistorySize)
+
+// Publish publica um evento no broker padrão
+func Publish(e Event) Event {
+	return Default.Publish(e)
+}
+
+// SetBackfill define de onde buscar os eventos que já saíram do histórico em memória,
+// como os publicados antes de um restart
+func (b *Broker) SetBackfill(fn Backfill) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.backfill = fn
+}
+
+// Publish entrega o evento aos assinantes. Os eventos devem chegar em ordem crescente de ID.
+func (b *Broker) Publish(e Event) Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	b.history = append(b.history, e)
+	if len(b.history) > b.historySize {
+		b.history = b.history[len(b.history)-b.historySize:]
+	}
+
+	for sub := range b.subscribers {
+		if !sub.filter.Matches(e) {
+			continue
+		}
+		select {
+		case sub.ch <- e:
+		default:
+			// Assinante lento: encerrar para que ele reconecte usando Last-Event-ID
+			b.drop(sub)
+		}
+	}
+
+	return e
+}
+
+// Subscribe inscreve um consumidor e retorna os eventos posteriores a lastID, que devem
+// ser enviados antes dos recebidos pelo canal. Quando o histórico em memória não alcança
+// lastID, os eventos anteriores a ele são buscados no backfill, limitados ao tamanho do
+// histórico.
+func (b *Broker) Subscribe(filter Filter, lastID uint64) ([]Event, *Subscription) {
+	// A busca no backfill acontece sem o lock, para não travar as publicações enquanto
+	// o banco responde. Os eventos publicados nesse meio tempo entram no histórico e
+	// são juntados aos buscados abaixo, sem repetição.
+	var older []Event
+	if lastID > 0 {
+		b.mu.Lock()
+		backfill := b.backfill
+		needed := backfill != nil && (len(b.history) == 0 || b.history[0].ID > lastID+1)
+		b.mu.Unlock()
+
+		if needed {
+			var err error
+			if older, err = backfill(lastID, b.historySize); err != nil {
+				log.Printf("Erro ao buscar eventos anteriores ao histórico: %v", err)
+			}
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var replay []Event
+	if lastID > 0 {
+		for _, e := range merge(older, b.history) {
+			if e.ID > lastID && filter.Matches(e) {
+				replay = append(replay, e)
+			}
+		}
+	}
+
+	ch := make(chan Event, subscriberBuffer)
+	sub := &Subscription{C: ch, ch: ch, filter: filter, broker: b}
+	b.subscribers[sub] = struct{}{}
+
+	return replay, sub
+}
+
+// merge junta os eventos buscados no backfill aos do histórico, sem repetir os que já
+// estão em memória
+func merge(older, history []Event) []Event {
+	if len(history) == 0 {
+		return older
+	}
+	merged := make([]Event, 0, len(older)+len(history))
+	for _, e := range older {
+		if e.ID < history[0].ID {
+			merged = append(merged, e)
+		}
+	}
+	return append(merged, history...)
+}
+
+// Close encerra todas as inscrições, liberando as conexões abertas
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subscribers {
+		b.drop(sub)
+	}
+}
+
+func (b *Broker) remove(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.drop(sub)
+}
+
+// drop remove o assinante e fecha seu canal; deve ser chamado com o lock adquirido
+func (b *Broker) drop(sub *Subscription) {
+	delete(b.subscribers, sub)
+	sub.once.Do(func() { close(sub.ch) })
+}
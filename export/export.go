@@ -0,0 +1,168 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/bulletdev/lta-results-api/models"
+)
+
+// Formatos de exportação suportados
+const (
+	FormatJSON   = "json"
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+	FormatXLSX   = "xlsx"
+)
+
+var contentTypes = map[string]string{
+	FormatJSON:   "application/json",
+	FormatCSV:    "text/csv",
+	FormatNDJSON: "application/x-ndjson",
+	FormatXLSX:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// Columns são as colunas das exportações tabulares: uma linha por jogador por partida
+var Columns = []string{
+	"matchId", "date", "region", "tournamentStage", "teamA", "teamB", "scoreA", "scoreB",
+	"winner", "duration", "mvp", "player", "team", "position", "champion",
+	"kills", "deaths", "assists", "cs", "gold", "damageDealt", "visionScore", "win",
+}
+
+// Índices das colunas numéricas, escritas como números no XLSX
+var numericColumns = map[int]bool{6: true, 7: true, 15: true, 16: true, 17: true, 18: true, 19: true, 20: true, 21: true}
+
+// Negotiate escolhe o formato pelo parâmetro format ou, na ausência dele, pelo header Accept.
+// Retorna string vazia se o formato solicitado não for suportado.
+func Negotiate(format, accept string) string {
+	if format != "" {
+		format = strings.ToLower(format)
+		if _, ok := contentTypes[format]; ok {
+			return format
+		}
+		return ""
+	}
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		for f, contentType := range contentTypes {
+			if mediaType == contentType {
+				return f
+			}
+		}
+	}
+
+	return FormatJSON
+}
+
+// ContentType retorna o Content-Type do formato
+func ContentType(format string) string {
+	if format == FormatCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return contentTypes[format]
+}
+
+// SafeName adapta um nome para uso como nome de arquivo e de aba (até 31 caracteres)
+func SafeName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if r <= unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_') {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('-')
+		}
+	}
+
+	safe := []rune(b.String())
+	if len(safe) > 31 {
+		safe = safe[:31]
+	}
+	if len(safe) == 0 {
+		return "export"
+	}
+	return string(safe)
+}
+
+// Rows achata uma partida em linhas, uma por jogador aceito por include (nil aceita todos).
+// Partidas sem jogadores geram uma única linha com as colunas de jogador vazias.
+func Rows(match *models.MatchResult, include func(models.Player) bool) [][]string {
+	base := []string{
+		match.MatchID,
+		match.Date.UTC().Format(time.RFC3339),
+		match.Region,
+		match.TournamentStage,
+		match.TeamA,
+		match.TeamB,
+		strconv.Itoa(match.ScoreA),
+		strconv.Itoa(match.ScoreB),
+		match.Winner,
+		match.Duration,
+		match.MVP,
+	}
+
+	var rows [][]string
+	for _, player := range match.Players {
+		if include != nil && !include(player) {
+			continue
+		}
+		row := append(append([]string{}, base...),
+			player.Name,
+			player.Team,
+			player.Position,
+			player.Champion,
+			strconv.Itoa(player.Kills),
+			strconv.Itoa(player.Deaths),
+			strconv.Itoa(player.Assists),
+			strconv.Itoa(player.CS),
+			strconv.Itoa(player.Gold),
+			strconv.Itoa(player.DamageDealt),
+			strconv.Itoa(player.VisionScore),
+			strconv.FormatBool(match.Winner != "" && match.Winner == player.Team),
+		)
+		rows = append(rows, row)
+	}
+
+	if len(match.Players) == 0 && include == nil {
+		row := append(append([]string{}, base...), make([]string, len(Columns)-len(base))...)
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// RowWriter grava linhas em um formato tabular
+type RowWriter interface {
+	Write(row []string) error
+	Close() error
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+// NewCSVWriter cria um RowWriter em CSV que já escreve o cabeçalho
+func NewCSVWriter(w io.Writer) (RowWriter, error) {
+	cw := &csvWriter{w: csv.NewWriter(w)}
+	if err := cw.Write(Columns); err != nil {
+		return nil, err
+	}
+	return cw, nil
+}
+
+func (c *csvWriter) Write(row []string) error {
+	return c.w.Write(row)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
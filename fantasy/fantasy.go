@@ -0,0 +1,172 @@
+package fantasy
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/models"
+)
+
+// PlayerScore representa a pontuação de um jogador em uma partida
+type PlayerScore struct {
+	MatchID   string             `json:"matchId"`
+	Date      time.Time          `json:"date"`
+	Week      string             `json:"week"`
+	Player    string             `json:"player"`
+	Team      string             `json:"team"`
+	Position  string             `json:"position"`
+	Champion  string             `json:"champion"`
+	Points    float64            `json:"points"`
+	Breakdown map[string]float64 `json:"breakdown"`
+}
+
+// Total representa a soma de pontos de um jogador em um período
+type Total struct {
+	Player  string  `json:"player"`
+	Team    string  `json:"team"`
+	Week    string  `json:"week,omitempty"`
+	Games   int     `json:"games"`
+	Points  float64 `json:"points"`
+	Average float64 `json:"average"`
+}
+
+// ScorePlayer calcula os pontos de um jogador em uma partida segundo o ruleset
+func ScorePlayer(r *models.FantasyRuleset, match models.MatchResult, player models.Player) PlayerScore {
+	breakdown := map[string]float64{
+		"kills":   float64(player.Kills) * r.Kill,
+		"deaths":  float64(player.Deaths) * r.Death,
+		"assists": float64(player.Assists) * r.Assist,
+		"cs":      float64(player.CS) * r.CS,
+	}
+
+	if r.KillBonusThreshold > 0 && player.Kills >= r.KillBonusThreshold {
+		breakdown["killBonus"] = r.KillBonus
+	}
+	if r.AssistBonusThreshold > 0 && player.Assists >= r.AssistBonusThreshold {
+		breakdown["assistBonus"] = r.AssistBonus
+	}
+	if player.Deaths == 0 {
+		breakdown["deathlessBonus"] = r.DeathlessBonus
+	}
+	if match.Winner != "" && strings.EqualFold(match.Winner, player.Team) {
+		breakdown["teamWin"] = r.TeamWin
+	}
+
+	points := 0.0
+	for key, value := range breakdown {
+		breakdown[key] = round(value)
+		points += value
+	}
+
+	return PlayerScore{
+		MatchID:   match.MatchID,
+		Date:      match.Date,
+		Week:      Week(match.Date),
+		Player:    player.Name,
+		Team:      player.Team,
+		Position:  player.Position,
+		Champion:  player.Champion,
+		Points:    round(points),
+		Breakdown: breakdown,
+	}
+}
+
+// ScoreMatch calcula os pontos de todos os jogadores de uma partida
+func ScoreMatch(r *models.FantasyRuleset, match models.MatchResult) []PlayerScore {
+	scores := make([]PlayerScore, 0, len(match.Players))
+	for _, player := range match.Players {
+		scores = append(scores, ScorePlayer(r, match, player))
+	}
+
+	sort.SliceStable(scores, func(i, j int) bool {
+		return scores[i].Points > scores[j].Points
+	})
+	return scores
+}
+
+// ScorePlayerMatches calcula os pontos de um jogador em cada partida disputada
+func ScorePlayerMatches(r *models.FantasyRuleset, matches []models.MatchResult, playerName string) []PlayerScore {
+	var scores []PlayerScore
+	for _, match := range matches {
+		for _, player := range match.Players {
+			if player.Name == playerName {
+				scores = append(scores, ScorePlayer(r, match, player))
+			}
+		}
+	}
+	return scores
+}
+
+// Weekly soma os pontos de cada jogador por semana (ISO), opcionalmente restrito a uma semana
+func Weekly(r *models.FantasyRuleset, matches []models.MatchResult, week string) []Total {
+	return aggregate(r, matches, func(score PlayerScore) (string, bool) {
+		if week != "" && score.Week != week {
+			return "", false
+		}
+		return score.Week, true
+	})
+}
+
+// Season soma os pontos de cada jogador em todas as partidas
+func Season(r *models.FantasyRuleset, matches []models.MatchResult) []Total {
+	return aggregate(r, matches, func(PlayerScore) (string, bool) {
+		return "", true
+	})
+}
+
+// Week retorna a semana ISO de uma data no formato 2025-W15
+func Week(date time.Time) string {
+	year, week := date.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// aggregate agrupa as pontuações por jogador e pela chave de período retornada por group
+func aggregate(r *models.FantasyRuleset, matches []models.MatchResult, group func(PlayerScore) (string, bool)) []Total {
+	totals := make(map[string]*Total)
+	var keys []string
+
+	for _, match := range matches {
+		for _, player := range match.Players {
+			score := ScorePlayer(r, match, player)
+			period, ok := group(score)
+			if !ok {
+				continue
+			}
+
+			key := period + "|" + score.Player
+			total, exists := totals[key]
+			if !exists {
+				total = &Total{Player: score.Player, Week: period}
+				totals[key] = total
+				keys = append(keys, key)
+			}
+			// O time mais recente do jogador prevalece
+			total.Team = score.Team
+			total.Games++
+			total.Points += score.Points
+		}
+	}
+
+	result := make([]Total, 0, len(keys))
+	for _, key := range keys {
+		total := totals[key]
+		total.Points = round(total.Points)
+		total.Average = round(total.Points / float64(total.Games))
+		result = append(result, *total)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Week != result[j].Week {
+			return result[i].Week < result[j].Week
+		}
+		return result[i].Points > result[j].Points
+	})
+	return result
+}
+
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,130 @@
+package fantasy
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/models"
+)
+
+// ruleset usa valores redondos para que os pontos de cada regra fiquem evidentes
+var ruleset = &models.FantasyRuleset{
+	Kill:                 3,
+	Death:                -1,
+	Assist:               2,
+	CS:                   0.01,
+	KillBonusThreshold:   10,
+	KillBonus:            2,
+	AssistBonusThreshold: 10,
+	AssistBonus:          2,
+	DeathlessBonus:       5,
+	TeamWin:              10,
+}
+
+func TestScorePlayer(t *testing.T) {
+	match := models.MatchResult{MatchID: "sul-1", TeamA: "LOUD", TeamB: "RED", Winner: "LOUD"}
+
+	tests := []struct {
+		name   string
+		player models.Player
+		want   map[string]float64
+		points float64
+	}{
+		{
+			name:   "estatísticas básicas na derrota",
+			player: models.Player{Team: "RED", Kills: 2, Deaths: 3, Assists: 4, CS: 250},
+			want:   map[string]float64{"kills": 6, "deaths": -3, "assists": 8, "cs": 2.5},
+			points: 13.5,
+		},
+		{
+			name:   "bônus de abates e assistências no limite",
+			player: models.Player{Team: "RED", Kills: 10, Deaths: 1, Assists: 10},
+			want:   map[string]float64{"kills": 30, "deaths": -1, "assists": 20, "cs": 0, "killBonus": 2, "assistBonus": 2},
+			points: 53,
+		},
+		{
+			name:   "sem mortes",
+			player: models.Player{Team: "RED", Kills: 1},
+			want:   map[string]float64{"kills": 3, "deaths": 0, "assists": 0, "cs": 0, "deathlessBonus": 5},
+			points: 8,
+		},
+		{
+			name:   "vitória do time com outra caixa",
+			player: models.Player{Team: "loud", Deaths: 2},
+			want:   map[string]float64{"kills": 0, "deaths": -2, "assists": 0, "cs": 0, "teamWin": 10},
+			points: 8,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := ScorePlayer(ruleset, match, tt.player)
+			if !reflect.DeepEqual(score.Breakdown, tt.want) {
+				t.Errorf("detalhamento = %v, esperado %v", score.Breakdown, tt.want)
+			}
+			if score.Points != tt.points {
+				t.Errorf("pontos = %v, esperado %v", score.Points, tt.points)
+			}
+		})
+	}
+}
+
+func TestScorePlayerWithoutWinner(t *testing.T) {
+	// Sem vencedor registrado, nenhum jogador recebe o bônus de vitória
+	score := ScorePlayer(ruleset, models.MatchResult{}, models.Player{Team: "", Deaths: 1})
+	if _, ok := score.Breakdown["teamWin"]; ok {
+		t.Errorf("bônus de vitória concedido sem vencedor: %v", score.Breakdown)
+	}
+}
+
+func TestBonusesDisabled(t *testing.T) {
+	noBonus := *ruleset
+	noBonus.KillBonusThreshold = 0
+	noBonus.AssistBonusThreshold = 0
+
+	score := ScorePlayer(&noBonus, models.MatchResult{}, models.Player{Kills: 20, Deaths: 1, Assists: 20})
+	if _, ok := score.Breakdown["killBonus"]; ok {
+		t.Error("bônus de abates concedido com limite zero")
+	}
+	if _, ok := score.Breakdown["assistBonus"]; ok {
+		t.Error("bônus de assistências concedido com limite zero")
+	}
+}
+
+func TestWeeklyAndSeason(t *testing.T) {
+	// 7 e 14 de abril de 2025 são segundas-feiras de semanas ISO diferentes
+	first := time.Date(2025, 4, 7, 18, 0, 0, 0, time.UTC)
+	second := time.Date(2025, 4, 14, 18, 0, 0, 0, time.UTC)
+	matches := []models.MatchResult{
+		{Date: first, Winner: "LOUD", Players: []models.Player{
+			{Name: "Robo", Team: "LOUD", Kills: 1, Deaths: 1},
+			{Name: "Tinowns", Team: "RED", Kills: 2, Deaths: 1},
+		}},
+		{Date: second, Winner: "paiN Gaming", Players: []models.Player{
+			{Name: "Robo", Team: "paiN Gaming", Kills: 2, Deaths: 1},
+		}},
+	}
+
+	if got := Week(first); got != "2025-W15" {
+		t.Errorf("Week = %s, esperado 2025-W15", got)
+	}
+
+	weekly := Weekly(ruleset, matches, "")
+	want := []Total{
+		{Player: "Robo", Team: "LOUD", Week: "2025-W15", Games: 1, Points: 12, Average: 12},
+		{Player: "Tinowns", Team: "RED", Week: "2025-W15", Games: 1, Points: 5, Average: 5},
+		{Player: "Robo", Team: "paiN Gaming", Week: "2025-W16", Games: 1, Points: 15, Average: 15},
+	}
+	if !reflect.DeepEqual(weekly, want) {
+		t.Errorf("Weekly = %+v, esperado %+v", weekly, want)
+	}
+	if only := Weekly(ruleset, matches, "2025-W16"); len(only) != 1 || only[0].Week != "2025-W16" {
+		t.Errorf("Weekly restrito à semana = %+v", only)
+	}
+
+	// Na temporada prevalece o time mais recente do jogador
+	season := Season(ruleset, matches)
+	if robo := season[0]; robo.Player != "Robo" || robo.Team != "paiN Gaming" || robo.Games != 2 || robo.Points != 27 || robo.Average != 13.5 {
+		t.Errorf("temporada de Robo = %+v", robo)
+	}
+}
@@ -0,0 +1,208 @@
+package gql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// Limites aplicados a cada consulta antes da execução
+var (
+	MaxDepth      = 8
+	MaxComplexity = 5000
+)
+
+// Tamanho assumido para listas sem o argumento limit no cálculo de complexidade
+const defaultListSize = 10
+
+// Cost resume o tamanho de uma operação
+type Cost struct {
+	Depth      int `json:"depth"`
+	Complexity int `json:"complexity"`
+}
+
+// Analyze calcula a profundidade e a complexidade da operação. Cada campo custa 1 e o
+// custo dos campos internos é multiplicado pelo argumento limit, quando existir, ou por
+// defaultListSize nos campos que retornam listas. Campos de introspecção são ignorados.
+func Analyze(schema *graphql.Schema, doc *ast.Document, operationName string, variables map[string]interface{}) (Cost, error) {
+	a := &analyzer{
+		schema:    schema,
+		variables: variables,
+		fragments: make(map[string]*ast.FragmentDefinition),
+		visiting:  make(map[string]bool),
+	}
+
+	var operation *ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.FragmentDefinition:
+			a.fragments[def.Name.Value] = def
+		case *ast.OperationDefinition:
+			name := ""
+			if def.Name != nil {
+				name = def.Name.Value
+			}
+			if operationName == "" || name == operationName {
+				if operation != nil && operationName == "" {
+					return Cost{}, fmt.Errorf("informe operationName quando a consulta tiver mais de uma operação")
+				}
+				operation = def
+			}
+		}
+	}
+	if operation == nil {
+		return Cost{}, fmt.Errorf("operação não encontrada: %q", operationName)
+	}
+
+	root := schema.QueryType()
+	if operation.Operation == ast.OperationTypeMutation {
+		root = schema.MutationType()
+	}
+
+	depth, complexity := a.selectionSet(root, operation.SelectionSet)
+	return Cost{Depth: depth, Complexity: complexity}, nil
+}
+
+// Check rejeita operações acima de MaxDepth ou MaxComplexity
+func Check(cost Cost) error {
+	if cost.Depth > MaxDepth {
+		return fmt.Errorf("consulta muito profunda: %d níveis (máximo: %d)", cost.Depth, MaxDepth)
+	}
+	if cost.Complexity > MaxComplexity {
+		return fmt.Errorf("consulta muito complexa: custo %d (máximo: %d)", cost.Complexity, MaxComplexity)
+	}
+	return nil
+}
+
+type analyzer struct {
+	schema    *graphql.Schema
+	variables map[string]interface{}
+	fragments map[string]*ast.FragmentDefinition
+	visiting  map[string]bool
+}
+
+// selectionSet retorna a profundidade e o custo de um conjunto de seleções de parent
+func (a *analyzer) selectionSet(parent *graphql.Object, set *ast.SelectionSet) (int, int) {
+	if set == nil {
+		return 0, 0
+	}
+
+	maxDepth, total := 0, 0
+	for _, selection := range set.Selections {
+		var depth, cost int
+
+		switch selection := selection.(type) {
+		case *ast.Field:
+			depth, cost = a.field(parent, selection)
+
+		case *ast.InlineFragment:
+			depth, cost = a.selectionSet(a.fragmentType(parent, selection.TypeCondition), selection.SelectionSet)
+
+		case *ast.FragmentSpread:
+			name := selection.Name.Value
+			fragment, ok := a.fragments[name]
+			// Ciclos entre fragments são rejeitados na validação; aqui apenas não recursamos
+			if !ok || a.visiting[name] {
+				continue
+			}
+			a.visiting[name] = true
+			depth, cost = a.selectionSet(a.fragmentType(parent, fragment.TypeCondition), fragment.SelectionSet)
+			delete(a.visiting, name)
+		}
+
+		if depth > maxDepth {
+			maxDepth = depth
+		}
+		total += cost
+	}
+
+	return maxDepth, total
+}
+
+func (a *analyzer) field(parent *graphql.Object, field *ast.Field) (int, int) {
+	name := field.Name.Value
+	if strings.HasPrefix(name, "__") {
+		return 0, 0
+	}
+
+	var def *graphql.FieldDefinition
+	if parent != nil {
+		def = parent.Fields()[name]
+	}
+	// Campos inexistentes são reportados pela validação do schema
+	if def == nil || field.SelectionSet == nil {
+		return 1, 1
+	}
+
+	child, isList := unwrap(def.Type)
+	depth, cost := a.selectionSet(child, field.SelectionSet)
+
+	multiplier := 1
+	if limit, ok := a.limit(def, field); ok {
+		multiplier = limit
+	} else if isList {
+		multiplier = defaultListSize
+	}
+
+	return depth + 1, 1 + cost*multiplier
+}
+
+// limit lê o argumento limit informado ou o valor padrão definido no schema
+func (a *analyzer) limit(def *graphql.FieldDefinition, field *ast.Field) (int, bool) {
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != "limit" {
+			continue
+		}
+		switch value := arg.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(value.Value); err == nil && n > 0 {
+				return n, true
+			}
+		case *ast.Variable:
+			switch n := a.variables[value.Name.Value].(type) {
+			case float64:
+				return int(n), n > 0
+			case int:
+				return n, n > 0
+			}
+		}
+	}
+
+	for _, arg := range def.Args {
+		if n, ok := arg.DefaultValue.(int); ok && arg.PrivateName == "limit" {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+func (a *analyzer) fragmentType(parent *graphql.Object, condition *ast.Named) *graphql.Object {
+	if condition == nil || condition.Name == nil {
+		return parent
+	}
+	if object, ok := a.schema.Type(condition.Name.Value).(*graphql.Object); ok {
+		return object
+	}
+	return nil
+}
+
+// unwrap remove NonNull e List do tipo, indicando se ele era uma lista
+func unwrap(t graphql.Type) (*graphql.Object, bool) {
+	isList := false
+	for {
+		switch wrapped := t.(type) {
+		case *graphql.NonNull:
+			t = wrapped.OfType
+		case *graphql.List:
+			isList = true
+			t = wrapped.OfType
+		case *graphql.Object:
+			return wrapped, isList
+		default:
+			return nil, isList
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package gql
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/bulletdev/lta-results-api/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type loadersKey struct{}
+
+// Loaders agrupa as buscas feitas pelos resolvers de uma mesma requisição. Cada resolver
+// registra a chave que precisa e devolve um thunk; quando o executor resolve o primeiro
+// thunk de um nível, todas as chaves registradas até ali são buscadas em uma única consulta.
+type Loaders struct {
+	teamMatches   *loader
+	playerMatches *loader
+}
+
+// NewLoaders cria os loaders de uma requisição
+func NewLoaders() *Loaders {
+	return &Loaders{
+		teamMatches:   newLoader(fetchTeamMatches),
+		playerMatches: newLoader(fetchPlayerMatches),
+	}
+}
+
+// WithLoaders associa os loaders ao contexto da requisição
+func WithLoaders(ctx context.Context, l *Loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *Loaders {
+	if l, ok := ctx.Value(loadersKey{}).(*Loaders); ok {
+		return l
+	}
+	return NewLoaders()
+}
+
+// loader acumula chaves e as resolve em lote com fetch, guardando os resultados
+type loader struct {
+	mu      sync.Mutex
+	fetch   func(keys []string) (map[string][]models.MatchResult, error)
+	pending []string
+	queued  map[string]bool
+	results map[string][]models.MatchResult
+	errs    map[string]error
+}
+
+func newLoader(fetch func(keys []string) (map[string][]models.MatchResult, error)) *loader {
+	return &loader{
+		fetch:   fetch,
+		queued:  make(map[string]bool),
+		results: make(map[string][]models.MatchResult),
+		errs:    make(map[string]error),
+	}
+}
+
+// Load registra a chave e retorna uma função que devolve as partidas dela,
+// disparando a busca em lote na primeira chamada
+func (l *loader) Load(key string) func() ([]models.MatchResult, error) {
+	l.mu.Lock()
+	if !l.queued[key] {
+		l.queued[key] = true
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() ([]models.MatchResult, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if _, done := l.results[key]; !done && l.errs[key] == nil {
+			keys := l.pending
+			l.pending = nil
+
+			found, err := l.fetch(keys)
+			for _, k := range keys {
+				if err != nil {
+					l.errs[k] = err
+					continue
+				}
+				l.results[k] = found[k]
+			}
+		}
+
+		return l.results[key], l.errs[key]
+	}
+}
+
+// fetchTeamMatches busca de uma vez as partidas de todos os times, da mais recente para a mais antiga
+func fetchTeamMatches(teams []string) (map[string][]models.MatchResult, error) {
+	matches, err := models.GetAllMatchResults(bson.M{"$or": []bson.M{
+		{"teamA": bson.M{"$in": teams}},
+		{"teamB": bson.M{"$in": teams}},
+	}})
+	if err != nil {
+		return nil, err
+	}
+	sortByDateDesc(matches)
+
+	wanted := toSet(teams)
+	grouped := make(map[string][]models.MatchResult, len(teams))
+	for _, match := range matches {
+		if wanted[match.TeamA] {
+			grouped[match.TeamA] = append(grouped[match.TeamA], match)
+		}
+		if wanted[match.TeamB] && match.TeamB != match.TeamA {
+			grouped[match.TeamB] = append(grouped[match.TeamB], match)
+		}
+	}
+	return grouped, nil
+}
+
+// fetchPlayerMatches busca de uma vez as partidas de todos os jogadores, da mais recente para a mais antiga
+func fetchPlayerMatches(players []string) (map[string][]models.MatchResult, error) {
+	matches, err := models.GetAllMatchResults(bson.M{"players.name": bson.M{"$in": players}})
+	if err != nil {
+		return nil, err
+	}
+	sortByDateDesc(matches)
+
+	wanted := toSet(players)
+	grouped := make(map[string][]models.MatchResult, len(players))
+	for _, match := range matches {
+		added := make(map[string]bool)
+		for _, player := range match.Players {
+			if wanted[player.Name] && !added[player.Name] {
+				added[player.Name] = true
+				grouped[player.Name] = append(grouped[player.Name], match)
+			}
+		}
+	}
+	return grouped, nil
+}
+
+func sortByDateDesc(matches []models.MatchResult) {
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Date.After(matches[j].Date)
+	})
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
@@ -0,0 +1,453 @@
+package gql
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bulletdev/lta-results-api/champions"
+	"github.com/bulletdev/lta-results-api/models"
+	"github.com/graphql-go/graphql"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Limite máximo de itens por página nas listas paginadas
+const maxPageSize = 100
+
+// team é a origem dos resolvers do tipo Team
+type team struct {
+	Name string `json:"name"`
+}
+
+var championStatsType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "ChampionStats",
+	Description: "Desempenho de um time com um campeão",
+	Fields: graphql.Fields{
+		"champion": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"games":    &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"wins":     &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"winRate":  &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+	},
+})
+
+var playerStatsType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "PlayerStats",
+	Description: "Estatísticas agregadas de um jogador",
+	Fields: graphql.Fields{
+		"playerName":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"totalGames":     &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"wins":           &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"losses":         &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"winRate":        &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		"averageKills":   &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		"averageDeaths":  &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		"averageAssists": &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		"averageCS":      &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		"kda":            &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+	},
+})
+
+var teamStatsType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "TeamStats",
+	Description: "Estatísticas agregadas de um time",
+	Fields: graphql.Fields{
+		"teamName":            &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"totalGames":          &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"wins":                &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"losses":              &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"winRate":             &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		"averageGameDuration": &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		"mostPlayedChampions": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(championStatsType)))},
+	},
+})
+
+var championPairType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "ChampionPair",
+	Description: "Taxa de vitória de um par de campeões, com intervalo de confiança de Wilson",
+	Fields: graphql.Fields{
+		"champion": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"other":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"position": &graphql.Field{Type: graphql.String},
+		"games":    &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"wins":     &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"winRate":  &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		"ciLower":  &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		"ciUpper":  &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+	},
+})
+
+var playerType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "Player",
+	Description: "Desempenho de um jogador em uma partida",
+	Fields: graphql.Fields{
+		"name":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"team":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"position":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"champion":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"kills":       &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"deaths":      &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"assists":     &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"cs":          &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"gold":        &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"damageDealt": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"visionScore": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"stats": &graphql.Field{
+			Type:        playerStatsType,
+			Description: "Estatísticas do jogador em todas as partidas",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolvePlayerStats(p, p.Source.(models.Player).Name), nil
+			},
+		},
+	},
+})
+
+var teamType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "Team",
+	Description: "Um time e as partidas que disputou",
+	Fields: graphql.Fields{
+		"name": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+	},
+})
+
+var matchType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "MatchResult",
+	Description: "Resultado de uma partida",
+	Fields: graphql.Fields{
+		"id": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.ID),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(models.MatchResult).ID.Hex(), nil
+			},
+		},
+		"matchId":         &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"date":            &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		"region":          &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"tournamentStage": &graphql.Field{Type: graphql.String},
+		"teamA":           &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"teamB":           &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"scoreA":          &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"scoreB":          &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"winner":          &graphql.Field{Type: graphql.String},
+		"duration":        &graphql.Field{Type: graphql.String},
+		"mvp":             &graphql.Field{Type: graphql.String},
+		"vod":             &graphql.Field{Type: graphql.String},
+		"players": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(playerType))),
+			Description: "Jogadores da partida, opcionalmente filtrados por time e posição",
+			Args: graphql.FieldConfigArgument{
+				"team":     &graphql.ArgumentConfig{Type: graphql.String},
+				"position": &graphql.ArgumentConfig{Type: graphql.String},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				team, _ := p.Args["team"].(string)
+				position, _ := p.Args["position"].(string)
+
+				players := []models.Player{}
+				for _, player := range p.Source.(models.MatchResult).Players {
+					if (team == "" || player.Team == team) && (position == "" || player.Position == position) {
+						players = append(players, player)
+					}
+				}
+				return players, nil
+			},
+		},
+		"teams": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(teamType))),
+			Description: "Os dois times da partida",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				match := p.Source.(models.MatchResult)
+				return []team{{Name: match.TeamA}, {Name: match.TeamB}}, nil
+			},
+		},
+	},
+})
+
+// Argumentos de filtro e paginação das listas de partidas
+var matchListArgs = graphql.FieldConfigArgument{
+	"region": &graphql.ArgumentConfig{Type: graphql.String},
+	"stage":  &graphql.ArgumentConfig{Type: graphql.String},
+	"limit":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 10},
+	"offset": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 0},
+}
+
+// Argumentos das matrizes de campeões
+var championArgs = graphql.FieldConfigArgument{
+	"region":     &graphql.ArgumentConfig{Type: graphql.String},
+	"stage":      &graphql.ArgumentConfig{Type: graphql.String},
+	"champion":   &graphql.ArgumentConfig{Type: graphql.String},
+	"position":   &graphql.ArgumentConfig{Type: graphql.String},
+	"minGames":   &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 3},
+	"confidence": &graphql.ArgumentConfig{Type: graphql.Float, DefaultValue: 0.95},
+}
+
+func init() {
+	// Campos que referenciam tipos definidos depois são adicionados aqui
+	teamType.AddFieldConfig("stats", &graphql.Field{
+		Type: teamStatsType,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			name := p.Source.(team).Name
+			load := loadersFrom(p.Context).teamMatches.Load(name)
+			return func() (interface{}, error) {
+				matches, err := load()
+				if err != nil {
+					return nil, err
+				}
+				if stats := models.ComputeTeamStats(name, matches); stats != nil {
+					return *stats, nil
+				}
+				return nil, nil
+			}, nil
+		},
+	})
+	teamType.AddFieldConfig("matches", &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(matchType))),
+		Description: "Partidas do time, da mais recente para a mais antiga",
+		Args:        matchListArgs,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			limit, offset, err := pagination(p.Args)
+			if err != nil {
+				return nil, err
+			}
+			region, _ := p.Args["region"].(string)
+			stage, _ := p.Args["stage"].(string)
+
+			load := loadersFrom(p.Context).teamMatches.Load(p.Source.(team).Name)
+			return func() (interface{}, error) {
+				matches, err := load()
+				if err != nil {
+					return nil, err
+				}
+
+				filtered := []models.MatchResult{}
+				for _, match := range matches {
+					if (region == "" || match.Region == region) && (stage == "" || match.TournamentStage == stage) {
+						filtered = append(filtered, match)
+					}
+				}
+				return page(filtered, limit, offset), nil
+			}, nil
+		},
+	})
+}
+
+var queryType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Query",
+	Fields: graphql.Fields{
+		"matches": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(matchType))),
+			Description: "Partidas da mais recente para a mais antiga",
+			Args: graphql.FieldConfigArgument{
+				"region": matchListArgs["region"],
+				"stage":  matchListArgs["stage"],
+				"team":   &graphql.ArgumentConfig{Type: graphql.String},
+				"limit":  matchListArgs["limit"],
+				"offset": matchListArgs["offset"],
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				limit, offset, err := pagination(p.Args)
+				if err != nil {
+					return nil, err
+				}
+
+				findOptions := options.Find().
+					SetSort(bson.M{"date": -1}).
+					SetSkip(int64(offset)).
+					SetLimit(int64(limit))
+
+				results, _, err := models.GetMatchResults(matchFilter(p.Args), findOptions)
+				if err != nil {
+					return nil, fmt.Errorf("erro ao buscar resultados")
+				}
+				if results == nil {
+					results = []models.MatchResult{}
+				}
+				return results, nil
+			},
+		},
+		"matchCount": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.Int),
+			Description: "Total de partidas que atendem ao filtro, para paginação",
+			Args: graphql.FieldConfigArgument{
+				"region": matchListArgs["region"],
+				"stage":  matchListArgs["stage"],
+				"team":   &graphql.ArgumentConfig{Type: graphql.String},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				_, total, err := models.GetMatchResults(matchFilter(p.Args), options.Find().SetLimit(1))
+				if err != nil {
+					return nil, fmt.Errorf("erro ao contar resultados")
+				}
+				return int(total), nil
+			},
+		},
+		"match": &graphql.Field{
+			Type: matchType,
+			Args: graphql.FieldConfigArgument{
+				"matchId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				result, err := models.GetMatchResultByID(p.Args["matchId"].(string))
+				if errors.Is(err, mongo.ErrNoDocuments) {
+					return nil, nil
+				}
+				if err != nil {
+					return nil, fmt.Errorf("erro ao buscar resultado")
+				}
+				return *result, nil
+			},
+		},
+		"team": &graphql.Field{
+			Type: teamType,
+			Args: graphql.FieldConfigArgument{
+				"name": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return team{Name: p.Args["name"].(string)}, nil
+			},
+		},
+		"teams": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(teamType))),
+			Description: "Times com partidas registradas, em ordem alfabética",
+			Args: graphql.FieldConfigArgument{
+				"region": matchListArgs["region"],
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				filter := bson.M{}
+				if region, _ := p.Args["region"].(string); region != "" {
+					filter["region"] = region
+				}
+
+				names, err := models.GetTeamNames(filter)
+				if err != nil {
+					return nil, fmt.Errorf("erro ao buscar times")
+				}
+
+				teams := make([]team, len(names))
+				for i, name := range names {
+					teams[i] = team{Name: name}
+				}
+				return teams, nil
+			},
+		},
+		"playerStats": &graphql.Field{
+			Type: playerStatsType,
+			Args: graphql.FieldConfigArgument{
+				"name": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolvePlayerStats(p, p.Args["name"].(string)), nil
+			},
+		},
+		"championSynergy": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(championPairType))),
+			Description: "Pares de campeões jogando no mesmo time",
+			Args:        championArgs,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return championMatrix(p, champions.Synergy)
+			},
+		},
+		"championCounters": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(championPairType))),
+			Description: "Confrontos entre campeões na mesma posição",
+			Args:        championArgs,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return championMatrix(p, champions.Counters)
+			},
+		},
+	},
+})
+
+// Schema é o schema GraphQL da API
+var Schema graphql.Schema
+
+func init() {
+	var err error
+	Schema, err = graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+	if err != nil {
+		panic(fmt.Sprintf("schema GraphQL inválido: %v", err))
+	}
+}
+
+// resolvePlayerStats devolve um thunk com as estatísticas do jogador, buscadas em lote
+func resolvePlayerStats(p graphql.ResolveParams, name string) func() (interface{}, error) {
+	load := loadersFrom(p.Context).playerMatches.Load(name)
+	return func() (interface{}, error) {
+		matches, err := load()
+		if err != nil {
+			return nil, err
+		}
+		if stats := models.ComputePlayerStats(name, matches); stats != nil {
+			return *stats, nil
+		}
+		return nil, nil
+	}
+}
+
+func championMatrix(p graphql.ResolveParams, compute func([]models.MatchResult, champions.Options) ([]champions.PairStats, error)) (interface{}, error) {
+	filter := bson.M{}
+	if region, _ := p.Args["region"].(string); region != "" {
+		filter["region"] = region
+	}
+	if stage, _ := p.Args["stage"].(string); stage != "" {
+		filter["tournamentStage"] = stage
+	}
+
+	opts := champions.Options{MinGames: p.Args["minGames"].(int), Confidence: p.Args["confidence"].(float64)}
+	opts.Champion, _ = p.Args["champion"].(string)
+	opts.Position, _ = p.Args["position"].(string)
+	if opts.MinGames < 1 {
+		return nil, fmt.Errorf("minGames deve ser um número positivo")
+	}
+
+	matches, err := models.GetAllMatchResults(filter)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar resultados")
+	}
+
+	pairs, err := compute(matches, opts)
+	if err != nil {
+		return nil, err
+	}
+	if pairs == nil {
+		pairs = []champions.PairStats{}
+	}
+	return pairs, nil
+}
+
+func matchFilter(args map[string]interface{}) bson.M {
+	filter := bson.M{}
+	if region, _ := args["region"].(string); region != "" {
+		filter["region"] = region
+	}
+	if stage, _ := args["stage"].(string); stage != "" {
+		filter["tournamentStage"] = stage
+	}
+	if team, _ := args["team"].(string); team != "" {
+		filter["$or"] = []bson.M{{"teamA": team}, {"teamB": team}}
+	}
+	return filter
+}
+
+func pagination(args map[string]interface{}) (int, int, error) {
+	limit, _ := args["limit"].(int)
+	offset, _ := args["offset"].(int)
+	if limit < 1 || limit > maxPageSize {
+		return 0, 0, fmt.Errorf("limit deve estar entre 1 e %d", maxPageSize)
+	}
+	if offset < 0 {
+		return 0, 0, fmt.Errorf("offset não pode ser negativo")
+	}
+	return limit, offset, nil
+}
+
+func page(matches []models.MatchResult, limit, offset int) []models.MatchResult {
+	if offset >= len(matches) {
+		return []models.MatchResult{}
+	}
+	end := offset + limit
+	if end > len(matches) {
+		end = len(matches)
+	}
+	return matches[offset:end]
+}
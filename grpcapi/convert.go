@@ -0,0 +1,107 @@
+package grpcapi
+
+import (
+	"github.com/bulletdev/lta-results-api/events"
+	"github.com/bulletdev/lta-results-api/models"
+	ltav1 "github.com/bulletdev/lta-results-api/proto/lta/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toMatchResult(m *models.MatchResult) *ltav1.MatchResult {
+	result := &ltav1.MatchResult{
+		MatchId:         m.MatchID,
+		Date:            timestamppb.New(m.Date),
+		TeamA:           m.TeamA,
+		TeamB:           m.TeamB,
+		ScoreA:          int32(m.ScoreA),
+		ScoreB:          int32(m.ScoreB),
+		Region:          m.Region,
+		Players:         make([]*ltav1.Player, len(m.Players)),
+		Duration:        m.Duration,
+		Winner:          m.Winner,
+		Mvp:             m.MVP,
+		TournamentStage: m.TournamentStage,
+		Vod:             m.VOD,
+		CreatedAt:       timestamppb.New(m.CreatedAt),
+		UpdatedAt:       timestamppb.New(m.UpdatedAt),
+	}
+	if !m.ID.IsZero() {
+		result.Id = m.ID.Hex()
+	}
+
+	for i, p := range m.Players {
+		result.Players[i] = &ltav1.Player{
+			Name:        p.Name,
+			Team:        p.Team,
+			Position:    p.Position,
+			Champion:    p.Champion,
+			Kills:       int32(p.Kills),
+			Deaths:      int32(p.Deaths),
+			Assists:     int32(p.Assists),
+			Cs:          int32(p.CS),
+			Gold:        int32(p.Gold),
+			DamageDealt: int32(p.DamageDealt),
+			VisionScore: int32(p.VisionScore),
+		}
+	}
+
+	return result
+}
+
+func toPlayerStats(s *models.PlayerStats) *ltav1.PlayerStats {
+	return &ltav1.PlayerStats{
+		PlayerName:     s.PlayerName,
+		TotalGames:     int32(s.TotalGames),
+		Wins:           int32(s.Wins),
+		Losses:         int32(s.Losses),
+		WinRate:        s.WinRate,
+		AverageKills:   s.AverageKills,
+		AverageDeaths:  s.AverageDeaths,
+		AverageAssists: s.AverageAssists,
+		AverageCs:      s.AverageCS,
+		Kda:            s.KDA,
+	}
+}
+
+func toTeamStats(s *models.TeamStats) *ltav1.TeamStats {
+	stats := &ltav1.TeamStats{
+		TeamName:            s.TeamName,
+		TotalGames:          int32(s.TotalGames),
+		Wins:                int32(s.Wins),
+		Losses:              int32(s.Losses),
+		WinRate:             s.WinRate,
+		AverageGameDuration: s.AverageGameDuration,
+		MostPlayedChampions: make([]*ltav1.ChampionStats, len(s.MostPlayedChampions)),
+	}
+
+	for i, c := range s.MostPlayedChampions {
+		stats.MostPlayedChampions[i] = &ltav1.ChampionStats{
+			Champion: c.Champion,
+			Games:    int32(c.Games),
+			Wins:     int32(c.Wins),
+			WinRate:  c.WinRate,
+		}
+	}
+
+	return stats
+}
+
+func toResultEvent(e events.Event) *ltav1.ResultEvent {
+	event := &ltav1.ResultEvent{
+		Id:      e.ID,
+		Type:    e.Type,
+		MatchId: e.MatchID,
+		Region:  e.Region,
+		Teams:   e.Teams,
+		Time:    timestamppb.New(e.Time),
+	}
+
+	switch data := e.Data.(type) {
+	case *models.MatchResult:
+		event.Match = toMatchResult(data)
+	case map[string]string:
+		event.Details = data
+	}
+
+	return event
+}
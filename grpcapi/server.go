@@ -0,0 +1,181 @@
+// Package grpcapi implementa o serviço gRPC definido em proto/lta/v1/results.proto.
+package grpcapi
+
+import (
+	"context"
+	"log"
+
+	"github.com/bulletdev/lta-results-api/database"
+	"github.com/bulletdev/lta-results-api/events"
+	"github.com/bulletdev/lta-results-api/models"
+	ltav1 "github.com/bulletdev/lta-results-api/proto/lta/v1"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// Paginação padrão e máxima de ListResults, igual à da API REST
+const (
+	defaultLimit = 10
+	maxLimit     = 100
+)
+
+// Server implementa ltav1.ResultsServiceServer sobre a camada de models
+type Server struct {
+	ltav1.UnimplementedResultsServiceServer
+
+	broker *events.Broker
+}
+
+// NewServer cria o serviço usando o broker informado para WatchResults
+func NewServer(broker *events.Broker) *Server {
+	return &Server{broker: broker}
+}
+
+// NewGRPCServer cria um servidor gRPC com o serviço de resultados e a reflexão registrados
+func NewGRPCServer(broker *events.Broker) *grpc.Server {
+	server := grpc.NewServer()
+	ltav1.RegisterResultsServiceServer(server, NewServer(broker))
+	reflection.Register(server)
+	return server
+}
+
+func (s *Server) ListResults(ctx context.Context, req *ltav1.ListResultsRequest) (*ltav1.ListResultsResponse, error) {
+	page := int(req.GetPage())
+	if page == 0 {
+		page = 1
+	}
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = defaultLimit
+	}
+	if page < 1 || limit < 1 || limit > maxLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "page deve ser positivo e limit deve estar entre 1 e %d", maxLimit)
+	}
+
+	filter := bson.M{}
+	if req.GetRegion() != "" {
+		filter["region"] = req.GetRegion()
+	}
+	if req.GetStage() != "" {
+		filter["tournamentStage"] = req.GetStage()
+	}
+	if req.GetTeam() != "" {
+		filter["$or"] = []bson.M{{"teamA": req.GetTeam()}, {"teamB": req.GetTeam()}}
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.M{"date": -1}).
+		SetSkip(int64((page - 1) * limit)).
+		SetLimit(int64(limit))
+
+	results, total, err := models.GetMatchResults(filter, findOptions)
+	if err != nil {
+		log.Printf("Erro ao buscar resultados (gRPC): %v", err)
+		return nil, storeError(err, "erro ao buscar resultados")
+	}
+
+	resp := &ltav1.ListResultsResponse{
+		Results: make([]*ltav1.MatchResult, len(results)),
+		Total:   total,
+		Page:    int32(page),
+		Limit:   int32(limit),
+		Pages:   (total + int64(limit) - 1) / int64(limit),
+	}
+	for i := range results {
+		resp.Results[i] = toMatchResult(&results[i])
+	}
+	return resp, nil
+}
+
+func (s *Server) GetResult(ctx context.Context, req *ltav1.GetResultRequest) (*ltav1.MatchResult, error) {
+	if req.GetMatchId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "match_id é obrigatório")
+	}
+
+	result, err := models.GetMatchResultByID(req.GetMatchId())
+	if err == mongo.ErrNoDocuments {
+		return nil, status.Error(codes.NotFound, "resultado não encontrado")
+	}
+	if err != nil {
+		log.Printf("Erro ao buscar resultado %s (gRPC): %v", req.GetMatchId(), err)
+		return nil, storeError(err, "erro ao buscar resultado")
+	}
+
+	return toMatchResult(result), nil
+}
+
+func (s *Server) GetPlayerStats(ctx context.Context, req *ltav1.GetPlayerStatsRequest) (*ltav1.PlayerStats, error) {
+	if req.GetPlayerName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "player_name é obrigatório")
+	}
+
+	stats, err := models.GetPlayerStats(req.GetPlayerName())
+	if err != nil {
+		log.Printf("Erro ao buscar estatísticas do jogador (gRPC): %v", err)
+		return nil, storeError(err, "erro ao buscar estatísticas")
+	}
+	if stats == nil {
+		return nil, status.Error(codes.NotFound, "jogador não encontrado")
+	}
+
+	return toPlayerStats(stats), nil
+}
+
+func (s *Server) GetTeamStats(ctx context.Context, req *ltav1.GetTeamStatsRequest) (*ltav1.TeamStats, error) {
+	if req.GetTeamName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "team_name é obrigatório")
+	}
+
+	stats, err := models.GetTeamStats(req.GetTeamName())
+	if err != nil {
+		log.Printf("Erro ao buscar estatísticas do time (gRPC): %v", err)
+		return nil, storeError(err, "erro ao buscar estatísticas")
+	}
+	if stats == nil {
+		return nil, status.Error(codes.NotFound, "time não encontrado")
+	}
+
+	return toTeamStats(stats), nil
+}
+
+// WatchResults envia os eventos perdidos desde last_event_id e depois as alterações
+// em tempo real, até o cliente cancelar a chamada ou o broker ser encerrado
+func (s *Server) WatchResults(req *ltav1.WatchResultsRequest, stream grpc.ServerStreamingServer[ltav1.ResultEvent]) error {
+	filter := events.Filter{Region: req.GetRegion(), Teams: req.GetTeams()}
+	replay, sub := s.broker.Subscribe(filter, req.GetLastEventId())
+	defer sub.Close()
+
+	for _, e := range replay {
+		if err := stream.Send(toResultEvent(e)); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case e, ok := <-sub.C:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(toResultEvent(e)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// storeError converte uma falha do banco em status gRPC: Unavailable quando o
+// banco está inacessível e Internal nos demais casos
+func storeError(err error, msg string) error {
+	if database.IsUnavailable(err) {
+		return status.Error(codes.Unavailable, "serviço temporariamente indisponível")
+	}
+	return status.Error(codes.Internal, msg)
+}
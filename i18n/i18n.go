@@ -0,0 +1,109 @@
+// Package i18n traduz as mensagens da API para os idiomas suportados.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Idiomas suportados
+const (
+	PtBR = "pt-BR"
+	EN   = "en"
+	ES   = "es"
+)
+
+// Default é o idioma usado quando o cliente não informa um suportado
+const Default = PtBR
+
+// Supported lista os idiomas na ordem de preferência da API
+var Supported = []string{PtBR, EN, ES}
+
+// Negotiate escolhe o idioma pelo header Accept-Language, respeitando os pesos q.
+// Variantes regionais são aceitas pelo idioma base (pt-PT usa pt-BR, en-US usa en).
+func Negotiate(acceptLanguage string) string {
+	type candidate struct {
+		lang    string
+		quality float64
+		order   int
+	}
+
+	var candidates []candidate
+	for i, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if tag == "" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+
+		if lang := match(tag); lang != "" {
+			candidates = append(candidates, candidate{lang, quality, i})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return Default
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+	return candidates[0].lang
+}
+
+func match(tag string) string {
+	if tag == "*" {
+		return Default
+	}
+	base := tag
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		base = tag[:i]
+	}
+	switch base {
+	case "pt":
+		return PtBR
+	case "en":
+		return EN
+	case "es":
+		return ES
+	}
+	return ""
+}
+
+// Translate retorna a mensagem da chave no idioma informado, formatada com args.
+// Sem tradução no idioma, usa o idioma padrão; sem a chave, retorna a própria chave.
+func Translate(lang, key string, args ...interface{}) string {
+	translations, ok := messages[key]
+	if !ok {
+		return key
+	}
+
+	format, ok := translations[lang]
+	if !ok {
+		format = translations[Default]
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Has indica se a chave possui mensagem cadastrada
+func Has(key string) bool {
+	_, ok := messages[key]
+	return ok
+}
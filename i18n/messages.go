@@ -0,0 +1,484 @@
+package i18n
+
+// messages contém as mensagens de erro da API, indexadas pelo código do erro
+var messages = map[string]map[string]string{
+	// Gerais
+	"internal_error": {
+		PtBR: "Erro interno do servidor",
+		EN:   "Internal server error",
+		ES:   "Error interno del servidor",
+	},
+	"service_unavailable": {
+		PtBR: "Serviço temporariamente indisponível. Tente novamente em instantes",
+		EN:   "Service temporarily unavailable. Please try again shortly",
+		ES:   "Servicio temporalmente no disponible. Inténtelo de nuevo en unos instantes",
+	},
+	"route_not_found": {
+		PtBR: "Rota não encontrada",
+		EN:   "Route not found",
+		ES:   "Ruta no encontrada",
+	},
+	"invalid_body": {
+		PtBR: "Corpo da requisição inválido",
+		EN:   "Invalid request body",
+		ES:   "Cuerpo de la solicitud no válido",
+	},
+	"invalid_id": {
+		PtBR: "ID inválido",
+		EN:   "Invalid ID",
+		ES:   "ID no válido",
+	},
+	"format_not_acceptable": {
+		PtBR: "Formato não suportado. Use json, csv, ndjson ou xlsx",
+		EN:   "Unsupported format. Use json, csv, ndjson or xlsx",
+		ES:   "Formato no admitido. Use json, csv, ndjson o xlsx",
+	},
+
+	"rate_limited": {
+		PtBR: "Muitas requisições. Tente novamente em %d segundos",
+		EN:   "Too many requests. Try again in %d seconds",
+		ES:   "Demasiadas solicitudes. Inténtelo de nuevo en %d segundos",
+	},
+
+	// Autenticação
+	"api_key_missing": {
+		PtBR: "API key ausente",
+		EN:   "Missing API key",
+		ES:   "Falta la API key",
+	},
+	"api_key_invalid": {
+		PtBR: "API key inválida",
+		EN:   "Invalid API key",
+		ES:   "API key no válida",
+	},
+	"api_key_revoked": {
+		PtBR: "API key revogada",
+		EN:   "API key has been revoked",
+		ES:   "La API key fue revocada",
+	},
+	"api_key_expired": {
+		PtBR: "API key expirada",
+		EN:   "API key has expired",
+		ES:   "La API key expiró",
+	},
+	"api_key_quota_exceeded": {
+		PtBR: "Cota diária da API key esgotada",
+		EN:   "Daily API key quota exceeded",
+		ES:   "Se agotó la cuota diaria de la API key",
+	},
+	"api_key_lookup_failed": {
+		PtBR: "Erro ao verificar a API key",
+		EN:   "Failed to verify the API key",
+		ES:   "Error al verificar la API key",
+	},
+	"token_invalid": {
+		PtBR: "Token de acesso inválido",
+		EN:   "Invalid access token",
+		ES:   "Token de acceso no válido",
+	},
+	"token_expired": {
+		PtBR: "Token de acesso expirado",
+		EN:   "Access token has expired",
+		ES:   "El token de acceso expiró",
+	},
+	"token_auth_disabled": {
+		PtBR: "Autenticação por token não configurada; use a API key",
+		EN:   "Token authentication is not configured; use an API key",
+		ES:   "La autenticación por token no está configurada; use la API key",
+	},
+	"insufficient_scope": {
+		PtBR: "A credencial não tem o escopo %s",
+		EN:   "The credential lacks the %s scope",
+		ES:   "La credencial no tiene el alcance %s",
+	},
+
+	// API keys
+	"api_key_name_required": {
+		PtBR: "Informe o nome da API key",
+		EN:   "The API key name is required",
+		ES:   "Indique el nombre de la API key",
+	},
+	"api_key_scopes_required": {
+		PtBR: "Informe ao menos um escopo",
+		EN:   "At least one scope is required",
+		ES:   "Indique al menos un alcance",
+	},
+	"api_key_scope_unknown": {
+		PtBR: "Escopo desconhecido: %s",
+		EN:   "Unknown scope: %s",
+		ES:   "Alcance desconocido: %s",
+	},
+	"api_key_quota_invalid": {
+		PtBR: "dailyQuota não pode ser negativa",
+		EN:   "dailyQuota cannot be negative",
+		ES:   "dailyQuota no puede ser negativa",
+	},
+	"api_key_expiry_invalid": {
+		PtBR: "expiresAt deve estar no futuro",
+		EN:   "expiresAt must be in the future",
+		ES:   "expiresAt debe estar en el futuro",
+	},
+	"api_key_generate_failed": {
+		PtBR: "Erro ao gerar a API key",
+		EN:   "Failed to generate the API key",
+		ES:   "Error al generar la API key",
+	},
+	"api_key_create_failed": {
+		PtBR: "Erro ao criar a API key",
+		EN:   "Failed to create the API key",
+		ES:   "Error al crear la API key",
+	},
+	"api_key_fetch_failed": {
+		PtBR: "Erro ao buscar API keys",
+		EN:   "Failed to fetch API keys",
+		ES:   "Error al obtener las API keys",
+	},
+	"api_key_not_found": {
+		PtBR: "API key não encontrada ou já revogada",
+		EN:   "API key not found or already revoked",
+		ES:   "API key no encontrada o ya revocada",
+	},
+	"api_key_rotate_failed": {
+		PtBR: "Erro ao rotacionar a API key",
+		EN:   "Failed to rotate the API key",
+		ES:   "Error al rotar la API key",
+	},
+	"api_key_revoke_failed": {
+		PtBR: "Erro ao revogar a API key",
+		EN:   "Failed to revoke the API key",
+		ES:   "Error al revocar la API key",
+	},
+
+	// Resultados e estatísticas
+	"results_fetch_failed": {
+		PtBR: "Erro ao buscar resultados",
+		EN:   "Failed to fetch match results",
+		ES:   "Error al obtener los resultados",
+	},
+	"result_not_found": {
+		PtBR: "Resultado não encontrado",
+		EN:   "Match result not found",
+		ES:   "Resultado no encontrado",
+	},
+	"result_create_failed": {
+		PtBR: "Erro ao criar resultado",
+		EN:   "Failed to create match result",
+		ES:   "Error al crear el resultado",
+	},
+	"result_update_failed": {
+		PtBR: "Erro ao atualizar resultado",
+		EN:   "Failed to update match result",
+		ES:   "Error al actualizar el resultado",
+	},
+	"result_version_conflict": {
+		PtBR: "O resultado foi alterado desde a versão informada em If-Match",
+		EN:   "The match result has changed since the version given in If-Match",
+		ES:   "El resultado cambió desde la versión indicada en If-Match",
+	},
+	"patch_content_type_invalid": {
+		PtBR: "Envie o patch como application/merge-patch+json",
+		EN:   "Send the patch as application/merge-patch+json",
+		ES:   "Envíe el patch como application/merge-patch+json",
+	},
+	"trash_fetch_failed": {
+		PtBR: "Erro ao buscar a lixeira",
+		EN:   "Failed to fetch the trash",
+		ES:   "Error al obtener la papelera",
+	},
+	"trash_result_not_found": {
+		PtBR: "Resultado não encontrado na lixeira",
+		EN:   "Match result not found in the trash",
+		ES:   "Resultado no encontrado en la papelera",
+	},
+	"result_exists": {
+		PtBR: "Já existe um resultado com este matchId; se ele estiver na lixeira, restaure-o",
+		EN:   "A match result with this matchId already exists; if it is in the trash, restore it",
+		ES:   "Ya existe un resultado con este matchId; si está en la papelera, restáurelo",
+	},
+	"result_restore_failed": {
+		PtBR: "Erro ao restaurar resultado",
+		EN:   "Failed to restore the match result",
+		ES:   "Error al restaurar el resultado",
+	},
+	"history_fetch_failed": {
+		PtBR: "Erro ao buscar histórico do resultado",
+		EN:   "Failed to fetch the match result history",
+		ES:   "Error al obtener el historial del resultado",
+	},
+	"revision_not_found": {
+		PtBR: "Revisão não encontrada",
+		EN:   "Revision not found",
+		ES:   "Revisión no encontrada",
+	},
+	"result_revert_failed": {
+		PtBR: "Erro ao reverter resultado",
+		EN:   "Failed to revert the match result",
+		ES:   "Error al revertir el resultado",
+	},
+	"result_delete_failed": {
+		PtBR: "Erro ao excluir resultado",
+		EN:   "Failed to delete match result",
+		ES:   "Error al eliminar el resultado",
+	},
+	"stats_fetch_failed": {
+		PtBR: "Erro ao buscar estatísticas",
+		EN:   "Failed to fetch statistics",
+		ES:   "Error al obtener las estadísticas",
+	},
+	"player_not_found": {
+		PtBR: "Jogador não encontrado",
+		EN:   "Player not found",
+		ES:   "Jugador no encontrado",
+	},
+	"team_not_found": {
+		PtBR: "Time não encontrado: %s",
+		EN:   "Team not found: %s",
+		ES:   "Equipo no encontrado: %s",
+	},
+
+	// Importação
+	"import_format_required": {
+		PtBR: "Informe o formato (ndjson, json ou csv) pelo parâmetro format ou pelo Content-Type",
+		EN:   "Provide the format (ndjson, json or csv) in the format parameter or the Content-Type",
+		ES:   "Indique el formato (ndjson, json o csv) en el parámetro format o en el Content-Type",
+	},
+	"import_batch_size_invalid": {
+		PtBR: "batchSize deve estar entre 1 e %d",
+		EN:   "batchSize must be between 1 and %d",
+		ES:   "batchSize debe estar entre 1 y %d",
+	},
+	"import_file_invalid": {
+		PtBR: "Não foi possível ler o arquivo de importação",
+		EN:   "Could not read the import file",
+		ES:   "No se pudo leer el archivo de importación",
+	},
+
+	// GraphQL
+	"graphql_query_required": {
+		PtBR: "query é obrigatório",
+		EN:   "query is required",
+		ES:   "query es obligatorio",
+	},
+	"graphql_variables_invalid": {
+		PtBR: "variables deve ser um objeto JSON",
+		EN:   "variables must be a JSON object",
+		ES:   "variables debe ser un objeto JSON",
+	},
+
+	// Previsões e simulações
+	"best_of_invalid": {
+		PtBR: "bestOf deve ser 1, 3 ou 5",
+		EN:   "bestOf must be 1, 3 or 5",
+		ES:   "bestOf debe ser 1, 3 o 5",
+	},
+	"teams_invalid": {
+		PtBR: "Informe dois times diferentes em teamA e teamB",
+		EN:   "Provide two different teams in teamA and teamB",
+		ES:   "Indique dos equipos distintos en teamA y teamB",
+	},
+	"region_required": {
+		PtBR: "O parâmetro region é obrigatório",
+		EN:   "The region parameter is required",
+		ES:   "El parámetro region es obligatorio",
+	},
+	"runs_invalid": {
+		PtBR: "runs deve estar entre 1 e %d",
+		EN:   "runs must be between 1 and %d",
+		ES:   "runs debe estar entre 1 y %d",
+	},
+	"spots_invalid": {
+		PtBR: "spots deve ser um número positivo",
+		EN:   "spots must be a positive number",
+		ES:   "spots debe ser un número positivo",
+	},
+	"seed_invalid": {
+		PtBR: "seed deve ser um número inteiro",
+		EN:   "seed must be an integer",
+		ES:   "seed debe ser un número entero",
+	},
+	"region_matches_not_found": {
+		PtBR: "Nenhuma partida encontrada para a região",
+		EN:   "No matches found for the region",
+		ES:   "No se encontraron partidas para la región",
+	},
+
+	// Campeões
+	"min_games_invalid": {
+		PtBR: "minGames deve ser um número positivo",
+		EN:   "minGames must be a positive number",
+		ES:   "minGames debe ser un número positivo",
+	},
+	"confidence_invalid": {
+		PtBR: "Nível de confiança não suportado. Use 0.8, 0.9, 0.95 ou 0.99",
+		EN:   "Unsupported confidence level. Use 0.8, 0.9, 0.95 or 0.99",
+		ES:   "Nivel de confianza no admitido. Use 0.8, 0.9, 0.95 o 0.99",
+	},
+
+	// Calendário
+	"schedule_fetch_failed": {
+		PtBR: "Erro ao buscar calendário",
+		EN:   "Failed to fetch the schedule",
+		ES:   "Error al obtener el calendario",
+	},
+	"schedule_save_failed": {
+		PtBR: "Erro ao salvar partida do calendário",
+		EN:   "Failed to save the scheduled match",
+		ES:   "Error al guardar el partido del calendario",
+	},
+	"schedule_delete_failed": {
+		PtBR: "Erro ao excluir partida do calendário",
+		EN:   "Failed to delete the scheduled match",
+		ES:   "Error al eliminar el partido del calendario",
+	},
+
+	// Fantasy
+	"ruleset_fetch_failed": {
+		PtBR: "Erro ao buscar regras de fantasy",
+		EN:   "Failed to fetch fantasy rulesets",
+		ES:   "Error al obtener las reglas de fantasy",
+	},
+	"ruleset_not_found": {
+		PtBR: "Regras de fantasy não encontradas",
+		EN:   "Fantasy ruleset not found",
+		ES:   "Reglas de fantasy no encontradas",
+	},
+	"ruleset_save_failed": {
+		PtBR: "Erro ao salvar regras de fantasy",
+		EN:   "Failed to save the fantasy ruleset",
+		ES:   "Error al guardar las reglas de fantasy",
+	},
+	"ruleset_delete_failed": {
+		PtBR: "Erro ao excluir regras de fantasy",
+		EN:   "Failed to delete the fantasy ruleset",
+		ES:   "Error al eliminar las reglas de fantasy",
+	},
+
+	// Webhooks
+	"webhook_url_invalid": {
+		PtBR: "url deve ser uma URL http(s) válida",
+		EN:   "url must be a valid http(s) URL",
+		ES:   "url debe ser una URL http(s) válida",
+	},
+	"webhook_events_required": {
+		PtBR: "Informe ao menos um evento",
+		EN:   "Provide at least one event",
+		ES:   "Indique al menos un evento",
+	},
+	"webhook_event_unknown": {
+		PtBR: "Evento desconhecido: %s",
+		EN:   "Unknown event: %s",
+		ES:   "Evento desconocido: %s",
+	},
+	"webhook_secret_failed": {
+		PtBR: "Erro ao gerar segredo do webhook",
+		EN:   "Failed to generate the webhook secret",
+		ES:   "Error al generar el secreto del webhook",
+	},
+	"webhook_create_failed": {
+		PtBR: "Erro ao criar webhook",
+		EN:   "Failed to create the webhook",
+		ES:   "Error al crear el webhook",
+	},
+	"webhook_fetch_failed": {
+		PtBR: "Erro ao buscar webhooks",
+		EN:   "Failed to fetch webhooks",
+		ES:   "Error al obtener los webhooks",
+	},
+	"webhook_not_found": {
+		PtBR: "Webhook não encontrado",
+		EN:   "Webhook not found",
+		ES:   "Webhook no encontrado",
+	},
+	"webhook_delete_failed": {
+		PtBR: "Erro ao excluir webhook",
+		EN:   "Failed to delete the webhook",
+		ES:   "Error al eliminar el webhook",
+	},
+	"webhook_id_invalid": {
+		PtBR: "webhookId inválido",
+		EN:   "Invalid webhookId",
+		ES:   "webhookId no válido",
+	},
+	"deliveries_fetch_failed": {
+		PtBR: "Erro ao buscar entregas",
+		EN:   "Failed to fetch deliveries",
+		ES:   "Error al obtener las entregas",
+	},
+	"dead_letter_not_found": {
+		PtBR: "Entrega não encontrada na fila de mortas",
+		EN:   "Delivery not found in the dead letter queue",
+		ES:   "Entrega no encontrada en la cola de mensajes fallidos",
+	},
+	"delivery_requeue_failed": {
+		PtBR: "Erro ao reenfileirar entrega",
+		EN:   "Failed to requeue the delivery",
+		ES:   "Error al volver a encolar la entrega",
+	},
+
+	// Validação de partidas
+	"validation_failed": {
+		PtBR: "A partida possui campos inválidos",
+		EN:   "The match has invalid fields",
+		ES:   "El partido tiene campos no válidos",
+	},
+	"validation_required": {
+		PtBR: "campo obrigatório",
+		EN:   "field is required",
+		ES:   "campo obligatorio",
+	},
+	"validation_region_invalid": {
+		PtBR: "região desconhecida; use uma de: %s",
+		EN:   "unknown region; use one of: %s",
+		ES:   "región desconocida; use una de: %s",
+	},
+	"validation_teams_equal": {
+		PtBR: "teamA e teamB devem ser diferentes",
+		EN:   "teamA and teamB must be different",
+		ES:   "teamA y teamB deben ser distintos",
+	},
+	"validation_best_of_invalid": {
+		PtBR: "o formato da série deve ser 1, 3 ou 5",
+		EN:   "the series format must be 1, 3 or 5",
+		ES:   "el formato de la serie debe ser 1, 3 o 5",
+	},
+	"validation_negative": {
+		PtBR: "não pode ser negativo",
+		EN:   "must not be negative",
+		ES:   "no puede ser negativo",
+	},
+	"validation_score_series": {
+		PtBR: "o placar deve encerrar uma série MD1, MD3 ou MD5 (ex.: 1-0, 2-1, 3-2)",
+		EN:   "the score must end a best-of-1, 3 or 5 series (e.g. 1-0, 2-1, 3-2)",
+		ES:   "el marcador debe cerrar una serie al mejor de 1, 3 o 5 (ej.: 1-0, 2-1, 3-2)",
+	},
+	"validation_winner_invalid": {
+		PtBR: "o vencedor deve ser teamA ou teamB",
+		EN:   "the winner must be teamA or teamB",
+		ES:   "el ganador debe ser teamA o teamB",
+	},
+	"validation_winner_score_mismatch": {
+		PtBR: "o vencedor não corresponde ao placar",
+		EN:   "the winner does not match the score",
+		ES:   "el ganador no corresponde al marcador",
+	},
+	"validation_player_duplicate": {
+		PtBR: "jogador repetido (já informado em players[%d])",
+		EN:   "duplicate player (already listed at players[%d])",
+		ES:   "jugador repetido (ya indicado en players[%d])",
+	},
+	"validation_player_team_invalid": {
+		PtBR: "o time do jogador deve ser teamA ou teamB",
+		EN:   "the player's team must be teamA or teamB",
+		ES:   "el equipo del jugador debe ser teamA o teamB",
+	},
+	"validation_position_invalid": {
+		PtBR: "posição desconhecida; use uma de: %s",
+		EN:   "unknown position; use one of: %s",
+		ES:   "posición desconocida; use una de: %s",
+	},
+	"validation_match_id_mismatch": {
+		PtBR: "deve ser igual ao matchId da rota",
+		EN:   "must match the matchId in the path",
+		ES:   "debe coincidir con el matchId de la ruta",
+	},
+}
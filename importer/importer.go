@@ -0,0 +1,132 @@
+package importer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/models"
+	"github.com/bulletdev/lta-results-api/mvp"
+	"github.com/bulletdev/lta-results-api/validation"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Tamanho padrão e máximo dos lotes gravados no banco
+const (
+	DefaultBatchSize = 500
+	MaxBatchSize     = 5000
+)
+
+// Options configura uma importação
+type Options struct {
+	DryRun    bool
+	BatchSize int
+	// Author é registrado no histórico de cada partida gravada
+	Author models.Author
+}
+
+// Report resume o resultado de uma importação
+type Report struct {
+	DryRun    bool       `json:"dryRun"`
+	Total     int        `json:"total"`
+	Valid     int        `json:"valid"`
+	Invalid   int        `json:"invalid"`
+	Inserted  int        `json:"inserted"`
+	Updated   int        `json:"updated"`
+	Unchanged int        `json:"unchanged"`
+	Failed    int        `json:"failed"`
+	Errors    []RowError `json:"errors"`
+}
+
+// RowError descreve os problemas encontrados em uma linha da entrada
+type RowError struct {
+	Row     int               `json:"row"`
+	MatchID string            `json:"matchId,omitempty"`
+	Errors  []string          `json:"errors"`
+	Fields  validation.Errors `json:"fields,omitempty"`
+}
+
+// Run valida os registros e, fora do modo dry-run, grava os válidos em lotes
+func Run(records []Record, opts Options) *Report {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+	if opts.BatchSize > MaxBatchSize {
+		opts.BatchSize = MaxBatchSize
+	}
+
+	report := &Report{DryRun: opts.DryRun, Total: len(records), Errors: []RowError{}}
+	seen := make(map[string]int)
+	now := time.Now()
+
+	var valid []Record
+	for _, record := range records {
+		if record.Err != nil {
+			report.addError(record, record.Err.Error())
+			report.Invalid++
+			continue
+		}
+
+		fieldErrs := validation.MatchResult(record.Match)
+		problems := fieldErrs.Messages()
+		if previous, ok := seen[record.Match.MatchID]; ok && record.Match.MatchID != "" {
+			problems = append(problems, fmt.Sprintf("matchId duplicado no arquivo (já informado na linha %d)", previous))
+		}
+		if len(problems) > 0 {
+			report.addError(record, problems...).Fields = fieldErrs
+			report.Invalid++
+			continue
+		}
+		seen[record.Match.MatchID] = record.Row
+
+		// Mesmo tratamento da criação manual
+		record.Match.ID = primitive.NilObjectID
+		record.Match.CreatedAt = now
+		record.Match.UpdatedAt = now
+		mvp.Assign(record.Match)
+
+		valid = append(valid, record)
+	}
+	report.Valid = len(valid)
+
+	if opts.DryRun {
+		return report
+	}
+
+	for start := 0; start < len(valid); start += opts.BatchSize {
+		end := start + opts.BatchSize
+		if end > len(valid) {
+			end = len(valid)
+		}
+		batch := valid[start:end]
+
+		matches := make([]*models.MatchResult, len(batch))
+		for i, record := range batch {
+			matches[i] = record.Match
+		}
+
+		inserted, updated, err := models.UpsertMatchResults(matches, opts.Author)
+		if err != nil {
+			// O lote é gravado em uma transação, então todas as linhas dele falham juntas
+			for _, record := range batch {
+				report.addError(record, "erro ao gravar no banco: "+err.Error())
+			}
+			report.Failed += len(batch)
+			continue
+		}
+		report.Inserted += inserted
+		report.Updated += updated
+		report.Unchanged += len(batch) - inserted - updated
+	}
+
+	return report
+}
+
+// addError registra os problemas da linha e retorna o erro adicionado ao relatório
+func (r *Report) addError(record Record, problems ...string) *RowError {
+	rowErr := RowError{Row: record.Row, Errors: problems}
+	if record.Match != nil {
+		rowErr.MatchID = record.Match.MatchID
+	}
+	r.Errors = append(r.Errors, rowErr)
+	return &r.Errors[len(r.Errors)-1]
+}
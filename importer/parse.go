@@ -0,0 +1,228 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/models"
+)
+
+// Formatos de entrada suportados
+const (
+	FormatNDJSON = "ndjson"
+	FormatJSON   = "json"
+	FormatCSV    = "csv"
+)
+
+// Record é uma partida lida da entrada, com a linha de origem e o erro de leitura, se houver
+type Record struct {
+	Row   int
+	Match *models.MatchResult
+	Err   error
+}
+
+// DetectFormat identifica o formato pelo Content-Type ou pela extensão do arquivo
+func DetectFormat(contentTypeOrPath string) string {
+	value := strings.ToLower(contentTypeOrPath)
+	switch {
+	case strings.Contains(value, "ndjson"), strings.Contains(value, "jsonl"):
+		return FormatNDJSON
+	case strings.Contains(value, "csv"):
+		return FormatCSV
+	case strings.Contains(value, "json"):
+		return FormatJSON
+	}
+
+	switch filepath.Ext(value) {
+	case ".ndjson", ".jsonl":
+		return FormatNDJSON
+	case ".csv":
+		return FormatCSV
+	case ".json":
+		return FormatJSON
+	}
+	return ""
+}
+
+// Parse lê as partidas no formato informado. Erros em linhas individuais são
+// registrados no Record correspondente; apenas erros que impedem a leitura são retornados.
+func Parse(r io.Reader, format string) ([]Record, error) {
+	switch format {
+	case FormatNDJSON:
+		return parseNDJSON(r)
+	case FormatJSON:
+		return parseJSON(r)
+	case FormatCSV:
+		return parseCSV(r)
+	}
+	return nil, fmt.Errorf("formato não suportado: %q (use ndjson, json ou csv)", format)
+}
+
+func parseNDJSON(r io.Reader) ([]Record, error) {
+	var records []Record
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		var match models.MatchResult
+		if err := json.Unmarshal(data, &match); err != nil {
+			records = append(records, Record{Row: line, Err: fmt.Errorf("JSON inválido: %v", err)})
+			continue
+		}
+		records = append(records, Record{Row: line, Match: &match})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func parseJSON(r io.Reader) ([]Record, error) {
+	decoder := json.NewDecoder(r)
+
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, fmt.Errorf("JSON inválido: %v", err)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, fmt.Errorf("JSON inválido: esperado um array de partidas")
+	}
+
+	var records []Record
+	for row := 1; decoder.More(); row++ {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("JSON inválido no item %d: %v", row, err)
+		}
+
+		var match models.MatchResult
+		if err := json.Unmarshal(raw, &match); err != nil {
+			records = append(records, Record{Row: row, Err: fmt.Errorf("JSON inválido: %v", err)})
+			continue
+		}
+		records = append(records, Record{Row: row, Match: &match})
+	}
+
+	return records, nil
+}
+
+// parseCSV lê o mesmo layout gerado pela exportação: uma linha por jogador por partida.
+// Linhas consecutivas ou não com o mesmo matchId são agrupadas em uma única partida.
+func parseCSV(r io.Reader) ([]Record, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("CSV sem cabeçalho: %v", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	if _, ok := columns["matchId"]; !ok {
+		return nil, fmt.Errorf("CSV sem a coluna matchId")
+	}
+
+	var records []Record
+	byMatch := make(map[string]int)
+
+	for line := 2; ; line++ {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			records = append(records, Record{Row: line, Err: fmt.Errorf("CSV inválido: %v", err)})
+			continue
+		}
+
+		get := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(fields) {
+				return strings.TrimSpace(fields[i])
+			}
+			return ""
+		}
+
+		var rowErrs []string
+		number := func(name string) int {
+			value := get(name)
+			if value == "" {
+				return 0
+			}
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				rowErrs = append(rowErrs, fmt.Sprintf("%s não é um número: %q", name, value))
+			}
+			return n
+		}
+
+		matchID := get("matchId")
+		index, exists := byMatch[matchID]
+		if !exists {
+			match := &models.MatchResult{
+				MatchID:         matchID,
+				Region:          get("region"),
+				TournamentStage: get("tournamentStage"),
+				TeamA:           get("teamA"),
+				TeamB:           get("teamB"),
+				ScoreA:          number("scoreA"),
+				ScoreB:          number("scoreB"),
+				Winner:          get("winner"),
+				Duration:        get("duration"),
+				MVP:             get("mvp"),
+			}
+			if value := get("date"); value != "" {
+				date, err := time.Parse(time.RFC3339, value)
+				if err != nil {
+					rowErrs = append(rowErrs, fmt.Sprintf("date deve estar no formato RFC 3339: %q", value))
+				}
+				match.Date = date
+			}
+
+			index = len(records)
+			byMatch[matchID] = index
+			records = append(records, Record{Row: line, Match: match})
+		}
+
+		if name := get("player"); name != "" {
+			player := models.Player{
+				Name:        name,
+				Team:        get("team"),
+				Position:    get("position"),
+				Champion:    get("champion"),
+				Kills:       number("kills"),
+				Deaths:      number("deaths"),
+				Assists:     number("assists"),
+				CS:          number("cs"),
+				Gold:        number("gold"),
+				DamageDealt: number("damageDealt"),
+				VisionScore: number("visionScore"),
+			}
+			records[index].Match.Players = append(records[index].Match.Players, player)
+		}
+
+		if len(rowErrs) > 0 && records[index].Err == nil {
+			records[index].Err = fmt.Errorf("linha %d: %s", line, strings.Join(rowErrs, "; "))
+		}
+	}
+
+	return records, nil
+}
@@ -0,0 +1,234 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/database"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Escopos que uma API key pode receber. ScopeAdmin concede todos os outros.
+const (
+	ScopeRead   = "read"
+	ScopeWrite  = "write"
+	ScopeScrape = "scrape"
+	ScopeAdmin  = "admin"
+)
+
+// Scopes lista os escopos válidos
+var Scopes = []string{ScopeRead, ScopeWrite, ScopeScrape, ScopeAdmin}
+
+// ValidScope indica se o escopo existe
+func ValidScope(scope string) bool {
+	for _, s := range Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKey representa uma chave de acesso à API administrativa. Apenas o hash SHA-256
+// da chave é gravado; Prefix identifica a chave sem revelá-la.
+type APIKey struct {
+	ID     primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Name   string             `bson:"name" json:"name"`
+	Owner  string             `bson:"owner" json:"owner"`
+	Prefix string             `bson:"prefix" json:"prefix"`
+	Hash   string             `bson:"hash" json:"-"`
+	Scopes []string           `bson:"scopes" json:"scopes"`
+	// DailyQuota limita as requisições por dia (UTC); zero não limita
+	DailyQuota int64      `bson:"dailyQuota,omitempty" json:"dailyQuota,omitempty"`
+	ExpiresAt  *time.Time `bson:"expiresAt,omitempty" json:"expiresAt,omitempty"`
+	RevokedAt  *time.Time `bson:"revokedAt,omitempty" json:"revokedAt,omitempty"`
+	RotatedAt  *time.Time `bson:"rotatedAt,omitempty" json:"rotatedAt,omitempty"`
+	LastUsedAt *time.Time `bson:"lastUsedAt,omitempty" json:"lastUsedAt,omitempty"`
+	CreatedAt  time.Time  `bson:"createdAt" json:"createdAt"`
+	UpdatedAt  time.Time  `bson:"updatedAt" json:"updatedAt"`
+}
+
+// Expired indica se a chave já expirou em now
+func (k *APIKey) Expired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// CreateAPIKey insere uma nova API key
+func CreateAPIKey(key *APIKey) error {
+	collection := database.GetCollection("api_keys")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := collection.InsertOne(ctx, key)
+	return err
+}
+
+// GetAPIKeys obtém todas as API keys, inclusive as revogadas
+func GetAPIKeys() ([]APIKey, error) {
+	collection := database.GetCollection("api_keys")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cursor, err := collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"createdAt": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	keys := []APIKey{}
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// GetAPIKeyByHash obtém a API key com o hash informado
+func GetAPIKeyByHash(hash string) (*APIKey, error) {
+	collection := database.GetCollection("api_keys")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var key APIKey
+	if err := collection.FindOne(ctx, bson.M{"hash": hash}).Decode(&key); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// RotateAPIKey troca o segredo de uma chave não revogada, mantendo nome, escopos e
+// cota, e retorna a chave atualizada. A chave anterior deixa de funcionar imediatamente.
+func RotateAPIKey(id primitive.ObjectID, prefix, hash string) (*APIKey, error) {
+	collection := database.GetCollection("api_keys")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	update := bson.M{"$set": bson.M{
+		"prefix":    prefix,
+		"hash":      hash,
+		"rotatedAt": now,
+		"updatedAt": now,
+	}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var key APIKey
+	err := collection.FindOneAndUpdate(ctx, bson.M{"_id": id, "revokedAt": nil}, update, opts).Decode(&key)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// RevokeAPIKey revoga uma chave, retornando mongo.ErrNoDocuments se ela não existir ou
+// já estiver revogada
+func RevokeAPIKey(id primitive.ObjectID) error {
+	collection := database.GetCollection("api_keys")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	update := bson.M{"$set": bson.M{"revokedAt": now, "updatedAt": now}}
+
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": id, "revokedAt": nil}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// APIKeyUsageDay é o dia (UTC) em que um uso em now é contado para a cota
+func APIKeyUsageDay(now time.Time) string {
+	return now.UTC().Format("2006-01-02")
+}
+
+// GetAPIKeyUsage retorna quantas requisições a chave fez no dia, 0 se nenhuma
+func GetAPIKeyUsage(id primitive.ObjectID, day string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var usage struct {
+		Count int64 `bson:"count"`
+	}
+	err := database.GetCollection("api_key_usage").
+		FindOne(ctx, bson.M{"keyId": id, "day": day}).
+		Decode(&usage)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return 0, nil
+	}
+	return usage.Count, err
+}
+
+// APIKeyUse são os usos de uma chave em um dia ainda não gravados
+type APIKeyUse struct {
+	KeyID      primitive.ObjectID
+	Day        string
+	Count      int64
+	LastUsedAt time.Time
+}
+
+// RecordAPIKeyUses soma os usos às contagens dos dias e atualiza o lastUsedAt das
+// chaves. Retorna, na ordem de uses, a contagem de cada dia depois da soma, que inclui
+// os usos gravados por outras instâncias; em caso de erro, apenas a dos usos já
+// gravados.
+func RecordAPIKeyUses(uses []APIKeyUse) ([]int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	counts := make([]int64, 0, len(uses))
+	lastUsed := make(map[primitive.ObjectID]time.Time)
+	for _, use := range uses {
+		// No upsert o keyId e o day vêm do filtro
+		update := bson.M{"$inc": bson.M{"count": use.Count}}
+		opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+		var usage struct {
+			Count int64 `bson:"count"`
+		}
+		err := database.GetCollection("api_key_usage").
+			FindOneAndUpdate(ctx, bson.M{"keyId": use.KeyID, "day": use.Day}, update, opts).
+			Decode(&usage)
+		if err != nil {
+			return counts, err
+		}
+		counts = append(counts, usage.Count)
+
+		if use.LastUsedAt.After(lastUsed[use.KeyID]) {
+			lastUsed[use.KeyID] = use.LastUsedAt
+		}
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(lastUsed))
+	for id, at := range lastUsed {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": id}).
+			SetUpdate(bson.M{"$set": bson.M{"lastUsedAt": at}}))
+	}
+	if len(writes) == 0 {
+		return counts, nil
+	}
+	_, err := database.GetCollection("api_keys").BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return counts, err
+}
+
+// PurgeAPIKeyUsage exclui as contagens de uso dos dias (UTC) anteriores ao de before.
+// Apenas a contagem do dia corrente é usada pela cota.
+func PurgeAPIKeyUsage(before time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// O formato AAAA-MM-DD ordena as datas como texto
+	res, err := database.GetCollection("api_key_usage").
+		DeleteMany(ctx, bson.M{"day": bson.M{"$lt": APIKeyUsageDay(before)}})
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
@@ -0,0 +1,113 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/database"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DefaultRulesetName é o nome do conjunto de regras usado quando nenhum é informado
+const DefaultRulesetName = "default"
+
+// FantasyRuleset define a pontuação de fantasy aplicada às estatísticas dos jogadores
+type FantasyRuleset struct {
+	Name                 string    `bson:"name" json:"name"`
+	Description          string    `bson:"description,omitempty" json:"description,omitempty"`
+	Kill                 float64   `bson:"kill" json:"kill"`
+	Death                float64   `bson:"death" json:"death"`
+	Assist               float64   `bson:"assist" json:"assist"`
+	CS                   float64   `bson:"cs" json:"cs"`
+	KillBonusThreshold   int       `bson:"killBonusThreshold" json:"killBonusThreshold"`
+	KillBonus            float64   `bson:"killBonus" json:"killBonus"`
+	AssistBonusThreshold int       `bson:"assistBonusThreshold" json:"assistBonusThreshold"`
+	AssistBonus          float64   `bson:"assistBonus" json:"assistBonus"`
+	DeathlessBonus       float64   `bson:"deathlessBonus" json:"deathlessBonus"`
+	TeamWin              float64   `bson:"teamWin" json:"teamWin"`
+	CreatedAt            time.Time `bson:"createdAt" json:"createdAt"`
+	UpdatedAt            time.Time `bson:"updatedAt" json:"updatedAt"`
+}
+
+// DefaultFantasyRuleset retorna as regras padrão, usadas enquanto nenhuma for salva com esse nome
+func DefaultFantasyRuleset() *FantasyRuleset {
+	return &FantasyRuleset{
+		Name:                 DefaultRulesetName,
+		Description:          "Pontuação padrão de fantasy da LTA",
+		Kill:                 3,
+		Death:                -1,
+		Assist:               2,
+		CS:                   0.02,
+		KillBonusThreshold:   10,
+		KillBonus:            2,
+		AssistBonusThreshold: 10,
+		AssistBonus:          2,
+		DeathlessBonus:       2,
+		TeamWin:              2,
+	}
+}
+
+// GetFantasyRulesets obtém todos os conjuntos de regras salvos
+func GetFantasyRulesets() ([]FantasyRuleset, error) {
+	collection := database.GetCollection("fantasy_rulesets")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cursor, err := collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"name": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rulesets []FantasyRuleset
+	if err := cursor.All(ctx, &rulesets); err != nil {
+		return nil, err
+	}
+
+	return rulesets, nil
+}
+
+// GetFantasyRuleset obtém um conjunto de regras pelo nome, recorrendo ao padrão
+// quando "default" ainda não foi personalizado. Retorna nil se não existir.
+func GetFantasyRuleset(name string) (*FantasyRuleset, error) {
+	collection := database.GetCollection("fantasy_rulesets")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var ruleset FantasyRuleset
+	err := collection.FindOne(ctx, bson.M{"name": name}).Decode(&ruleset)
+	if err == mongo.ErrNoDocuments {
+		if name == DefaultRulesetName {
+			return DefaultFantasyRuleset(), nil
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &ruleset, nil
+}
+
+// SaveFantasyRuleset insere ou substitui um conjunto de regras pelo nome
+func SaveFantasyRuleset(ruleset *FantasyRuleset) error {
+	collection := database.GetCollection("fantasy_rulesets")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"name": ruleset.Name}
+	_, err := collection.ReplaceOne(ctx, filter, ruleset, options.Replace().SetUpsert(true))
+	return err
+}
+
+// DeleteFantasyRuleset exclui um conjunto de regras
+func DeleteFantasyRuleset(name string) error {
+	collection := database.GetCollection("fantasy_rulesets")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := collection.DeleteOne(ctx, bson.M{"name": name})
+	return err
+}
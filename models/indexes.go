@@ -0,0 +1,40 @@
+package models
+
+import (
+	"log"
+
+	"github.com/bulletdev/lta-results-api/database"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// indexes lista os índices usados pelas consultas dos modelos e os que garantem a
+// unicidade dos dados
+var indexes = []database.Index{
+	// Uma partida por matchId, inclusive as que estão na lixeira
+	{Collection: "match_results", Keys: bson.D{{Key: "matchId", Value: 1}}, Unique: true},
+	{Collection: "match_revisions", Keys: bson.D{{Key: "matchId", Value: 1}, {Key: "_id", Value: -1}}},
+	// Eventos pendentes e retomada dos streams, ambos pela sequência
+	{Collection: "outbox", Keys: bson.D{{Key: "published", Value: 1}, {Key: "seq", Value: 1}}},
+	{Collection: "counters", Keys: bson.D{{Key: "name", Value: 1}}, Unique: true},
+	{Collection: "api_keys", Keys: bson.D{{Key: "hash", Value: 1}}, Unique: true},
+	// Uma contagem por chave e dia; o índice por dia atende à limpeza das antigas
+	{Collection: "api_key_usage", Keys: bson.D{{Key: "keyId", Value: 1}, {Key: "day", Value: 1}}, Unique: true},
+	{Collection: "api_key_usage", Keys: bson.D{{Key: "day", Value: 1}}},
+	// Uma entrega por evento e webhook, para que reprocessar um evento não a duplique
+	{Collection: "webhook_deliveries", Keys: bson.D{{Key: "webhookId", Value: 1}, {Key: "eventId", Value: 1}}, Unique: true},
+	{Collection: "webhook_deliveries", Keys: bson.D{{Key: "status", Value: 1}, {Key: "nextAttemptAt", Value: 1}}},
+	{Collection: "scrape_status", Keys: bson.D{{Key: "region", Value: 1}}, Unique: true},
+}
+
+// EnsureIndexes cria os índices que ainda não existem. Antes, os matchIds repetidos
+// são resolvidos por DedupeMatchResults, para que o índice único possa ser criado.
+func EnsureIndexes() error {
+	moved, err := DedupeMatchResults()
+	if moved > 0 {
+		log.Printf("%d partidas com matchId repetido movidas para a lixeira", moved)
+	}
+	if err != nil {
+		return err
+	}
+	return database.EnsureIndexes(indexes)
+}
@@ -2,13 +2,17 @@ package models
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sort"
 	"strconv"
 	"time"
 
 	"github.com/bulletdev/lta-results-api/database"
+	"github.com/bulletdev/lta-results-api/events"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
@@ -28,8 +32,27 @@ type MatchResult struct {
 	MVP             string             `bson:"mvp,omitempty" json:"mvp,omitempty"`
 	TournamentStage string             `bson:"tournamentStage,omitempty" json:"tournamentStage,omitempty"`
 	VOD             string             `bson:"vod,omitempty" json:"vod,omitempty"`
+	Version         int64              `bson:"version" json:"version"`
 	CreatedAt       time.Time          `bson:"createdAt" json:"createdAt"`
 	UpdatedAt       time.Time          `bson:"updatedAt" json:"updatedAt"`
+	DeletedAt       *time.Time         `bson:"deletedAt,omitempty" json:"deletedAt,omitempty"`
+	DeletedBy       *Author            `bson:"deletedBy,omitempty" json:"deletedBy,omitempty"`
+}
+
+// ErrVersionConflict indica que o resultado foi alterado desde a versão lida
+var ErrVersionConflict = errors.New("o resultado foi alterado por outra requisição")
+
+// ErrMatchExists indica que já existe uma partida com o mesmo matchId, ativa ou, ao
+// criar uma nova, na lixeira
+var ErrMatchExists = errors.New("já existe uma partida com este matchId")
+
+// live restringe o filtro às partidas que não estão na lixeira, sem alterar o original
+func live(filter bson.M) bson.M {
+	restricted := bson.M{"deletedAt": nil}
+	for key, value := range filter {
+		restricted[key] = value
+	}
+	return restricted
 }
 
 // Player representa um jogador em uma partida
@@ -86,6 +109,8 @@ func GetMatchResults(filter bson.M, options *options.FindOptions) ([]MatchResult
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	filter = live(filter)
+
 	// Contar total de documentos
 	total, err := collection.CountDocuments(ctx, filter)
 	if err != nil {
@@ -108,13 +133,73 @@ func GetMatchResults(filter bson.M, options *options.FindOptions) ([]MatchResult
 	return results, total, nil
 }
 
-// GetMatchResultByID obtém um resultado específico por ID
+// GetAllMatchResults obtém todas as partidas que atendem ao filtro, ordenadas por data
+func GetAllMatchResults(filter bson.M) ([]MatchResult, error) {
+	collection := database.GetCollection("match_results")
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	findOptions := options.Find().SetSort(bson.M{"date": 1})
+	cursor, err := collection.Find(ctx, live(filter), findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []MatchResult
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// MatchResultCursor percorre partidas sem carregá-las todas em memória
+type MatchResultCursor struct {
+	cursor *mongo.Cursor
+}
+
+// OpenMatchResults abre o cursor das partidas que atendem ao filtro. Erros do banco
+// aparecem aqui, antes de qualquer partida ser lida.
+func OpenMatchResults(ctx context.Context, filter bson.M, findOptions *options.FindOptions) (*MatchResultCursor, error) {
+	collection := database.GetCollection("match_results")
+
+	cursor, err := collection.Find(ctx, live(filter), findOptions)
+	if err != nil {
+		return nil, err
+	}
+	return &MatchResultCursor{cursor: cursor}, nil
+}
+
+// Each chama fn para cada partida até o fim do cursor ou o primeiro erro, e fecha o cursor
+func (c *MatchResultCursor) Each(ctx context.Context, fn func(*MatchResult) error) error {
+	defer c.cursor.Close(ctx)
+
+	for c.cursor.Next(ctx) {
+		var result MatchResult
+		if err := c.cursor.Decode(&result); err != nil {
+			return err
+		}
+		if err := fn(&result); err != nil {
+			return err
+		}
+	}
+
+	return c.cursor.Err()
+}
+
+// Close libera o cursor sem percorrê-lo
+func (c *MatchResultCursor) Close(ctx context.Context) error {
+	return c.cursor.Close(ctx)
+}
+
+// GetMatchResultByID obtém um resultado específico por ID. Partidas na lixeira não são retornadas.
 func GetMatchResultByID(matchID string) (*MatchResult, error) {
 	collection := database.GetCollection("match_results")
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	filter := bson.M{"matchId": matchID}
+	filter := live(bson.M{"matchId": matchID})
 	var result MatchResult
 
 	err := collection.FindOne(ctx, filter).Decode(&result)
@@ -132,7 +217,7 @@ func GetPlayerStats(playerName string) (*PlayerStats, error) {
 	defer cancel()
 
 	// Filtrar partidas onde o jogador participou
-	filter := bson.M{"players.name": playerName}
+	filter := live(bson.M{"players.name": playerName})
 	cursor, err := collection.Find(ctx, filter)
 	if err != nil {
 		return nil, err
@@ -144,8 +229,25 @@ func GetPlayerStats(playerName string) (*PlayerStats, error) {
 		return nil, err
 	}
 
+	return ComputePlayerStats(playerName, matches), nil
+}
+
+// ComputePlayerStats calcula as estatísticas de um jogador a partir das partidas
+// informadas. Retorna nil se ele não participou de nenhuma delas.
+func ComputePlayerStats(playerName string, matches []MatchResult) *PlayerStats {
+	var played []MatchResult
+	for _, match := range matches {
+		for _, player := range match.Players {
+			if player.Name == playerName {
+				played = append(played, match)
+				break
+			}
+		}
+	}
+	matches = played
+
 	if len(matches) == 0 {
-		return nil, nil
+		return nil
 	}
 
 	// Calcular estatísticas
@@ -190,7 +292,7 @@ func GetPlayerStats(playerName string) (*PlayerStats, error) {
 		stats.KDA = "Perfect"
 	}
 
-	return stats, nil
+	return stats
 }
 
 // GetTeamStats calcula estatísticas agregadas para um time
@@ -200,12 +302,12 @@ func GetTeamStats(teamName string) (*TeamStats, error) {
 	defer cancel()
 
 	// Filtrar partidas onde o time participou
-	filter := bson.M{
+	filter := live(bson.M{
 		"$or": []bson.M{
 			{"teamA": teamName},
 			{"teamB": teamName},
 		},
-	}
+	})
 
 	cursor, err := collection.Find(ctx, filter)
 	if err != nil {
@@ -218,8 +320,22 @@ func GetTeamStats(teamName string) (*TeamStats, error) {
 		return nil, err
 	}
 
+	return ComputeTeamStats(teamName, matches), nil
+}
+
+// ComputeTeamStats calcula as estatísticas de um time a partir das partidas informadas.
+// Retorna nil se ele não participou de nenhuma delas.
+func ComputeTeamStats(teamName string, matches []MatchResult) *TeamStats {
+	var played []MatchResult
+	for _, match := range matches {
+		if match.TeamA == teamName || match.TeamB == teamName {
+			played = append(played, match)
+		}
+	}
+	matches = played
+
 	if len(matches) == 0 {
-		return nil, nil
+		return nil
 	}
 
 	// Calcular estatísticas
@@ -274,40 +390,463 @@ func GetTeamStats(teamName string) (*TeamStats, error) {
 		stats.MostPlayedChampions = stats.MostPlayedChampions[:5]
 	}
 
-	return stats, nil
+	return stats
+}
+
+// GetTeamNames lista, em ordem alfabética, os times das partidas que atendem ao filtro
+func GetTeamNames(filter bson.M) ([]string, error) {
+	collection := database.GetCollection("match_results")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	seen := make(map[string]bool)
+	for _, field := range []string{"teamA", "teamB"} {
+		values, err := collection.Distinct(ctx, field, live(filter))
+		if err != nil {
+			return nil, err
+		}
+		for _, value := range values {
+			if name, ok := value.(string); ok && name != "" {
+				seen[name] = true
+			}
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names, nil
 }
 
-// CreateMatchResult insere um novo resultado de partida
-func CreateMatchResult(result *MatchResult) error {
+// CreateMatchResult insere um novo resultado de partida e registra o evento no outbox
+// e a revisão no histórico. Retorna ErrMatchExists se o matchId já estiver em uso,
+// inclusive por uma partida na lixeira.
+func CreateMatchResult(result *MatchResult, author Author) error {
 	collection := database.GetCollection("match_results")
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	_, err := collection.InsertOne(ctx, result)
-	return err
+	result.Version = 1
+	return database.WithTransaction(ctx, func(ctx context.Context) error {
+		if _, err := collection.InsertOne(ctx, result); err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return ErrMatchExists
+			}
+			return err
+		}
+		if err := appendRevision(ctx, ActionCreate, nil, result, author, nil); err != nil {
+			return err
+		}
+		return AppendOutboxEvent(ctx, NewMatchEvent(events.MatchCreated, result))
+	})
 }
 
-// UpdateMatchResult atualiza um resultado existente
-func UpdateMatchResult(matchID string, result *MatchResult) error {
+// UpsertMatchResults insere ou atualiza um lote de partidas pelo matchId, preservando o
+// _id e o createdAt das existentes, e registra no outbox um evento e no histórico uma
+// revisão para cada partida alterada. Partidas que estavam na lixeira são restauradas;
+// as que não mudaram não são gravadas nem contadas.
+func UpsertMatchResults(results []*MatchResult, author Author) (inserted int, updated int, err error) {
+	return upsertMatchResults(results, author, true)
+}
+
+// SyncScrapedMatchResults grava as partidas extraídas pelo scraper como
+// UpsertMatchResults, mas não restaura as que estão na lixeira nem sobrescreve as que
+// foram alteradas por último pelos endpoints administrativos
+func SyncScrapedMatchResults(results []*MatchResult) (inserted int, updated int, err error) {
+	return upsertMatchResults(results, ScraperAuthor, false)
+}
+
+// upsertMatchResults grava o lote em uma transação. Com imported, as partidas da
+// lixeira são restauradas e as edições administrativas sobrescritas.
+func upsertMatchResults(results []*MatchResult, author Author, imported bool) (inserted int, updated int, err error) {
+	if len(results) == 0 {
+		return 0, 0, nil
+	}
+
+	collection := database.GetCollection("match_results")
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	matchIDs := make([]string, len(results))
+	for i, result := range results {
+		matchIDs[i] = result.MatchID
+	}
+
+	err = database.WithTransaction(ctx, func(ctx context.Context) error {
+		inserted, updated = 0, 0
+
+		// Estado anterior das partidas, inclusive as da lixeira, para decidir o que
+		// gravar e para o diff das revisões
+		cursor, err := collection.Find(ctx, bson.M{"matchId": bson.M{"$in": matchIDs}})
+		if err != nil {
+			return err
+		}
+		var existing []MatchResult
+		if err := cursor.All(ctx, &existing); err != nil {
+			return err
+		}
+		previous := make(map[string]*MatchResult, len(existing))
+		for i := range existing {
+			previous[existing[i].MatchID] = &existing[i]
+		}
+
+		type change struct {
+			before, result *MatchResult
+		}
+		var writes []mongo.WriteModel
+		var changes []change
+		for _, result := range results {
+			before := previous[result.MatchID]
+			if before == nil {
+				result.ID = primitive.NewObjectID()
+				result.Version = 1
+				writes = append(writes, mongo.NewInsertOneModel().SetDocument(result))
+				changes = append(changes, change{nil, result})
+				continue
+			}
+
+			// O scraper não traz de volta partidas excluídas
+			if before.DeletedAt != nil && !imported {
+				continue
+			}
+
+			diff, err := Diff(before, result)
+			if err != nil {
+				return err
+			}
+			if len(diff) == 0 {
+				continue
+			}
+
+			// Nem desfaz correções feitas pelos administradores
+			if !imported {
+				source, err := lastRevisionSource(ctx, result.MatchID)
+				if err != nil {
+					return err
+				}
+				if source == SourceAdmin {
+					continue
+				}
+			}
+
+			result.ID = before.ID
+			result.CreatedAt = before.CreatedAt
+			result.Version = before.Version + 1
+
+			doc, err := toDocument(result)
+			if err != nil {
+				return err
+			}
+			delete(doc, "_id")
+			delete(doc, "createdAt")
+			delete(doc, "version")
+
+			update := bson.M{"$set": doc, "$inc": bson.M{"version": 1}}
+			if before.DeletedAt != nil {
+				// Importar uma partida que estava na lixeira a restaura
+				update["$unset"] = bson.M{"deletedAt": "", "deletedBy": ""}
+			}
+			writes = append(writes, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": before.ID}).
+				SetUpdate(update))
+			changes = append(changes, change{before, result})
+		}
+
+		if len(writes) == 0 {
+			return nil
+		}
+		if _, err := collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(true)); err != nil {
+			return err
+		}
+
+		for _, c := range changes {
+			eventType, action := events.MatchUpdated, ActionUpdate
+			if c.before == nil {
+				eventType, action = events.MatchCreated, ActionCreate
+				inserted++
+			} else {
+				updated++
+			}
+
+			if err := appendRevision(ctx, action, c.before, c.result, author, nil); err != nil {
+				return err
+			}
+			if err := AppendOutboxEvent(ctx, NewMatchUpdateEvent(eventType, c.before, c.result)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return inserted, updated, nil
+}
+
+// toDocument converte uma struct em documento BSON editável
+func toDocument(v interface{}) (bson.M, error) {
+	data, err := bson.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc bson.M
+	if err := bson.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// ReplaceMatchResult grava next no lugar de current, desde que este ainda esteja na
+// mesma versão no banco, e registra o evento no outbox e a revisão no histórico.
+// O _id, o createdAt e o matchId devem vir de current; a versão gravada é a
+// seguinte à dele. Retorna mongo.ErrNoDocuments se o resultado não existir mais e
+// ErrVersionConflict se ele tiver sido alterado nesse meio tempo.
+func ReplaceMatchResult(current, next *MatchResult, author Author) error {
+	return replaceMatchResult(current, next, author, ActionUpdate, nil)
+}
+
+// RevertMatchResult restaura o estado da partida registrado na revisão, nas mesmas
+// condições de ReplaceMatchResult
+func RevertMatchResult(current *MatchResult, revision *Revision, author Author) (*MatchResult, error) {
+	next := *revision.Snapshot
+	next.ID = current.ID
+	next.CreatedAt = current.CreatedAt
+	next.UpdatedAt = time.Now()
+
+	if err := replaceMatchResult(current, &next, author, ActionRevert, &revision.ID); err != nil {
+		return nil, err
+	}
+	return &next, nil
+}
+
+func replaceMatchResult(current, next *MatchResult, author Author, action string, revertedFrom *primitive.ObjectID) error {
+	collection := database.GetCollection("match_results")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := live(bson.M{"matchId": current.MatchID, "version": current.Version})
+	if current.Version == 0 {
+		// Documentos gravados antes do versionamento não possuem o campo
+		filter["version"] = bson.M{"$in": bson.A{0, nil}}
+	}
+	next.Version = current.Version + 1
+	// A exclusão só acontece por DeleteMatchResult
+	next.DeletedAt, next.DeletedBy = nil, nil
+
+	return database.WithTransaction(ctx, func(ctx context.Context) error {
+		res, err := collection.ReplaceOne(ctx, filter, next)
+		if err != nil {
+			return err
+		}
+		if res.MatchedCount == 0 {
+			count, err := collection.CountDocuments(ctx, live(bson.M{"matchId": current.MatchID}))
+			if err != nil {
+				return err
+			}
+			if count == 0 {
+				return mongo.ErrNoDocuments
+			}
+			return ErrVersionConflict
+		}
+
+		if err := appendRevision(ctx, action, current, next, author, revertedFrom); err != nil {
+			return err
+		}
+		return AppendOutboxEvent(ctx, NewMatchUpdateEvent(events.MatchUpdated, current, next))
+	})
+}
+
+// DeleteMatchResult move o resultado para a lixeira, registrando quando e por quem,
+// e grava o evento no outbox e a revisão no histórico. Retorna mongo.ErrNoDocuments
+// se não houver partida ativa com o matchId.
+func DeleteMatchResult(matchID string, author Author) error {
 	collection := database.GetCollection("match_results")
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	filter := bson.M{"matchId": matchID}
-	update := bson.M{"$set": result}
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{"deletedAt": now, "deletedBy": author, "updatedAt": now},
+		"$inc": bson.M{"version": 1},
+	}
+	findOptions := options.FindOneAndUpdate().SetReturnDocument(options.After)
 
-	_, err := collection.UpdateOne(ctx, filter, update)
-	return err
+	return database.WithTransaction(ctx, func(ctx context.Context) error {
+		var deleted MatchResult
+		err := collection.FindOneAndUpdate(ctx, live(bson.M{"matchId": matchID}), update, findOptions).Decode(&deleted)
+		if err != nil {
+			return err
+		}
+		if err := appendRevision(ctx, ActionDelete, nil, &deleted, author, nil); err != nil {
+			return err
+		}
+		return AppendOutboxEvent(ctx, NewMatchEvent(events.MatchDeleted, &deleted))
+	})
 }
 
-// DeleteMatchResult exclui um resultado
-func DeleteMatchResult(matchID string) error {
+// RestoreMatchResult tira o resultado da lixeira e registra o evento no outbox e a
+// revisão no histórico. Retorna mongo.ErrNoDocuments se ele não estiver na lixeira
+// e ErrMatchExists se outra partida ativa já usar o mesmo matchId.
+func RestoreMatchResult(matchID string, author Author) (*MatchResult, error) {
 	collection := database.GetCollection("match_results")
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	filter := bson.M{"matchId": matchID}
+	update := bson.M{
+		"$set":   bson.M{"updatedAt": time.Now()},
+		"$unset": bson.M{"deletedAt": "", "deletedBy": ""},
+		"$inc":   bson.M{"version": 1},
+	}
+	findOptions := options.FindOneAndUpdate().
+		SetReturnDocument(options.After).
+		SetSort(bson.M{"deletedAt": -1})
+
+	var restored MatchResult
+	err := database.WithTransaction(ctx, func(ctx context.Context) error {
+		count, err := collection.CountDocuments(ctx, live(bson.M{"matchId": matchID}))
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrMatchExists
+		}
+
+		filter := bson.M{"matchId": matchID, "deletedAt": bson.M{"$ne": nil}}
+		if err := collection.FindOneAndUpdate(ctx, filter, update, findOptions).Decode(&restored); err != nil {
+			return err
+		}
+		if err := appendRevision(ctx, ActionRestore, nil, &restored, author, nil); err != nil {
+			return err
+		}
+		// Para os consumidores, a partida restaurada volta a existir
+		return AppendOutboxEvent(ctx, NewMatchEvent(events.MatchCreated, &restored))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &restored, nil
+}
+
+// GetDeletedMatchResults lista as partidas na lixeira, das excluídas mais recentemente
+// para as mais antigas, com o total
+func GetDeletedMatchResults(skip, limit int64) ([]MatchResult, int64, error) {
+	collection := database.GetCollection("match_results")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"deletedAt": bson.M{"$ne": nil}}
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().SetSort(bson.M{"deletedAt": -1}).SetSkip(skip).SetLimit(limit)
+	cursor, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	results := []MatchResult{}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, 0, err
+	}
+
+	return results, total, nil
+}
+
+// PurgeDeletedMatchResults exclui definitivamente as partidas que foram para a lixeira
+// até o instante informado. O histórico delas é mantido.
+func PurgeDeletedMatchResults(deletedBefore time.Time) (int64, error) {
+	collection := database.GetCollection("match_results")
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	res, err := collection.DeleteMany(ctx, bson.M{"deletedAt": bson.M{"$lte": deletedBefore}})
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
+
+// DedupeMatchResults resolve os matchIds repetidos em dados anteriores ao índice único:
+// mantém a partida ativa alterada mais recentemente (ou a mais recente da lixeira, se
+// todas estiverem nela) e move as demais para a lixeira com o matchId seguido de "~" e
+// o _id, para que continuem recuperáveis. Retorna quantas partidas foram movidas.
+func DedupeMatchResults() (int, error) {
+	collection := database.GetCollection("match_results")
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	findOptions := options.Find().SetProjection(bson.M{"matchId": 1, "updatedAt": 1, "deletedAt": 1})
+	cursor, err := collection.Find(ctx, bson.M{}, findOptions)
+	if err != nil {
+		return 0, err
+	}
+	var all []MatchResult
+	if err := cursor.All(ctx, &all); err != nil {
+		return 0, err
+	}
+
+	groups := make(map[string][]MatchResult)
+	for _, match := range all {
+		groups[match.MatchID] = append(groups[match.MatchID], match)
+	}
 
-	_, err := collection.DeleteOne(ctx, filter)
-	return err
+	moved := 0
+	for matchID, group := range groups {
+		if len(group) < 2 {
+			continue
+		}
+		sort.Slice(group, func(i, j int) bool {
+			a, b := group[i], group[j]
+			if (a.DeletedAt == nil) != (b.DeletedAt == nil) {
+				return a.DeletedAt == nil
+			}
+			if !a.UpdatedAt.Equal(b.UpdatedAt) {
+				return a.UpdatedAt.After(b.UpdatedAt)
+			}
+			return a.ID.Hex() > b.ID.Hex()
+		})
+
+		for _, duplicate := range group[1:] {
+			if err := moveDuplicate(ctx, duplicate); err != nil {
+				return moved, fmt.Errorf("erro ao mover a partida %s (%s) repetida: %w", matchID, duplicate.ID.Hex(), err)
+			}
+			moved++
+		}
+	}
+	return moved, nil
+}
+
+// moveDuplicate renomeia a partida repetida e a coloca na lixeira, registrando a
+// revisão. Não gera evento: para os clientes a partida com o matchId original continua
+// existindo.
+func moveDuplicate(ctx context.Context, duplicate MatchResult) error {
+	collection := database.GetCollection("match_results")
+
+	now := time.Now()
+	set := bson.M{
+		"matchId":   duplicate.MatchID + "~" + duplicate.ID.Hex(),
+		"updatedAt": now,
+	}
+	if duplicate.DeletedAt == nil {
+		set["deletedAt"] = now
+		set["deletedBy"] = MigrationAuthor
+	}
+	update := bson.M{"$set": set, "$inc": bson.M{"version": 1}}
+	findOptions := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	return database.WithTransaction(ctx, func(ctx context.Context) error {
+		var moved MatchResult
+		if err := collection.FindOneAndUpdate(ctx, bson.M{"_id": duplicate.ID}, update, findOptions).Decode(&moved); err != nil {
+			return err
+		}
+		return appendRevision(ctx, ActionDelete, nil, &moved, MigrationAuthor, nil)
+	})
 }
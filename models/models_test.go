@@ -0,0 +1,241 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/config"
+	"github.com/bulletdev/lta-results-api/database"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var testAuthor = Author{Actor: "ana", Source: SourceAdmin}
+
+// connect abre um armazenamento embutido temporário, ainda sem os índices
+func connect(t *testing.T) {
+	t.Helper()
+
+	cfg := config.Defaults().Database
+	cfg.Backend = config.BackendEmbedded
+	cfg.Path = filepath.Join(t.TempDir(), "models.db")
+	if err := database.Connect(cfg); err != nil {
+		t.Fatalf("erro ao abrir o armazenamento: %v", err)
+	}
+	t.Cleanup(database.Close)
+}
+
+func openStore(t *testing.T) {
+	t.Helper()
+
+	connect(t)
+	if err := EnsureIndexes(); err != nil {
+		t.Fatalf("erro ao criar os índices: %v", err)
+	}
+}
+
+// createMatch grava uma partida válida com o matchId informado
+func createMatch(t *testing.T, matchID string) *MatchResult {
+	t.Helper()
+
+	now := time.Now()
+	match := &MatchResult{
+		MatchID:   matchID,
+		Date:      time.Date(2025, 4, 12, 18, 0, 0, 0, time.UTC),
+		Region:    "sul",
+		TeamA:     "LOUD",
+		TeamB:     "paiN Gaming",
+		ScoreA:    2,
+		ScoreB:    1,
+		Winner:    "LOUD",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := CreateMatchResult(match, testAuthor); err != nil {
+		t.Fatalf("erro ao criar a partida: %v", err)
+	}
+	return match
+}
+
+func getMatch(t *testing.T, matchID string) *MatchResult {
+	t.Helper()
+
+	match, err := GetMatchResultByID(matchID)
+	if err != nil {
+		t.Fatalf("erro ao buscar a partida %s: %v", matchID, err)
+	}
+	return match
+}
+
+func TestReplaceMatchResultChecksVersion(t *testing.T) {
+	openStore(t)
+	createMatch(t, "sul-1")
+
+	current := getMatch(t, "sul-1")
+	if current.Version != 1 {
+		t.Fatalf("versão inicial = %d, esperado 1", current.Version)
+	}
+
+	next := *current
+	next.Duration = "32:10"
+	if err := ReplaceMatchResult(current, &next, testAuthor); err != nil {
+		t.Fatalf("erro ao atualizar: %v", err)
+	}
+	if saved := getMatch(t, "sul-1"); saved.Version != 2 || saved.Duration != "32:10" {
+		t.Errorf("partida gravada na versão %d com duração %q", saved.Version, saved.Duration)
+	}
+
+	// Uma segunda escrita a partir da versão já substituída é rejeitada
+	stale := *current
+	stale.Duration = "40:00"
+	if err := ReplaceMatchResult(current, &stale, testAuthor); !errors.Is(err, ErrVersionConflict) {
+		t.Errorf("erro com versão desatualizada = %v, esperado ErrVersionConflict", err)
+	}
+	if saved := getMatch(t, "sul-1"); saved.Duration != "32:10" {
+		t.Errorf("escrita desatualizada gravada: duração %q", saved.Duration)
+	}
+
+	// A partida na lixeira não pode mais ser atualizada
+	latest := getMatch(t, "sul-1")
+	if err := DeleteMatchResult("sul-1", testAuthor); err != nil {
+		t.Fatalf("erro ao excluir: %v", err)
+	}
+	if err := ReplaceMatchResult(latest, &next, testAuthor); !errors.Is(err, mongo.ErrNoDocuments) {
+		t.Errorf("erro ao atualizar partida excluída = %v, esperado ErrNoDocuments", err)
+	}
+}
+
+func TestDeleteAndRestoreMatchResult(t *testing.T) {
+	openStore(t)
+	createMatch(t, "sul-1")
+
+	if err := DeleteMatchResult("sul-1", testAuthor); err != nil {
+		t.Fatalf("erro ao excluir: %v", err)
+	}
+	if _, err := GetMatchResultByID("sul-1"); !errors.Is(err, mongo.ErrNoDocuments) {
+		t.Errorf("partida excluída ainda visível: %v", err)
+	}
+	if err := DeleteMatchResult("sul-1", testAuthor); !errors.Is(err, mongo.ErrNoDocuments) {
+		t.Errorf("segunda exclusão = %v, esperado ErrNoDocuments", err)
+	}
+
+	deleted, total, err := GetDeletedMatchResults(0, 10)
+	if err != nil {
+		t.Fatalf("erro ao listar a lixeira: %v", err)
+	}
+	if total != 1 || deleted[0].DeletedBy == nil || *deleted[0].DeletedBy != testAuthor {
+		t.Fatalf("lixeira = %d partidas, %+v", total, deleted)
+	}
+
+	// O matchId continua reservado pela partida na lixeira
+	now := time.Now()
+	again := &MatchResult{MatchID: "sul-1", CreatedAt: now, UpdatedAt: now}
+	if err := CreateMatchResult(again, testAuthor); !errors.Is(err, ErrMatchExists) {
+		t.Errorf("criação com matchId na lixeira = %v, esperado ErrMatchExists", err)
+	}
+
+	restored, err := RestoreMatchResult("sul-1", testAuthor)
+	if err != nil {
+		t.Fatalf("erro ao restaurar: %v", err)
+	}
+	if restored.DeletedAt != nil || restored.DeletedBy != nil || restored.Version != 3 {
+		t.Errorf("partida restaurada = versão %d, deletedAt %v", restored.Version, restored.DeletedAt)
+	}
+	if live := getMatch(t, "sul-1"); live.Version != 3 {
+		t.Errorf("versão após restaurar = %d, esperado 3", live.Version)
+	}
+	if _, err := RestoreMatchResult("sul-1", testAuthor); !errors.Is(err, ErrMatchExists) {
+		t.Errorf("restauração de partida ativa = %v, esperado ErrMatchExists", err)
+	}
+}
+
+func TestPurgeDeletedMatchResults(t *testing.T) {
+	openStore(t)
+	createMatch(t, "sul-1")
+	createMatch(t, "sul-2")
+
+	if err := DeleteMatchResult("sul-1", testAuthor); err != nil {
+		t.Fatalf("erro ao excluir: %v", err)
+	}
+
+	// Apenas as partidas excluídas até o instante informado são removidas
+	if purged, err := PurgeDeletedMatchResults(time.Now().Add(-time.Hour)); err != nil || purged != 0 {
+		t.Errorf("exclusão antes do prazo = %d, %v", purged, err)
+	}
+	if purged, err := PurgeDeletedMatchResults(time.Now()); err != nil || purged != 1 {
+		t.Errorf("exclusão definitiva = %d, %v; esperado 1", purged, err)
+	}
+	if _, err := RestoreMatchResult("sul-1", testAuthor); !errors.Is(err, mongo.ErrNoDocuments) {
+		t.Errorf("partida excluída definitivamente restaurada: %v", err)
+	}
+	getMatch(t, "sul-2")
+
+	// O histórico é mantido
+	revisions, err := GetMatchRevisions("sul-1", 10)
+	if err != nil || len(revisions) != 2 {
+		t.Errorf("histórico após a exclusão definitiva = %d revisões, %v", len(revisions), err)
+	}
+}
+
+func TestEnsureIndexesDedupesMatchResults(t *testing.T) {
+	connect(t)
+	collection := database.GetCollection("match_results")
+	ctx := context.Background()
+
+	// Partidas gravadas antes do índice único, com o matchId repetido
+	base := time.Date(2025, 4, 12, 18, 0, 0, 0, time.UTC)
+	older := MatchResult{ID: primitive.NewObjectID(), MatchID: "sul-1", Version: 1, UpdatedAt: base}
+	newer := MatchResult{ID: primitive.NewObjectID(), MatchID: "sul-1", Version: 1, UpdatedAt: base.Add(time.Hour)}
+	trashed := MatchResult{ID: primitive.NewObjectID(), MatchID: "sul-1", Version: 2, UpdatedAt: base.Add(2 * time.Hour)}
+	trashed.DeletedAt = &trashed.UpdatedAt
+	for _, match := range []MatchResult{older, newer, trashed} {
+		if _, err := collection.InsertOne(ctx, match); err != nil {
+			t.Fatalf("erro ao inserir: %v", err)
+		}
+	}
+
+	if err := EnsureIndexes(); err != nil {
+		t.Fatalf("erro ao criar os índices: %v", err)
+	}
+
+	// A partida ativa alterada mais recentemente mantém o matchId
+	if live := getMatch(t, "sul-1"); live.ID != newer.ID {
+		t.Errorf("partida mantida = %s, esperado %s", live.ID.Hex(), newer.ID.Hex())
+	}
+
+	for _, duplicate := range []MatchResult{older, trashed} {
+		var moved MatchResult
+		if err := collection.FindOne(ctx, bson.M{"_id": duplicate.ID}).Decode(&moved); err != nil {
+			t.Fatalf("partida repetida %s perdida: %v", duplicate.ID.Hex(), err)
+		}
+		if want := "sul-1~" + duplicate.ID.Hex(); moved.MatchID != want {
+			t.Errorf("matchId da repetida = %s, esperado %s", moved.MatchID, want)
+		}
+		if moved.DeletedAt == nil || moved.Version != duplicate.Version+1 {
+			t.Errorf("repetida fora da lixeira ou na versão %d", moved.Version)
+		}
+	}
+
+	// Apenas a partida que estava ativa é atribuída à migração
+	deleted, _, err := GetDeletedMatchResults(0, 10)
+	if err != nil {
+		t.Fatalf("erro ao listar a lixeira: %v", err)
+	}
+	for _, match := range deleted {
+		migrated := match.DeletedBy != nil && *match.DeletedBy == MigrationAuthor
+		if migrated != strings.HasSuffix(match.MatchID, older.ID.Hex()) {
+			t.Errorf("%s excluída por %+v", match.MatchID, match.DeletedBy)
+		}
+	}
+
+	// O índice único passa a valer
+	duplicate := MatchResult{ID: primitive.NewObjectID(), MatchID: "sul-1"}
+	if _, err := collection.InsertOne(ctx, duplicate); !mongo.IsDuplicateKeyError(err) {
+		t.Errorf("matchId repetido aceito após o índice: %v", err)
+	}
+}
@@ -0,0 +1,203 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/database"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// OutboxEvent representa um evento de domínio gravado junto com a alteração que o originou.
+// Seq é o número sequencial do evento, usado como ID nos streams e nos webhooks; ele é
+// persistido, então continua válido após um restart.
+type OutboxEvent struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Seq         uint64             `bson:"seq" json:"seq"`
+	Type        string             `bson:"type" json:"type"`
+	MatchID     string             `bson:"matchId,omitempty" json:"matchId,omitempty"`
+	Region      string             `bson:"region,omitempty" json:"region,omitempty"`
+	Teams       []string           `bson:"teams,omitempty" json:"teams,omitempty"`
+	Players     []string           `bson:"players,omitempty" json:"players,omitempty"`
+	Match       *MatchResult       `bson:"match,omitempty" json:"match,omitempty"`
+	Details     map[string]string  `bson:"details,omitempty" json:"details,omitempty"`
+	DeliveredTo []string           `bson:"deliveredTo,omitempty" json:"deliveredTo,omitempty"`
+	Published   bool               `bson:"published" json:"published"`
+	PublishedAt *time.Time         `bson:"publishedAt,omitempty" json:"publishedAt,omitempty"`
+	CreatedAt   time.Time          `bson:"createdAt" json:"createdAt"`
+}
+
+// outboxSignal avisa o dispatcher de que há eventos novos, evitando esperar o próximo ciclo
+var outboxSignal = make(chan struct{}, 1)
+
+// OutboxSignal retorna o canal sinalizado a cada evento gravado no outbox
+func OutboxSignal() <-chan struct{} {
+	return outboxSignal
+}
+
+// NewMatchEvent cria um evento de outbox referente a uma partida
+func NewMatchEvent(eventType string, result *MatchResult) *OutboxEvent {
+	return NewMatchUpdateEvent(eventType, nil, result)
+}
+
+// NewMatchUpdateEvent cria o evento de uma alteração de previous para result. Os times e
+// jogadores do evento incluem os de previous, para que quem acompanha um time ou
+// jogador removido da partida também seja avisado.
+func NewMatchUpdateEvent(eventType string, previous, result *MatchResult) *OutboxEvent {
+	var teams, players []string
+	for _, match := range []*MatchResult{previous, result} {
+		if match == nil {
+			continue
+		}
+		teams = appendUnique(teams, match.TeamA, match.TeamB)
+		for _, player := range match.Players {
+			players = appendUnique(players, player.Name)
+		}
+	}
+
+	return &OutboxEvent{
+		Type:    eventType,
+		MatchID: result.MatchID,
+		Region:  result.Region,
+		Teams:   teams,
+		Players: players,
+		Match:   result,
+	}
+}
+
+func appendUnique(list []string, values ...string) []string {
+	for _, value := range values {
+		found := false
+		for _, existing := range list {
+			found = found || existing == value
+		}
+		if !found {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+// AppendOutboxEvent grava um evento no outbox. Deve receber o contexto da operação
+// que originou o evento para fazer parte da mesma transação.
+func AppendOutboxEvent(ctx context.Context, event *OutboxEvent) error {
+	collection := database.GetCollection("outbox")
+
+	seq, err := nextSequence(ctx, "outbox")
+	if err != nil {
+		return err
+	}
+
+	event.ID = primitive.NewObjectID()
+	event.Seq = seq
+	event.Published = false
+	event.CreatedAt = time.Now()
+
+	if _, err := collection.InsertOne(ctx, event); err != nil {
+		return err
+	}
+
+	select {
+	case outboxSignal <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// GetPendingOutboxEvents obtém os eventos ainda não publicados, na ordem em que foram gravados
+func GetPendingOutboxEvents(limit int64) ([]OutboxEvent, error) {
+	collection := database.GetCollection("outbox")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "seq", Value: 1}, {Key: "_id", Value: 1}}).SetLimit(limit)
+	cursor, err := collection.Find(ctx, bson.M{"published": false}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var pending []OutboxEvent
+	if err := cursor.All(ctx, &pending); err != nil {
+		return nil, err
+	}
+
+	return pending, nil
+}
+
+// GetPublishedOutboxEventsAfter obtém até limit eventos já publicados posteriores ao
+// número de sequência informado, para retomar um stream que o histórico em memória
+// não alcança mais
+func GetPublishedOutboxEventsAfter(ctx context.Context, seq uint64, limit int64) ([]OutboxEvent, error) {
+	collection := database.GetCollection("outbox")
+
+	filter := bson.M{"published": true, "seq": bson.M{"$gt": seq}}
+	cursor, err := collection.Find(ctx, filter, options.Find().SetSort(bson.M{"seq": 1}).SetLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var published []OutboxEvent
+	if err := cursor.All(ctx, &published); err != nil {
+		return nil, err
+	}
+
+	return published, nil
+}
+
+// MarkOutboxEventDelivered registra os assinantes que já processaram o evento, para que
+// não o recebam de novo quando ele for reenviado por causa da falha de outro
+func MarkOutboxEventDelivered(id primitive.ObjectID, subscribers []string) error {
+	collection := database.GetCollection("outbox")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"deliveredTo": subscribers}})
+	return err
+}
+
+// MarkOutboxEventPublished marca um evento como publicado
+func MarkOutboxEventPublished(id primitive.ObjectID) error {
+	collection := database.GetCollection("outbox")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	update := bson.M{"$set": bson.M{"published": true, "publishedAt": now}}
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	return err
+}
+
+// PurgePublishedOutboxEvents exclui os eventos publicados até o instante informado. Os
+// pendentes nunca são excluídos.
+func PurgePublishedOutboxEvents(publishedBefore time.Time) (int64, error) {
+	collection := database.GetCollection("outbox")
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	res, err := collection.DeleteMany(ctx, bson.M{"published": true, "publishedAt": bson.M{"$lte": publishedBefore}})
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
+
+// nextSequence incrementa e retorna o contador informado. Dentro de uma transação, o
+// contador também serializa as transações concorrentes, de modo que a ordem dos números
+// é a ordem de confirmação.
+func nextSequence(ctx context.Context, name string) (uint64, error) {
+	collection := database.GetCollection("counters")
+
+	findOptions := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	var counter struct {
+		Value int64 `bson:"value"`
+	}
+	err := collection.FindOneAndUpdate(ctx, bson.M{"name": name}, bson.M{"$inc": bson.M{"value": int64(1)}}, findOptions).Decode(&counter)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(counter.Value), nil
+}
@@ -0,0 +1,299 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/database"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Ações registradas no histórico de uma partida
+const (
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionRestore = "restore"
+	ActionRevert  = "revert"
+)
+
+// Origens das alterações
+const (
+	SourceAdmin     = "admin"
+	SourceImport    = "import"
+	SourceScraper   = "scraper"
+	SourceMigration = "migration"
+)
+
+// Author identifica quem fez uma alteração e por qual caminho
+type Author struct {
+	Actor  string `bson:"actor" json:"actor"`
+	Source string `bson:"source" json:"source"`
+}
+
+// ScraperAuthor é o autor das alterações feitas pelo scraper
+var ScraperAuthor = Author{Actor: "scraper", Source: SourceScraper}
+
+// MigrationAuthor é o autor das correções feitas nos dados ao iniciar a aplicação
+var MigrationAuthor = Author{Actor: "migration", Source: SourceMigration}
+
+// Revision registra uma alteração em uma partida. Snapshot guarda o documento como
+// ficou após a alteração e permite revertê-la.
+type Revision struct {
+	ID           primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	MatchID      string              `bson:"matchId" json:"matchId"`
+	Version      int64               `bson:"version" json:"version"`
+	Action       string              `bson:"action" json:"action"`
+	Author       Author              `bson:"author" json:"author"`
+	Changes      []FieldChange       `bson:"changes,omitempty" json:"changes,omitempty"`
+	RevertedFrom *primitive.ObjectID `bson:"revertedFrom,omitempty" json:"revertedFrom,omitempty"`
+	Snapshot     *MatchResult        `bson:"snapshot,omitempty" json:"snapshot,omitempty"`
+	CreatedAt    time.Time           `bson:"createdAt" json:"createdAt"`
+}
+
+// FieldChange descreve o valor anterior e o novo de um campo. Jogadores são
+// identificados pelo nome, como em players[Wizer].kills.
+type FieldChange struct {
+	Field string      `bson:"field" json:"field"`
+	From  interface{} `bson:"from" json:"from,omitempty"`
+	To    interface{} `bson:"to" json:"to,omitempty"`
+}
+
+// appendRevision grava a revisão da alteração de previous para next. Deve receber o
+// contexto da transação que fez a alteração.
+func appendRevision(ctx context.Context, action string, previous, next *MatchResult, author Author, revertedFrom *primitive.ObjectID) error {
+	revision := &Revision{
+		ID:           primitive.NewObjectID(),
+		Action:       action,
+		Author:       author,
+		RevertedFrom: revertedFrom,
+		CreatedAt:    time.Now(),
+	}
+
+	switch {
+	case next != nil:
+		revision.MatchID = next.MatchID
+		revision.Version = next.Version
+		revision.Snapshot = next
+	case previous != nil:
+		revision.MatchID = previous.MatchID
+		revision.Version = previous.Version
+		revision.Snapshot = previous
+	}
+
+	// Na criação e na exclusão o snapshot já descreve o documento inteiro
+	if previous != nil && next != nil {
+		changes, err := Diff(previous, next)
+		if err != nil {
+			return err
+		}
+		revision.Changes = changes
+	}
+
+	_, err := database.GetCollection("match_revisions").InsertOne(ctx, revision)
+	return err
+}
+
+// lastRevisionSource retorna a origem da alteração mais recente da partida, ou "" se
+// ela não tiver histórico
+func lastRevisionSource(ctx context.Context, matchID string) (string, error) {
+	findOptions := options.FindOne().
+		SetSort(bson.M{"_id": -1}).
+		SetProjection(bson.M{"author": 1})
+
+	var revision Revision
+	err := database.GetCollection("match_revisions").FindOne(ctx, bson.M{"matchId": matchID}, findOptions).Decode(&revision)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return revision.Author.Source, nil
+}
+
+// GetMatchRevisions obtém o histórico de uma partida, da alteração mais recente para a
+// mais antiga, sem os snapshots
+func GetMatchRevisions(matchID string, limit int64) ([]Revision, error) {
+	collection := database.GetCollection("match_revisions")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	findOptions := options.Find().
+		SetSort(bson.M{"_id": -1}).
+		SetLimit(limit).
+		SetProjection(bson.M{"snapshot": 0})
+	cursor, err := collection.Find(ctx, bson.M{"matchId": matchID}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	revisions := []Revision{}
+	if err := cursor.All(ctx, &revisions); err != nil {
+		return nil, err
+	}
+	for i := range revisions {
+		for j := range revisions[i].Changes {
+			change := &revisions[i].Changes[j]
+			change.From = plain(change.From)
+			change.To = plain(change.To)
+		}
+	}
+
+	return revisions, nil
+}
+
+// GetMatchRevision obtém uma revisão da partida, com o snapshot
+func GetMatchRevision(matchID string, id primitive.ObjectID) (*Revision, error) {
+	collection := database.GetCollection("match_revisions")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var revision Revision
+	if err := collection.FindOne(ctx, bson.M{"_id": id, "matchId": matchID}).Decode(&revision); err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// plain converte documentos e arrays BSON decodificados em mapas e listas, que
+// são serializados em JSON como objetos e arrays
+func plain(v interface{}) interface{} {
+	switch value := v.(type) {
+	case primitive.D:
+		m := make(map[string]interface{}, len(value))
+		for _, elem := range value {
+			m[elem.Key] = plain(elem.Value)
+		}
+		return m
+	case primitive.A:
+		list := make([]interface{}, len(value))
+		for i, elem := range value {
+			list[i] = plain(elem)
+		}
+		return list
+	}
+	return v
+}
+
+// Campos que mudam a cada escrita e não fazem parte do diff
+var ignoredDiffFields = map[string]bool{
+	"id":        true,
+	"version":   true,
+	"createdAt": true,
+	"updatedAt": true,
+}
+
+// Diff lista os campos alterados de previous para next, pela representação JSON
+func Diff(previous, next *MatchResult) ([]FieldChange, error) {
+	from, err := jsonObject(previous)
+	if err != nil {
+		return nil, err
+	}
+	to, err := jsonObject(next)
+	if err != nil {
+		return nil, err
+	}
+	for field := range ignoredDiffFields {
+		delete(from, field)
+		delete(to, field)
+	}
+
+	var changes []FieldChange
+	diffValues("", from, to, &changes)
+	return changes, nil
+}
+
+func jsonObject(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+func diffValues(path string, from, to interface{}, changes *[]FieldChange) {
+	if reflect.DeepEqual(from, to) {
+		return
+	}
+
+	fromObject, okFrom := from.(map[string]interface{})
+	toObject, okTo := to.(map[string]interface{})
+	if okFrom && okTo {
+		for _, key := range unionKeys(fromObject, toObject) {
+			diffValues(joinPath(path, key), fromObject[key], toObject[key], changes)
+		}
+		return
+	}
+
+	// Listas de objetos com nome (os jogadores) são comparadas item a item pelo nome
+	fromNamed, okFrom := byName(from)
+	toNamed, okTo := byName(to)
+	if okFrom && okTo {
+		for _, name := range unionKeys(fromNamed, toNamed) {
+			diffValues(fmt.Sprintf("%s[%s]", path, name), fromNamed[name], toNamed[name], changes)
+		}
+		return
+	}
+
+	*changes = append(*changes, FieldChange{Field: path, From: from, To: to})
+}
+
+// byName indexa uma lista de objetos pelo campo name, se todos tiverem um nome único
+func byName(v interface{}) (map[string]interface{}, bool) {
+	list, ok := v.([]interface{})
+	if !ok {
+		return nil, v == nil
+	}
+
+	named := make(map[string]interface{}, len(list))
+	for _, item := range list {
+		object, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		name, ok := object["name"].(string)
+		if !ok || name == "" {
+			return nil, false
+		}
+		if _, dup := named[name]; dup {
+			return nil, false
+		}
+		named[name] = object
+	}
+	return named, true
+}
+
+func unionKeys(a, b map[string]interface{}) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/database"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ScheduledMatch representa uma partida ainda não disputada do calendário
+type ScheduledMatch struct {
+	ID              primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	MatchID         string             `bson:"matchId" json:"matchId"`
+	Date            time.Time          `bson:"date" json:"date"`
+	TeamA           string             `bson:"teamA" json:"teamA"`
+	TeamB           string             `bson:"teamB" json:"teamB"`
+	Region          string             `bson:"region" json:"region"`
+	BestOf          int                `bson:"bestOf" json:"bestOf"`
+	TournamentStage string             `bson:"tournamentStage,omitempty" json:"tournamentStage,omitempty"`
+	CreatedAt       time.Time          `bson:"createdAt" json:"createdAt"`
+}
+
+// GetScheduledMatches obtém as partidas do calendário que atendem ao filtro
+func GetScheduledMatches(filter bson.M) ([]ScheduledMatch, error) {
+	collection := database.GetCollection("schedule")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	findOptions := options.Find().SetSort(bson.M{"date": 1})
+	cursor, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var matches []ScheduledMatch
+	if err := cursor.All(ctx, &matches); err != nil {
+		return nil, err
+	}
+
+	return matches, nil
+}
+
+// UpsertScheduledMatch insere ou substitui uma partida do calendário pelo matchId
+func UpsertScheduledMatch(match *ScheduledMatch) error {
+	collection := database.GetCollection("schedule")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"matchId": match.MatchID}
+	_, err := collection.ReplaceOne(ctx, filter, match, options.Replace().SetUpsert(true))
+	return err
+}
+
+// DeleteScheduledMatch remove uma partida do calendário
+func DeleteScheduledMatch(matchID string) error {
+	collection := database.GetCollection("schedule")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := collection.DeleteOne(ctx, bson.M{"matchId": matchID})
+	return err
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/database"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Categorias das falhas de scraping. São o lastError público do /health; o erro
+// completo fica no log e no evento scrape.failed.
+const (
+	ScrapeErrorExtraction = "extraction" // a página não carregou no navegador
+	ScrapeErrorParse      = "parse"      // o HTML não pôde ser lido
+	ScrapeErrorNoResults  = "no_results" // nenhuma partida válida na página
+	ScrapeErrorStorage    = "storage"    // as partidas não puderam ser gravadas
+)
+
+// ScrapeStatus resume as últimas execuções do scraping de uma região
+type ScrapeStatus struct {
+	Region              string     `bson:"region" json:"region"`
+	LastAttemptAt       *time.Time `bson:"lastAttemptAt,omitempty" json:"lastAttemptAt,omitempty"`
+	LastSuccessAt       *time.Time `bson:"lastSuccessAt,omitempty" json:"lastSuccessAt,omitempty"`
+	LastResults         int        `bson:"lastResults" json:"lastResults"`
+	LastError           string     `bson:"lastError,omitempty" json:"lastError,omitempty"`
+	ConsecutiveFailures int        `bson:"consecutiveFailures" json:"consecutiveFailures"`
+}
+
+// RecordScrapeSuccess registra uma extração bem-sucedida da região e zera as falhas seguidas
+func RecordScrapeSuccess(region string, results int, at time.Time) error {
+	collection := database.GetCollection("scrape_status")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	update := bson.M{
+		"$set": bson.M{
+			"lastAttemptAt":       at,
+			"lastSuccessAt":       at,
+			"lastResults":         results,
+			"consecutiveFailures": 0,
+		},
+		"$unset": bson.M{"lastError": ""},
+	}
+	_, err := collection.UpdateOne(ctx, bson.M{"region": region}, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// RecordScrapeFailure registra uma extração que falhou, com a categoria do erro,
+// preservando o último sucesso
+func RecordScrapeFailure(region, category string, at time.Time) error {
+	collection := database.GetCollection("scrape_status")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	update := bson.M{
+		"$set": bson.M{
+			"lastAttemptAt": at,
+			"lastError":     category,
+		},
+		"$inc": bson.M{"consecutiveFailures": 1},
+	}
+	_, err := collection.UpdateOne(ctx, bson.M{"region": region}, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// GetScrapeStatuses obtém a situação do scraping de todas as regiões já executadas
+func GetScrapeStatuses(ctx context.Context) ([]ScrapeStatus, error) {
+	collection := database.GetCollection("scrape_status")
+
+	cursor, err := collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"region": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var statuses []ScrapeStatus
+	if err := cursor.All(ctx, &statuses); err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
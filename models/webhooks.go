@@ -0,0 +1,191 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/database"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Situações possíveis de uma entrega de webhook
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+)
+
+// Webhook representa um endpoint externo que recebe eventos
+type Webhook struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	URL       string             `bson:"url" json:"url"`
+	Secret    string             `bson:"secret" json:"secret,omitempty"`
+	Events    []string           `bson:"events" json:"events"`
+	Active    bool               `bson:"active" json:"active"`
+	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+// WebhookDelivery representa uma tentativa de entrega de um evento a um webhook. EventID
+// é o número de sequência do evento no outbox, estável entre restarts, que os
+// receptores podem usar para descartar entregas repetidas.
+type WebhookDelivery struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	WebhookID      primitive.ObjectID `bson:"webhookId" json:"webhookId"`
+	URL            string             `bson:"url" json:"url"`
+	EventID        uint64             `bson:"eventId" json:"eventId"`
+	EventType      string             `bson:"eventType" json:"eventType"`
+	Payload        string             `bson:"payload" json:"payload"`
+	Status         string             `bson:"status" json:"status"`
+	Attempts       int                `bson:"attempts" json:"attempts"`
+	NextAttemptAt  time.Time          `bson:"nextAttemptAt" json:"nextAttemptAt"`
+	LastError      string             `bson:"lastError,omitempty" json:"lastError,omitempty"`
+	ResponseStatus int                `bson:"responseStatus,omitempty" json:"responseStatus,omitempty"`
+	DeliveredAt    *time.Time         `bson:"deliveredAt,omitempty" json:"deliveredAt,omitempty"`
+	CreatedAt      time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt      time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+// CreateWebhook insere um novo webhook
+func CreateWebhook(webhook *Webhook) error {
+	collection := database.GetCollection("webhooks")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := collection.InsertOne(ctx, webhook)
+	return err
+}
+
+// GetWebhooks obtém os webhooks que atendem ao filtro
+func GetWebhooks(filter bson.M) ([]Webhook, error) {
+	collection := database.GetCollection("webhooks")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cursor, err := collection.Find(ctx, filter, options.Find().SetSort(bson.M{"createdAt": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var webhooks []Webhook
+	if err := cursor.All(ctx, &webhooks); err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+// GetWebhookByID obtém um webhook pelo ID
+func GetWebhookByID(id primitive.ObjectID) (*Webhook, error) {
+	collection := database.GetCollection("webhooks")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var webhook Webhook
+	if err := collection.FindOne(ctx, bson.M{"_id": id}).Decode(&webhook); err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// GetWebhooksForEvent obtém os webhooks ativos inscritos em um tipo de evento
+func GetWebhooksForEvent(eventType string) ([]Webhook, error) {
+	return GetWebhooks(bson.M{"active": true, "events": eventType})
+}
+
+// DeleteWebhook exclui um webhook, retornando mongo.ErrNoDocuments se ele não existir
+func DeleteWebhook(id primitive.ObjectID) error {
+	collection := database.GetCollection("webhooks")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// CreateWebhookDelivery enfileira uma entrega. Se o evento já tiver uma entrega para o
+// mesmo webhook, ela é mantida, para que reprocessar um evento não duplique o envio.
+func CreateWebhookDelivery(delivery *WebhookDelivery) error {
+	collection := database.GetCollection("webhook_deliveries")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"webhookId": delivery.WebhookID, "eventId": delivery.EventID}
+	_, err := collection.UpdateOne(ctx, filter, bson.M{"$setOnInsert": delivery}, options.Update().SetUpsert(true))
+	return err
+}
+
+// GetDueWebhookDeliveries obtém as entregas pendentes cuja próxima tentativa já venceu
+func GetDueWebhookDeliveries(now time.Time, limit int64) ([]WebhookDelivery, error) {
+	filter := bson.M{
+		"status":        DeliveryPending,
+		"nextAttemptAt": bson.M{"$lte": now},
+	}
+	findOptions := options.Find().SetSort(bson.M{"nextAttemptAt": 1}).SetLimit(limit)
+	return GetWebhookDeliveries(filter, findOptions)
+}
+
+// GetWebhookDeliveries obtém entregas com base em um filtro
+func GetWebhookDeliveries(filter bson.M, findOptions *options.FindOptions) ([]WebhookDelivery, error) {
+	collection := database.GetCollection("webhook_deliveries")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var deliveries []WebhookDelivery
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// UpdateWebhookDelivery salva o estado de uma entrega
+func UpdateWebhookDelivery(delivery *WebhookDelivery) error {
+	collection := database.GetCollection("webhook_deliveries")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := collection.ReplaceOne(ctx, bson.M{"_id": delivery.ID}, delivery)
+	return err
+}
+
+// RequeueWebhookDelivery devolve uma entrega da fila de mortas para a fila de envio
+func RequeueWebhookDelivery(id primitive.ObjectID) error {
+	collection := database.GetCollection("webhook_deliveries")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	update := bson.M{"$set": bson.M{
+		"status":        DeliveryPending,
+		"attempts":      0,
+		"nextAttemptAt": now,
+		"updatedAt":     now,
+	}}
+
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": id, "status": DeliveryDead}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package mvp
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/bulletdev/lta-results-api/models"
+)
+
+// Scorer calcula a nota de desempenho de um jogador em uma partida
+type Scorer interface {
+	Score(match *models.MatchResult, player models.Player) float64
+}
+
+// Weights define o peso de cada componente do WeightedScorer
+type Weights struct {
+	KDA         float64
+	DamageShare float64
+	GoldShare   float64
+	Vision      float64
+	Win         float64
+}
+
+// WeightedScorer combina KDA, participação no dano, no ouro, visão e vitória
+type WeightedScorer struct {
+	Weights Weights
+}
+
+// DefaultWeights são os pesos usados pelo scorer padrão
+var DefaultWeights = Weights{
+	KDA:         1.0,
+	DamageShare: 4.0,
+	GoldShare:   2.0,
+	Vision:      1.0,
+	Win:         2.0,
+}
+
+var (
+	mu     sync.RWMutex
+	scorer Scorer = WeightedScorer{Weights: DefaultWeights}
+)
+
+// SetScorer substitui o algoritmo usado para escolher o MVP
+func SetScorer(s Scorer) {
+	mu.Lock()
+	defer mu.Unlock()
+	scorer = s
+}
+
+// Current retorna o algoritmo de MVP em uso
+func Current() Scorer {
+	mu.RLock()
+	defer mu.RUnlock()
+	return scorer
+}
+
+// Score implementa Scorer
+func (w WeightedScorer) Score(match *models.MatchResult, player models.Player) float64 {
+	var teamDamage, teamGold, teamVision int
+	for _, p := range match.Players {
+		if strings.EqualFold(p.Team, player.Team) {
+			teamDamage += p.DamageDealt
+			teamGold += p.Gold
+			teamVision += p.VisionScore
+		}
+	}
+
+	kda := float64(player.Kills+player.Assists) / math.Max(1, float64(player.Deaths))
+
+	score := w.Weights.KDA * kda
+	score += w.Weights.DamageShare * share(player.DamageDealt, teamDamage)
+	score += w.Weights.GoldShare * share(player.Gold, teamGold)
+	score += w.Weights.Vision * share(player.VisionScore, teamVision)
+	if match.Winner != "" && strings.EqualFold(match.Winner, player.Team) {
+		score += w.Weights.Win
+	}
+
+	return score
+}
+
+// Select retorna o jogador com a maior nota na partida e a nota obtida
+func Select(match *models.MatchResult) (string, float64) {
+	s := Current()
+
+	best, bestScore := "", math.Inf(-1)
+	for _, player := range match.Players {
+		if player.Name == "" {
+			continue
+		}
+		if score := s.Score(match, player); score > bestScore {
+			best, bestScore = player.Name, score
+		}
+	}
+
+	if best == "" {
+		return "", 0
+	}
+	return best, bestScore
+}
+
+// Assign preenche o MVP da partida quando a fonte não o informou
+func Assign(match *models.MatchResult) {
+	if match.MVP != "" {
+		return
+	}
+	match.MVP, _ = Select(match)
+}
+
+// Entry representa a posição de um jogador no ranking de MVPs
+type Entry struct {
+	Player       string  `json:"player"`
+	Team         string  `json:"team"`
+	MVPs         int     `json:"mvps"`
+	Games        int     `json:"games"`
+	MVPRate      float64 `json:"mvpRate"`
+	AverageScore float64 `json:"averageScore"`
+}
+
+// Ranking conta os MVPs de cada jogador e ordena pela quantidade e pela nota média
+func Ranking(matches []models.MatchResult) []Entry {
+	s := Current()
+	entries := make(map[string]*Entry)
+	scores := make(map[string]float64)
+
+	for i := range matches {
+		match := &matches[i]
+
+		winner := match.MVP
+		if winner == "" {
+			winner, _ = Select(match)
+		}
+
+		for _, player := range match.Players {
+			if player.Name == "" {
+				continue
+			}
+			entry, ok := entries[player.Name]
+			if !ok {
+				entry = &Entry{Player: player.Name}
+				entries[player.Name] = entry
+			}
+			entry.Team = player.Team
+			entry.Games++
+			scores[player.Name] += s.Score(match, player)
+			if player.Name == winner {
+				entry.MVPs++
+			}
+		}
+	}
+
+	ranking := make([]Entry, 0, len(entries))
+	for name, entry := range entries {
+		entry.MVPRate = round(float64(entry.MVPs) / float64(entry.Games))
+		entry.AverageScore = round(scores[name] / float64(entry.Games))
+		ranking = append(ranking, *entry)
+	}
+
+	sort.Slice(ranking, func(i, j int) bool {
+		if ranking[i].MVPs != ranking[j].MVPs {
+			return ranking[i].MVPs > ranking[j].MVPs
+		}
+		if ranking[i].AverageScore != ranking[j].AverageScore {
+			return ranking[i].AverageScore > ranking[j].AverageScore
+		}
+		return ranking[i].Player < ranking[j].Player
+	})
+
+	return ranking
+}
+
+func share(value, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(value) / float64(total)
+}
+
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,125 @@
+package mvp
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bulletdev/lta-results-api/models"
+)
+
+// match tem dois jogadores por time para que as participações fiquem evidentes
+func match() *models.MatchResult {
+	return &models.MatchResult{
+		TeamA:  "LOUD",
+		TeamB:  "RED",
+		Winner: "LOUD",
+		Players: []models.Player{
+			{Name: "Robo", Team: "LOUD", Kills: 6, Deaths: 2, Assists: 4, DamageDealt: 30000, Gold: 15000, VisionScore: 20},
+			{Name: "Croc", Team: "loud", Kills: 2, Deaths: 0, Assists: 10, DamageDealt: 10000, Gold: 5000, VisionScore: 60},
+			{Name: "Guigo", Team: "RED", Kills: 3, Deaths: 5, Assists: 2, DamageDealt: 20000, Gold: 12000, VisionScore: 30},
+			{Name: "Aegis", Team: "RED", Kills: 0, Deaths: 3, Assists: 1, DamageDealt: 20000, Gold: 8000, VisionScore: 10},
+		},
+	}
+}
+
+func TestWeightedScorer(t *testing.T) {
+	m := match()
+	robo, guigo := m.Players[0], m.Players[2]
+
+	tests := []struct {
+		name    string
+		weights Weights
+		player  models.Player
+		want    float64
+	}{
+		// (6+4)/2
+		{"KDA", Weights{KDA: 1}, robo, 5},
+		// Sem mortes o KDA divide por 1
+		{"KDA sem mortes", Weights{KDA: 1}, m.Players[1], 12},
+		// O time é comparado sem diferenciar maiúsculas: 30000 de 40000
+		{"participação no dano", Weights{DamageShare: 1}, robo, 0.75},
+		{"participação no ouro", Weights{GoldShare: 1}, robo, 0.75},
+		{"participação na visão", Weights{Vision: 1}, robo, 0.25},
+		{"vitória", Weights{Win: 2}, robo, 2},
+		{"derrota", Weights{Win: 2}, guigo, 0},
+		// 1.0*1 + 4*0.5 + 2*0.6 + 1*0.75 sem o bônus de vitória
+		{"pesos padrão na derrota", DefaultWeights, guigo, 1 + 2 + 1.2 + 0.75},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WeightedScorer{Weights: tt.weights}.Score(m, tt.player)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Score = %v, esperado %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectAndAssign(t *testing.T) {
+	// O KDA de Croc supera a participação de Robo no dano e no ouro
+	m := match()
+	if name, _ := Select(m); name != "Croc" {
+		t.Errorf("Select = %s, esperado Croc", name)
+	}
+	if name, score := Select(&models.MatchResult{}); name != "" || score != 0 {
+		t.Errorf("Select sem jogadores = %q, %v", name, score)
+	}
+
+	Assign(m)
+	if m.MVP != "Croc" {
+		t.Errorf("MVP atribuído = %s, esperado Croc", m.MVP)
+	}
+
+	// O MVP informado pela fonte é mantido
+	informed := match()
+	informed.MVP = "Guigo"
+	Assign(informed)
+	if informed.MVP != "Guigo" {
+		t.Errorf("MVP informado substituído por %s", informed.MVP)
+	}
+}
+
+// fixedScorer dá a cada jogador a nota da tabela
+type fixedScorer map[string]float64
+
+func (f fixedScorer) Score(_ *models.MatchResult, player models.Player) float64 {
+	return f[player.Name]
+}
+
+func TestSetScorer(t *testing.T) {
+	t.Cleanup(func() { SetScorer(WeightedScorer{Weights: DefaultWeights}) })
+
+	SetScorer(fixedScorer{"Aegis": 10})
+	if name, score := Select(match()); name != "Aegis" || score != 10 {
+		t.Errorf("Select com outro scorer = %s, %v; esperado Aegis, 10", name, score)
+	}
+}
+
+func TestRanking(t *testing.T) {
+	t.Cleanup(func() { SetScorer(WeightedScorer{Weights: DefaultWeights}) })
+	SetScorer(fixedScorer{"Robo": 3, "Croc": 2, "Guigo": 1})
+
+	players := []models.Player{{Name: "Robo"}, {Name: "Croc"}, {Name: "Guigo"}}
+	matches := []models.MatchResult{
+		{Players: players},
+		{Players: players, MVP: "Croc"},
+		{Players: players[1:], MVP: "Guigo"},
+	}
+
+	// Sem MVP informado, Robo é escolhido pela nota. Com o mesmo número de MVPs, a
+	// maior nota média vem primeiro.
+	ranking := Ranking(matches)
+	want := []Entry{
+		{Player: "Robo", MVPs: 1, Games: 2, MVPRate: 0.5, AverageScore: 3},
+		{Player: "Croc", MVPs: 1, Games: 3, MVPRate: 0.33, AverageScore: 2},
+		{Player: "Guigo", MVPs: 1, Games: 3, MVPRate: 0.33, AverageScore: 1},
+	}
+	if len(ranking) != len(want) {
+		t.Fatalf("ranking = %+v", ranking)
+	}
+	for i := range want {
+		if ranking[i] != want[i] {
+			t.Errorf("posição %d = %+v, esperado %+v", i+1, ranking[i], want[i])
+		}
+	}
+}
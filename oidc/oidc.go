@@ -0,0 +1,234 @@
+// Package oidc valida os tokens JWT emitidos pelo provedor de identidade usado no
+// login do painel administrativo e converte os papéis do token em escopos da API.
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrExpired indica um token com assinatura válida, mas expirado
+var ErrExpired = errors.New("token expirado")
+
+// Algoritmos de assinatura aceitos
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// Config descreve o provedor de identidade
+type Config struct {
+	// Issuer é comparado com a claim iss e, sem JWKSURL nem JWKSFile, usado na descoberta
+	// do JWKS em /.well-known/openid-configuration
+	Issuer string
+	// Audience é comparada com a claim aud e é obrigatória, para que tokens emitidos
+	// pelo mesmo provedor para outras aplicações não sejam aceitos
+	Audience string
+	JWKSURL  string
+	// JWKSFile carrega as chaves de um arquivo local, útil em testes e em ambientes sem
+	// acesso ao provedor
+	JWKSFile string
+	// RolesClaim é o caminho da claim com os papéis, com pontos para claims aninhadas,
+	// como realm_access.roles
+	RolesClaim string
+	// RoleScopes associa cada papel aos escopos que ele concede. Papéis sem associação
+	// não concedem escopos.
+	RoleScopes map[string][]string
+}
+
+// Enabled indica se a autenticação por token foi configurada
+func (c Config) Enabled() bool {
+	return c.Issuer != "" || c.JWKSURL != "" || c.JWKSFile != ""
+}
+
+// ParseRoleScopes lê a associação de papéis a escopos no formato
+// "papel=escopo,escopo;papel=escopo"
+func ParseRoleScopes(raw string) (map[string][]string, error) {
+	mapping := make(map[string][]string)
+	for _, entry := range strings.Split(raw, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		role, scopes, ok := strings.Cut(entry, "=")
+		role = strings.TrimSpace(role)
+		if !ok || role == "" {
+			return nil, fmt.Errorf("associação de papel inválida: %q", entry)
+		}
+		for _, scope := range strings.Split(scopes, ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				mapping[role] = append(mapping[role], scope)
+			}
+		}
+	}
+	return mapping, nil
+}
+
+// Identity é o usuário autenticado por um token
+type Identity struct {
+	Subject string
+	// Name é o identificador legível do usuário: preferred_username, email ou sub
+	Name   string
+	Roles  []string
+	Scopes []string
+}
+
+// Verifier valida tokens com as chaves do provedor
+type Verifier struct {
+	config Config
+	client *http.Client
+
+	mu   sync.Mutex
+	keys *keySet
+}
+
+// NewVerifier cria o validador. O JWKS de um arquivo é lido imediatamente; o de uma URL
+// é buscado no primeiro uso, para a API subir mesmo com o provedor fora do ar.
+func NewVerifier(cfg Config) (*Verifier, error) {
+	if cfg.Audience == "" {
+		return nil, errors.New("informe OIDC_AUDIENCE")
+	}
+	v := &Verifier{config: cfg, client: &http.Client{Timeout: 10 * time.Second}}
+
+	switch {
+	case cfg.JWKSFile != "":
+		keys, err := loadKeySetFile(cfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		v.keys = keys
+	case cfg.JWKSURL != "":
+		v.keys = newRemoteKeySet(cfg.JWKSURL, v.client)
+	case cfg.Issuer == "":
+		return nil, errors.New("informe OIDC_ISSUER, OIDC_JWKS_URL ou OIDC_JWKS_FILE")
+	}
+	return v, nil
+}
+
+// Verify valida a assinatura, a expiração, o emissor e a audiência do token e retorna
+// a identidade com os escopos concedidos pelos papéis
+func (v *Verifier) Verify(ctx context.Context, token string) (*Identity, error) {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(signingMethods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(30 * time.Second),
+		jwt.WithAudience(v.config.Audience),
+	}
+	if v.config.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(v.config.Issuer))
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		keys, err := v.keySet(ctx)
+		if err != nil {
+			return nil, err
+		}
+		kid, _ := t.Header["kid"].(string)
+		return keys.key(ctx, kid)
+	}, opts...)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrExpired
+		}
+		return nil, err
+	}
+
+	identity := &Identity{Roles: stringList(claimAt(claims, v.config.RolesClaim))}
+	identity.Subject, _ = claims["sub"].(string)
+	for _, claim := range []string{"preferred_username", "email", "sub"} {
+		if name, ok := claims[claim].(string); ok && name != "" {
+			identity.Name = name
+			break
+		}
+	}
+	identity.Scopes = v.scopes(identity.Roles)
+	return identity, nil
+}
+
+// keySet retorna as chaves, descobrindo a URL do JWKS pelo emissor no primeiro uso
+func (v *Verifier) keySet(ctx context.Context) (*keySet, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.keys != nil {
+		return v.keys, nil
+	}
+
+	url := v.config.Issuer + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("erro na descoberta OIDC: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("erro na descoberta OIDC: status %d", resp.StatusCode)
+	}
+
+	var discovery struct {
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&discovery); err != nil || discovery.JWKSURI == "" {
+		return nil, fmt.Errorf("descoberta OIDC sem jwks_uri em %s", url)
+	}
+
+	v.keys = newRemoteKeySet(discovery.JWKSURI, v.client)
+	return v.keys, nil
+}
+
+// scopes converte os papéis em escopos, sem repetições. Apenas os papéis associados em
+// RoleScopes concedem escopos, para que um papel do provedor que por acaso tenha o nome
+// de um escopo, como admin, não dê acesso à API.
+func (v *Verifier) scopes(roles []string) []string {
+	seen := make(map[string]bool)
+	var scopes []string
+	for _, role := range roles {
+		for _, scope := range v.config.RoleScopes[role] {
+			if !seen[scope] {
+				seen[scope] = true
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	return scopes
+}
+
+// claimAt segue um caminho com pontos dentro das claims
+func claimAt(claims map[string]interface{}, path string) interface{} {
+	var value interface{} = claims
+	for _, part := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = object[part]
+	}
+	return value
+}
+
+// stringList aceita uma lista de strings ou uma string com valores separados por espaço,
+// como a claim scope
+func stringList(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return strings.Fields(v)
+	case []interface{}:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testIssuer   = "https://login.exemplo.com/realms/lta"
+	testAudience = "lta-admin"
+)
+
+// signer assina tokens com uma das chaves publicadas no JWKS de teste
+type signer struct {
+	kid    string
+	method jwt.SigningMethod
+	key    crypto.Signer
+}
+
+func (s signer) sign(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(s.method, claims)
+	token.Header["kid"] = s.kid
+	signed, err := token.SignedString(s.key)
+	if err != nil {
+		t.Fatalf("erro ao assinar o token: %v", err)
+	}
+	return signed
+}
+
+// newSigners gera uma chave RSA e uma ECDSA e grava as públicas em um JWKS temporário,
+// retornando os assinantes e o caminho do arquivo
+func newSigners(t *testing.T) (rsaSigner, ecSigner signer, jwksFile string) {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("erro ao gerar chave RSA: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("erro ao gerar chave ECDSA: %v", err)
+	}
+
+	encode := func(n *big.Int) string { return base64.RawURLEncoding.EncodeToString(n.Bytes()) }
+	jwks := map[string][]jsonWebKey{"keys": {
+		{Kid: "rsa-1", Kty: "RSA", Use: "sig", N: encode(rsaKey.N), E: encode(big.NewInt(int64(rsaKey.E)))},
+		{Kid: "ec-1", Kty: "EC", Use: "sig", Crv: "P-256", X: encode(ecKey.X), Y: encode(ecKey.Y)},
+	}}
+	data, err := json.Marshal(jwks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwksFile = filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(jwksFile, data, 0o600); err != nil {
+		t.Fatalf("erro ao gravar o JWKS: %v", err)
+	}
+
+	return signer{"rsa-1", jwt.SigningMethodRS256, rsaKey}, signer{"ec-1", jwt.SigningMethodES256, ecKey}, jwksFile
+}
+
+// claims monta as claims de um token válido, que cada caso altera
+func claims(roles ...interface{}) jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss":                testIssuer,
+		"aud":                testAudience,
+		"sub":                "user-1",
+		"preferred_username": "ana",
+		"iat":                now.Unix(),
+		"exp":                now.Add(time.Hour).Unix(),
+		"roles":              roles,
+	}
+}
+
+func newVerifier(t *testing.T, jwksFile, rolesClaim string) *Verifier {
+	t.Helper()
+
+	verifier, err := NewVerifier(Config{
+		Issuer:     testIssuer,
+		Audience:   testAudience,
+		JWKSFile:   jwksFile,
+		RolesClaim: rolesClaim,
+		RoleScopes: map[string][]string{
+			"lta-admin":  {"admin"},
+			"lta-editor": {"read", "write"},
+			"lta-viewer": {"read"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("erro ao criar o verificador: %v", err)
+	}
+	return verifier
+}
+
+func TestVerify(t *testing.T) {
+	rsaSigner, ecSigner, jwksFile := newSigners(t)
+	verifier := newVerifier(t, jwksFile, "roles")
+
+	unknown := rsaSigner
+	unknown.kid = "rsa-2"
+
+	tests := []struct {
+		name    string
+		signer  signer
+		claims  func(jwt.MapClaims)
+		wantErr error
+	}{
+		{name: "RSA válido", signer: rsaSigner},
+		{name: "ECDSA válido", signer: ecSigner},
+		{
+			name:    "expirado",
+			signer:  rsaSigner,
+			claims:  func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() },
+			wantErr: ErrExpired,
+		},
+		{
+			name:    "sem expiração",
+			signer:  rsaSigner,
+			claims:  func(c jwt.MapClaims) { delete(c, "exp") },
+			wantErr: jwt.ErrTokenRequiredClaimMissing,
+		},
+		{
+			name:    "emissor diferente",
+			signer:  rsaSigner,
+			claims:  func(c jwt.MapClaims) { c["iss"] = "https://outro.exemplo.com" },
+			wantErr: jwt.ErrTokenInvalidIssuer,
+		},
+		{
+			name:    "audiência diferente",
+			signer:  ecSigner,
+			claims:  func(c jwt.MapClaims) { c["aud"] = "outra-aplicacao" },
+			wantErr: jwt.ErrTokenInvalidAudience,
+		},
+		{
+			name:    "sem audiência",
+			signer:  ecSigner,
+			claims:  func(c jwt.MapClaims) { delete(c, "aud") },
+			wantErr: jwt.ErrTokenRequiredClaimMissing,
+		},
+		{
+			name:    "kid desconhecido",
+			signer:  unknown,
+			wantErr: jwt.ErrTokenUnverifiable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := claims("lta-editor")
+			if tt.claims != nil {
+				tt.claims(c)
+			}
+
+			identity, err := verifier.Verify(context.Background(), tt.signer.sign(t, c))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("erro = %v, esperado %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("token válido rejeitado: %v", err)
+			}
+			if identity.Subject != "user-1" || identity.Name != "ana" {
+				t.Errorf("identidade = %q/%q, esperado user-1/ana", identity.Subject, identity.Name)
+			}
+			if want := []string{"read", "write"}; !reflect.DeepEqual(identity.Scopes, want) {
+				t.Errorf("escopos = %v, esperado %v", identity.Scopes, want)
+			}
+		})
+	}
+}
+
+func TestVerifyNestedRolesClaim(t *testing.T) {
+	rsaSigner, _, jwksFile := newSigners(t)
+	verifier := newVerifier(t, jwksFile, "realm_access.roles")
+
+	c := claims()
+	delete(c, "roles")
+	c["realm_access"] = map[string]interface{}{"roles": []interface{}{"lta-viewer", "offline_access"}}
+
+	identity, err := verifier.Verify(context.Background(), rsaSigner.sign(t, c))
+	if err != nil {
+		t.Fatalf("token válido rejeitado: %v", err)
+	}
+	if want := []string{"lta-viewer", "offline_access"}; !reflect.DeepEqual(identity.Roles, want) {
+		t.Errorf("papéis = %v, esperado %v", identity.Roles, want)
+	}
+	if want := []string{"read"}; !reflect.DeepEqual(identity.Scopes, want) {
+		t.Errorf("escopos = %v, esperado %v", identity.Scopes, want)
+	}
+}
+
+func TestRoleScopes(t *testing.T) {
+	rsaSigner, _, jwksFile := newSigners(t)
+	verifier := newVerifier(t, jwksFile, "roles")
+
+	tests := []struct {
+		name  string
+		roles []interface{}
+		want  []string
+	}{
+		{"papel associado", []interface{}{"lta-admin"}, []string{"admin"}},
+		{"vários papéis sem repetir escopos", []interface{}{"lta-viewer", "lta-editor"}, []string{"read", "write"}},
+		{"papel com nome de escopo não associado", []interface{}{"admin", "write"}, nil},
+		{"papel desconhecido ignorado", []interface{}{"offline_access", "lta-viewer"}, []string{"read"}},
+		{"sem papéis", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := verifier.Verify(context.Background(), rsaSigner.sign(t, claims(tt.roles...)))
+			if err != nil {
+				t.Fatalf("token válido rejeitado: %v", err)
+			}
+			if !reflect.DeepEqual(identity.Scopes, tt.want) {
+				t.Errorf("escopos = %v, esperado %v", identity.Scopes, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewVerifierRequiresAudience(t *testing.T) {
+	_, _, jwksFile := newSigners(t)
+
+	if _, err := NewVerifier(Config{Issuer: testIssuer, JWKSFile: jwksFile}); err == nil {
+		t.Error("verificador criado sem audiência")
+	}
+}
+
+func TestParseRoleScopes(t *testing.T) {
+	got, err := ParseRoleScopes(" lta-admin = admin ; lta-editor=read, write;")
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	want := map[string][]string{"lta-admin": {"admin"}, "lta-editor": {"read", "write"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseRoleScopes = %v, esperado %v", got, want)
+	}
+
+	for _, raw := range []string{"lta-admin", "=admin"} {
+		if _, err := ParseRoleScopes(raw); err == nil {
+			t.Errorf("ParseRoleScopes(%q) aceitou uma associação inválida", raw)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package outbox
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/events"
+	"github.com/bulletdev/lta-results-api/models"
+)
+
+// Configurações do dispatcher
+const (
+	pollInterval = 2 * time.Second
+	batchSize    = 100
+
+	// Os eventos publicados ficam no outbox por retention, período em que um stream
+	// ainda pode ser retomado a partir deles
+	retention     = 7 * 24 * time.Hour
+	purgeInterval = time.Hour
+)
+
+// Subscriber recebe os eventos publicados pelo dispatcher. Um erro faz com que o
+// evento seja entregue novamente no próximo ciclo, portanto assinantes devem ser idempotentes.
+type Subscriber func(events.Event) error
+
+var (
+	mu          sync.RWMutex
+	subscribers = make(map[string]Subscriber)
+)
+
+// Register registra um assinante em processo com um nome único
+func Register(name string, subscriber Subscriber) {
+	mu.Lock()
+	defer mu.Unlock()
+	subscribers[name] = subscriber
+}
+
+// Unregister remove um assinante
+func Unregister(name string) {
+	mu.Lock()
+	defer mu.Unlock()
+	delete(subscribers, name)
+}
+
+// Start inicia o dispatcher, que publica os eventos do outbox até ctx ser cancelado, e
+// a exclusão dos eventos publicados há mais tempo que o período de retenção
+func Start(ctx context.Context) {
+	go run(ctx)
+	go purge(ctx)
+	log.Println("Dispatcher do outbox iniciado")
+}
+
+func purge(ctx context.Context) {
+	ticker := time.NewTicker(purgeInterval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := models.PurgePublishedOutboxEvents(time.Now().Add(-retention))
+		if err != nil {
+			log.Printf("Erro ao limpar o outbox: %v", err)
+		} else if purged > 0 {
+			log.Printf("%d eventos publicados excluídos do outbox", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		dispatch(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-models.OutboxSignal():
+		}
+	}
+}
+
+// dispatch publica os eventos pendentes em ordem, parando no primeiro que falhar
+// para preservar a ordem de entrega
+func dispatch(ctx context.Context) {
+	for ctx.Err() == nil {
+		pending, err := models.GetPendingOutboxEvents(batchSize)
+		if err != nil {
+			log.Printf("Erro ao buscar eventos do outbox: %v", err)
+			return
+		}
+
+		for i := range pending {
+			if ctx.Err() != nil {
+				return
+			}
+			if !publish(&pending[i]) {
+				return
+			}
+		}
+
+		if len(pending) < batchSize {
+			return
+		}
+	}
+}
+
+// publish entrega o evento a todos os assinantes e o marca como publicado. Se um
+// assinante falhar, os que já o processaram são registrados e ficam de fora do reenvio.
+func publish(record *models.OutboxEvent) bool {
+	event := ToEvent(record)
+
+	mu.RLock()
+	defer mu.RUnlock()
+
+	delivered := append([]string(nil), record.DeliveredTo...)
+	for name, subscriber := range subscribers {
+		if contains(record.DeliveredTo, name) {
+			continue
+		}
+		if err := subscriber(event); err != nil {
+			log.Printf("Assinante %s falhou ao processar o evento %s: %v", name, record.ID.Hex(), err)
+			if len(delivered) > len(record.DeliveredTo) {
+				if err := models.MarkOutboxEventDelivered(record.ID, delivered); err != nil {
+					log.Printf("Erro ao registrar os assinantes do evento %s: %v", record.ID.Hex(), err)
+				}
+			}
+			return false
+		}
+		delivered = append(delivered, name)
+	}
+
+	if err := models.MarkOutboxEventPublished(record.ID); err != nil {
+		log.Printf("Erro ao marcar evento %s como publicado: %v", record.ID.Hex(), err)
+		return false
+	}
+	return true
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Replay busca no outbox os eventos já publicados posteriores a after. É o backfill do
+// broker, usado para retomar streams após um restart.
+func Replay(after uint64, limit int) ([]events.Event, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	records, err := models.GetPublishedOutboxEventsAfter(ctx, after, int64(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	replayed := make([]events.Event, len(records))
+	for i := range records {
+		replayed[i] = ToEvent(&records[i])
+	}
+	return replayed, nil
+}
+
+// ToEvent converte um registro do outbox no evento entregue aos assinantes
+func ToEvent(record *models.OutboxEvent) events.Event {
+	event := events.Event{
+		ID:      record.Seq,
+		Type:    record.Type,
+		MatchID: record.MatchID,
+		Region:  record.Region,
+		Teams:   record.Teams,
+		Players: record.Players,
+		Time:    record.CreatedAt,
+	}
+
+	if record.Match != nil {
+		event.Data = record.Match
+	} else if len(record.Details) > 0 {
+		event.Data = record.Details
+	}
+
+	return event
+}
@@ -0,0 +1,102 @@
+package prediction
+
+import (
+	"math"
+
+	"github.com/bulletdev/lta-results-api/models"
+)
+
+// Número de faixas usadas na tabela de calibração
+const calibrationBins = 10
+
+// BacktestReport resume a qualidade das previsões ao reproduzir a temporada
+type BacktestReport struct {
+	Matches     int                 `json:"matches"`
+	Skipped     int                 `json:"skipped"`
+	BrierScore  float64             `json:"brierScore"`
+	LogLoss     float64             `json:"logLoss"`
+	Accuracy    float64             `json:"accuracy"`
+	Calibration []CalibrationBucket `json:"calibration"`
+}
+
+// CalibrationBucket compara a probabilidade prevista com a frequência observada
+type CalibrationBucket struct {
+	Lower     float64 `json:"lower"`
+	Upper     float64 `json:"upper"`
+	Count     int     `json:"count"`
+	Predicted float64 `json:"predicted"`
+	Observed  float64 `json:"observed"`
+}
+
+// Backtest reproduz as partidas em ordem cronológica, prevendo cada série
+// apenas com os dados anteriores a ela e comparando com o Winner real
+func Backtest(matches []models.MatchResult) *BacktestReport {
+	model := NewModel()
+	report := &BacktestReport{}
+
+	sums := make([]float64, calibrationBins)
+	hits := make([]int, calibrationBins)
+	counts := make([]int, calibrationBins)
+
+	var brier, logLoss float64
+	correct := 0
+
+	for _, match := range SortByDate(matches) {
+		winner := SeriesWinner(match)
+
+		// Partidas sem vencedor ou entre times sem histórico não são avaliadas
+		if winner == "" || !model.Known(match.TeamA) || !model.Known(match.TeamB) {
+			report.Skipped++
+			model.Update(match)
+			continue
+		}
+
+		p := SeriesProbability(model.GameProbability(match.TeamA, match.TeamB), BestOf(match))
+		outcome := 0.0
+		if winner == match.TeamA {
+			outcome = 1
+		}
+
+		brier += (p - outcome) * (p - outcome)
+		clamped := math.Min(math.Max(p, 1e-6), 1-1e-6)
+		logLoss -= outcome*math.Log(clamped) + (1-outcome)*math.Log(1-clamped)
+		if (p >= 0.5) == (outcome == 1) {
+			correct++
+		}
+
+		bin := int(p * calibrationBins)
+		if bin == calibrationBins {
+			bin--
+		}
+		sums[bin] += p
+		counts[bin]++
+		if outcome == 1 {
+			hits[bin]++
+		}
+
+		report.Matches++
+		model.Update(match)
+	}
+
+	if report.Matches > 0 {
+		n := float64(report.Matches)
+		report.BrierScore = round(brier / n)
+		report.LogLoss = round(logLoss / n)
+		report.Accuracy = round(float64(correct) / n)
+	}
+
+	for i := 0; i < calibrationBins; i++ {
+		bucket := CalibrationBucket{
+			Lower: float64(i) / calibrationBins,
+			Upper: float64(i+1) / calibrationBins,
+			Count: counts[i],
+		}
+		if counts[i] > 0 {
+			bucket.Predicted = round(sums[i] / float64(counts[i]))
+			bucket.Observed = round(float64(hits[i]) / float64(counts[i]))
+		}
+		report.Calibration = append(report.Calibration, bucket)
+	}
+
+	return report
+}
@@ -0,0 +1,267 @@
+package prediction
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/bulletdev/lta-results-api/models"
+)
+
+// Parâmetros do modelo de previsão
+const (
+	initialRating = 1500.0
+	kFactor       = 32.0
+	formWindow    = 5
+	formWeight    = 100.0
+)
+
+// Model mantém os ratings Elo e a forma recente de cada time
+type Model struct {
+	ratings map[string]float64
+	form    map[string][]bool
+	games   map[string]int
+}
+
+// Prediction representa a previsão de uma série entre dois times
+type Prediction struct {
+	TeamA              string  `json:"teamA"`
+	TeamB              string  `json:"teamB"`
+	BestOf             int     `json:"bestOf"`
+	RatingA            float64 `json:"ratingA"`
+	RatingB            float64 `json:"ratingB"`
+	FormA              float64 `json:"formA"`
+	FormB              float64 `json:"formB"`
+	GameProbabilityA   float64 `json:"gameProbabilityA"`
+	GameProbabilityB   float64 `json:"gameProbabilityB"`
+	SeriesProbabilityA float64 `json:"seriesProbabilityA"`
+	SeriesProbabilityB float64 `json:"seriesProbabilityB"`
+	Favorite           string  `json:"favorite"`
+}
+
+// NewModel cria um modelo vazio
+func NewModel() *Model {
+	return &Model{
+		ratings: make(map[string]float64),
+		form:    make(map[string][]bool),
+		games:   make(map[string]int),
+	}
+}
+
+// Build cria um modelo a partir de partidas, processadas em ordem cronológica
+func Build(matches []models.MatchResult) *Model {
+	m := NewModel()
+	for _, match := range SortByDate(matches) {
+		m.Update(match)
+	}
+	return m
+}
+
+// SortByDate retorna uma cópia das partidas ordenada da mais antiga para a mais recente
+func SortByDate(matches []models.MatchResult) []models.MatchResult {
+	sorted := make([]models.MatchResult, len(matches))
+	copy(sorted, matches)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date.Before(sorted[j].Date)
+	})
+	return sorted
+}
+
+// Known indica se o time já possui partidas registradas no modelo
+func (m *Model) Known(team string) bool {
+	return m.games[team] > 0
+}
+
+// Rating retorna o rating Elo atual do time
+func (m *Model) Rating(team string) float64 {
+	if r, ok := m.ratings[team]; ok {
+		return r
+	}
+	return initialRating
+}
+
+// Form retorna a taxa de vitórias do time nos últimos jogos (0.5 sem histórico)
+func (m *Model) Form(team string) float64 {
+	recent := m.form[team]
+	if len(recent) == 0 {
+		return 0.5
+	}
+
+	wins := 0
+	for _, won := range recent {
+		if won {
+			wins++
+		}
+	}
+	return float64(wins) / float64(len(recent))
+}
+
+// GameProbability calcula a probabilidade de teamA vencer um único jogo contra teamB
+func (m *Model) GameProbability(teamA, teamB string) float64 {
+	// A forma recente ajusta o rating efetivo de cada time
+	ratingA := m.Rating(teamA) + (m.Form(teamA)-0.5)*formWeight
+	ratingB := m.Rating(teamB) + (m.Form(teamB)-0.5)*formWeight
+	return expected(ratingA, ratingB)
+}
+
+// Update aplica o resultado de uma partida aos ratings e à forma dos times. A ordem dos
+// jogos não é armazenada, então eles são reproduzidos alternando as vitórias, a
+// começar e terminar pelo vencedor da série (3-2 vira V D V D V), em vez de todas as
+// vitórias de um time antes das do outro.
+func (m *Model) Update(match models.MatchResult) {
+	if match.TeamA == "" || match.TeamB == "" {
+		return
+	}
+
+	winner, loser := match.TeamA, match.TeamB
+	winnerWins, loserWins := gameWins(match)
+	if loserWins > winnerWins {
+		winner, loser = loser, winner
+		winnerWins, loserWins = loserWins, winnerWins
+	}
+
+	for winnerWins > 0 || loserWins > 0 {
+		if winnerWins > 0 {
+			m.applyGame(winner, loser)
+			winnerWins--
+		}
+		if loserWins > 0 {
+			m.applyGame(loser, winner)
+			loserWins--
+		}
+	}
+}
+
+// Predict calcula a previsão de uma série melhor de bestOf entre dois times
+func (m *Model) Predict(teamA, teamB string, bestOf int) (*Prediction, error) {
+	if !validBestOf(bestOf) {
+		return nil, fmt.Errorf("bestOf inválido: %d", bestOf)
+	}
+
+	p := m.GameProbability(teamA, teamB)
+	series := SeriesProbability(p, bestOf)
+
+	prediction := &Prediction{
+		TeamA:              teamA,
+		TeamB:              teamB,
+		BestOf:             bestOf,
+		RatingA:            round(m.Rating(teamA)),
+		RatingB:            round(m.Rating(teamB)),
+		FormA:              round(m.Form(teamA)),
+		FormB:              round(m.Form(teamB)),
+		GameProbabilityA:   round(p),
+		GameProbabilityB:   round(1 - p),
+		SeriesProbabilityA: round(series),
+		SeriesProbabilityB: round(1 - series),
+		Favorite:           teamA,
+	}
+	if series < 0.5 {
+		prediction.Favorite = teamB
+	}
+
+	return prediction, nil
+}
+
+// SeriesProbability converte a probabilidade de vencer um jogo na probabilidade
+// de vencer uma série melhor de bestOf (Bo1, Bo3, Bo5)
+func SeriesProbability(p float64, bestOf int) float64 {
+	needed := bestOf/2 + 1
+	q := 1 - p
+
+	// Soma das séries em que o time vence o último jogo após perder k jogos
+	total := 0.0
+	for k := 0; k < needed; k++ {
+		total += binomial(needed-1+k, k) * math.Pow(p, float64(needed)) * math.Pow(q, float64(k))
+	}
+	return total
+}
+
+// SeriesWinner identifica o vencedor de uma partida armazenada
+func SeriesWinner(match models.MatchResult) string {
+	switch {
+	case strings.EqualFold(match.Winner, match.TeamA):
+		return match.TeamA
+	case strings.EqualFold(match.Winner, match.TeamB):
+		return match.TeamB
+	case match.ScoreA > match.ScoreB:
+		return match.TeamA
+	case match.ScoreB > match.ScoreA:
+		return match.TeamB
+	}
+	return ""
+}
+
+// BestOf estima o formato da série a partir do placar armazenado
+func BestOf(match models.MatchResult) int {
+	top := match.ScoreA
+	if match.ScoreB > top {
+		top = match.ScoreB
+	}
+	switch {
+	case top >= 3:
+		return 5
+	case top == 2:
+		return 3
+	}
+	return 1
+}
+
+// applyGame registra a vitória de winner sobre loser em um único jogo
+func (m *Model) applyGame(winner, loser string) {
+	rw, rl := m.Rating(winner), m.Rating(loser)
+	delta := kFactor * (1 - expected(rw, rl))
+	m.ratings[winner] = rw + delta
+	m.ratings[loser] = rl - delta
+
+	m.pushForm(winner, true)
+	m.pushForm(loser, false)
+}
+
+// pushForm adiciona um resultado à janela de forma recente do time
+func (m *Model) pushForm(team string, won bool) {
+	recent := append(m.form[team], won)
+	if len(recent) > formWindow {
+		recent = recent[len(recent)-formWindow:]
+	}
+	m.form[team] = recent
+	m.games[team]++
+}
+
+// gameWins retorna quantos jogos cada time venceu na partida
+func gameWins(match models.MatchResult) (int, int) {
+	if match.ScoreA > 0 || match.ScoreB > 0 {
+		return match.ScoreA, match.ScoreB
+	}
+
+	// Sem placar, usar apenas o vencedor informado
+	switch SeriesWinner(match) {
+	case match.TeamA:
+		return 1, 0
+	case match.TeamB:
+		return 0, 1
+	}
+	return 0, 0
+}
+
+// expected retorna a probabilidade Elo de a vencer b
+func expected(a, b float64) float64 {
+	return 1 / (1 + math.Pow(10, (b-a)/400))
+}
+
+// binomial calcula o coeficiente binomial C(n, k)
+func binomial(n, k int) float64 {
+	result := 1.0
+	for i := 1; i <= k; i++ {
+		result = result * float64(n-k+i) / float64(i)
+	}
+	return result
+}
+
+func validBestOf(bestOf int) bool {
+	return bestOf == 1 || bestOf == 3 || bestOf == 5
+}
+
+func round(v float64) float64 {
+	return math.Round(v*10000) / 10000
+}
@@ -0,0 +1,152 @@
+package prediction
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/bulletdev/lta-results-api/models"
+)
+
+func TestSeriesProbability(t *testing.T) {
+	tests := []struct {
+		name   string
+		p      float64
+		bestOf int
+		want   float64
+	}{
+		{"Bo1 equilibrado", 0.5, 1, 0.5},
+		{"Bo3 equilibrado", 0.5, 3, 0.5},
+		{"Bo5 equilibrado", 0.5, 5, 0.5},
+		{"Bo1 é o próprio jogo", 0.6, 1, 0.6},
+		// p²(3 - 2p)
+		{"Bo3", 0.6, 3, 0.36 * (3 - 1.2)},
+		// p³(1 + 3q + 6q²)
+		{"Bo5", 0.6, 5, 0.216 * (1 + 3*0.4 + 6*0.16)},
+		{"sem chance", 0, 5, 0},
+		{"vitória certa", 1, 3, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SeriesProbability(tt.p, tt.bestOf); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("SeriesProbability(%v, %d) = %v, esperado %v", tt.p, tt.bestOf, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeriesProbabilityFavorsTheBetterTeam(t *testing.T) {
+	for _, p := range []float64{0.3, 0.55, 0.7} {
+		bo1, bo3, bo5 := SeriesProbability(p, 1), SeriesProbability(p, 3), SeriesProbability(p, 5)
+
+		// As duas probabilidades da série somam 1
+		if sum := bo5 + SeriesProbability(1-p, 5); math.Abs(sum-1) > 1e-9 {
+			t.Errorf("p = %v: probabilidades da série somam %v", p, sum)
+		}
+		// Séries mais longas ampliam a vantagem de quem é melhor em um jogo
+		if (p > 0.5 && !(bo1 < bo3 && bo3 < bo5)) || (p < 0.5 && !(bo1 > bo3 && bo3 > bo5)) {
+			t.Errorf("p = %v: Bo1 %v, Bo3 %v, Bo5 %v fora de ordem", p, bo1, bo3, bo5)
+		}
+	}
+}
+
+func TestUpdateInterleavesGames(t *testing.T) {
+	m := NewModel()
+	m.Update(models.MatchResult{TeamA: "LOUD", TeamB: "paiN Gaming", ScoreA: 2, ScoreB: 3})
+
+	// 3-2 para a paiN é reproduzido como V D V D V
+	if want := []bool{true, false, true, false, true}; !reflect.DeepEqual(m.form["paiN Gaming"], want) {
+		t.Errorf("forma do vencedor = %v, esperado %v", m.form["paiN Gaming"], want)
+	}
+	if want := []bool{false, true, false, true, false}; !reflect.DeepEqual(m.form["LOUD"], want) {
+		t.Errorf("forma do perdedor = %v, esperado %v", m.form["LOUD"], want)
+	}
+
+	want := NewModel()
+	for i := 0; i < 5; i++ {
+		if i%2 == 0 {
+			want.applyGame("paiN Gaming", "LOUD")
+		} else {
+			want.applyGame("LOUD", "paiN Gaming")
+		}
+	}
+	if !reflect.DeepEqual(m.ratings, want.ratings) {
+		t.Errorf("ratings = %v, esperado %v", m.ratings, want.ratings)
+	}
+	if m.Rating("paiN Gaming") <= initialRating || m.Rating("LOUD") >= initialRating {
+		t.Errorf("ratings após a série: vencedor %v, perdedor %v", m.Rating("paiN Gaming"), m.Rating("LOUD"))
+	}
+}
+
+func TestUpdateWithoutScore(t *testing.T) {
+	tests := []struct {
+		name  string
+		match models.MatchResult
+		want  string
+	}{
+		{"vencedor informado", models.MatchResult{TeamA: "LOUD", TeamB: "RED", Winner: "RED"}, "RED"},
+		{"vencedor em outra caixa", models.MatchResult{TeamA: "LOUD", TeamB: "RED", Winner: "loud"}, "LOUD"},
+		{"sem vencedor", models.MatchResult{TeamA: "LOUD", TeamB: "RED"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewModel()
+			m.Update(tt.match)
+
+			for _, team := range []string{"LOUD", "RED"} {
+				want := initialRating
+				switch {
+				case tt.want == "":
+				case team == tt.want:
+					want = initialRating + kFactor/2
+				default:
+					want = initialRating - kFactor/2
+				}
+				if got := m.Rating(team); got != want {
+					t.Errorf("rating de %s = %v, esperado %v", team, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestBestOf(t *testing.T) {
+	tests := []struct {
+		scoreA, scoreB int
+		want           int
+	}{
+		{0, 0, 1},
+		{1, 0, 1},
+		{2, 1, 3},
+		{0, 2, 3},
+		{3, 2, 5},
+		{1, 3, 5},
+	}
+	for _, tt := range tests {
+		if got := BestOf(models.MatchResult{ScoreA: tt.scoreA, ScoreB: tt.scoreB}); got != tt.want {
+			t.Errorf("BestOf(%d-%d) = %d, esperado %d", tt.scoreA, tt.scoreB, got, tt.want)
+		}
+	}
+}
+
+func TestPredict(t *testing.T) {
+	m := NewModel()
+	m.Update(models.MatchResult{TeamA: "LOUD", TeamB: "RED", ScoreA: 3, ScoreB: 0})
+
+	prediction, err := m.Predict("RED", "LOUD", 5)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if prediction.Favorite != "LOUD" {
+		t.Errorf("favorito = %s, esperado LOUD", prediction.Favorite)
+	}
+	if sum := prediction.SeriesProbabilityA + prediction.SeriesProbabilityB; math.Abs(sum-1) > 1e-4 {
+		t.Errorf("probabilidades da série somam %v", sum)
+	}
+
+	for _, bestOf := range []int{0, 2, 7} {
+		if _, err := m.Predict("RED", "LOUD", bestOf); err == nil {
+			t.Errorf("bestOf %d aceito", bestOf)
+		}
+	}
+}
@@ -0,0 +1,4 @@
+package ltav1
+
+// Regenerar após alterar results.proto (requer protoc, protoc-gen-go e protoc-gen-go-grpc)
+//go:generate protoc -I ../.. --go_out=../.. --go_opt=paths=source_relative --go-grpc_out=../.. --go-grpc_opt=paths=source_relative lta/v1/results.proto
@@ -0,0 +1,291 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: lta/v1/results.proto
+
+package ltav1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ResultsService_ListResults_FullMethodName    = "/lta.v1.ResultsService/ListResults"
+	ResultsService_GetResult_FullMethodName      = "/lta.v1.ResultsService/GetResult"
+	ResultsService_GetPlayerStats_FullMethodName = "/lta.v1.ResultsService/GetPlayerStats"
+	ResultsService_GetTeamStats_FullMethodName   = "/lta.v1.ResultsService/GetTeamStats"
+	ResultsService_WatchResults_FullMethodName   = "/lta.v1.ResultsService/WatchResults"
+)
+
+// ResultsServiceClient is the client API for ResultsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ResultsService expõe os resultados das partidas para serviços internos
+type ResultsServiceClient interface {
+	// Lista partidas da mais recente para a mais antiga, com paginação
+	ListResults(ctx context.Context, in *ListResultsRequest, opts ...grpc.CallOption) (*ListResultsResponse, error)
+	// Busca uma partida pelo matchId
+	GetResult(ctx context.Context, in *GetResultRequest, opts ...grpc.CallOption) (*MatchResult, error)
+	// Estatísticas agregadas de um jogador
+	GetPlayerStats(ctx context.Context, in *GetPlayerStatsRequest, opts ...grpc.CallOption) (*PlayerStats, error)
+	// Estatísticas agregadas de um time
+	GetTeamStats(ctx context.Context, in *GetTeamStatsRequest, opts ...grpc.CallOption) (*TeamStats, error)
+	// Transmite as alterações nas partidas conforme acontecem
+	WatchResults(ctx context.Context, in *WatchResultsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ResultEvent], error)
+}
+
+type resultsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewResultsServiceClient(cc grpc.ClientConnInterface) ResultsServiceClient {
+	return &resultsServiceClient{cc}
+}
+
+func (c *resultsServiceClient) ListResults(ctx context.Context, in *ListResultsRequest, opts ...grpc.CallOption) (*ListResultsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListResultsResponse)
+	err := c.cc.Invoke(ctx, ResultsService_ListResults_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resultsServiceClient) GetResult(ctx context.Context, in *GetResultRequest, opts ...grpc.CallOption) (*MatchResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MatchResult)
+	err := c.cc.Invoke(ctx, ResultsService_GetResult_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resultsServiceClient) GetPlayerStats(ctx context.Context, in *GetPlayerStatsRequest, opts ...grpc.CallOption) (*PlayerStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlayerStats)
+	err := c.cc.Invoke(ctx, ResultsService_GetPlayerStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resultsServiceClient) GetTeamStats(ctx context.Context, in *GetTeamStatsRequest, opts ...grpc.CallOption) (*TeamStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TeamStats)
+	err := c.cc.Invoke(ctx, ResultsService_GetTeamStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resultsServiceClient) WatchResults(ctx context.Context, in *WatchResultsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ResultEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ResultsService_ServiceDesc.Streams[0], ResultsService_WatchResults_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchResultsRequest, ResultEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ResultsService_WatchResultsClient = grpc.ServerStreamingClient[ResultEvent]
+
+// ResultsServiceServer is the server API for ResultsService service.
+// All implementations must embed UnimplementedResultsServiceServer
+// for forward compatibility.
+//
+// ResultsService expõe os resultados das partidas para serviços internos
+type ResultsServiceServer interface {
+	// Lista partidas da mais recente para a mais antiga, com paginação
+	ListResults(context.Context, *ListResultsRequest) (*ListResultsResponse, error)
+	// Busca uma partida pelo matchId
+	GetResult(context.Context, *GetResultRequest) (*MatchResult, error)
+	// Estatísticas agregadas de um jogador
+	GetPlayerStats(context.Context, *GetPlayerStatsRequest) (*PlayerStats, error)
+	// Estatísticas agregadas de um time
+	GetTeamStats(context.Context, *GetTeamStatsRequest) (*TeamStats, error)
+	// Transmite as alterações nas partidas conforme acontecem
+	WatchResults(*WatchResultsRequest, grpc.ServerStreamingServer[ResultEvent]) error
+	mustEmbedUnimplementedResultsServiceServer()
+}
+
+// UnimplementedResultsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedResultsServiceServer struct{}
+
+func (UnimplementedResultsServiceServer) ListResults(context.Context, *ListResultsRequest) (*ListResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListResults not implemented")
+}
+func (UnimplementedResultsServiceServer) GetResult(context.Context, *GetResultRequest) (*MatchResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResult not implemented")
+}
+func (UnimplementedResultsServiceServer) GetPlayerStats(context.Context, *GetPlayerStatsRequest) (*PlayerStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPlayerStats not implemented")
+}
+func (UnimplementedResultsServiceServer) GetTeamStats(context.Context, *GetTeamStatsRequest) (*TeamStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTeamStats not implemented")
+}
+func (UnimplementedResultsServiceServer) WatchResults(*WatchResultsRequest, grpc.ServerStreamingServer[ResultEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchResults not implemented")
+}
+func (UnimplementedResultsServiceServer) mustEmbedUnimplementedResultsServiceServer() {}
+func (UnimplementedResultsServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeResultsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ResultsServiceServer will
+// result in compilation errors.
+type UnsafeResultsServiceServer interface {
+	mustEmbedUnimplementedResultsServiceServer()
+}
+
+func RegisterResultsServiceServer(s grpc.ServiceRegistrar, srv ResultsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedResultsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ResultsService_ServiceDesc, srv)
+}
+
+func _ResultsService_ListResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResultsServiceServer).ListResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResultsService_ListResults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResultsServiceServer).ListResults(ctx, req.(*ListResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResultsService_GetResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetResultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResultsServiceServer).GetResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResultsService_GetResult_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResultsServiceServer).GetResult(ctx, req.(*GetResultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResultsService_GetPlayerStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPlayerStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResultsServiceServer).GetPlayerStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResultsService_GetPlayerStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResultsServiceServer).GetPlayerStats(ctx, req.(*GetPlayerStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResultsService_GetTeamStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTeamStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResultsServiceServer).GetTeamStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResultsService_GetTeamStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResultsServiceServer).GetTeamStats(ctx, req.(*GetTeamStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResultsService_WatchResults_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchResultsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ResultsServiceServer).WatchResults(m, &grpc.GenericServerStream[WatchResultsRequest, ResultEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ResultsService_WatchResultsServer = grpc.ServerStreamingServer[ResultEvent]
+
+// ResultsService_ServiceDesc is the grpc.ServiceDesc for ResultsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ResultsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "lta.v1.ResultsService",
+	HandlerType: (*ResultsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListResults",
+			Handler:    _ResultsService_ListResults_Handler,
+		},
+		{
+			MethodName: "GetResult",
+			Handler:    _ResultsService_GetResult_Handler,
+		},
+		{
+			MethodName: "GetPlayerStats",
+			Handler:    _ResultsService_GetPlayerStats_Handler,
+		},
+		{
+			MethodName: "GetTeamStats",
+			Handler:    _ResultsService_GetTeamStats_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchResults",
+			Handler:       _ResultsService_WatchResults_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "lta/v1/results.proto",
+}
@@ -0,0 +1,141 @@
+// Package ratelimit limita as requisições por cliente com token buckets. Os buckets
+// ficam em um Store, que pode ser a memória do processo ou um armazenamento
+// compartilhado entre instâncias.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit descreve um token bucket: Requests requisições a cada Period, acumulando no
+// máximo Burst
+type Limit struct {
+	Requests int
+	Period   time.Duration
+	Burst    int
+}
+
+// Enabled indica se o limite deve ser aplicado
+func (l Limit) Enabled() bool {
+	return l.Requests > 0 && l.Period > 0
+}
+
+// rate retorna os tokens repostos por segundo
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// capacity retorna o tamanho do bucket
+func (l Limit) capacity() float64 {
+	if l.Burst > 0 {
+		return float64(l.Burst)
+	}
+	return float64(l.Requests)
+}
+
+// Policy descreve o limite no formato do header RateLimit-Policy, como "120;w=60"
+func (l Limit) Policy() string {
+	return fmt.Sprintf("%d;w=%d", l.Requests, int(l.Period.Seconds()))
+}
+
+// ParseLimit lê um limite no formato "requisições/período[,burst]", como "120/1m" ou
+// "30/1m,10". "off" desativa o limite.
+func ParseLimit(raw string) (Limit, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "off" || raw == "0" {
+		return Limit{}, nil
+	}
+
+	spec, burst, hasBurst := strings.Cut(raw, ",")
+	requests, period, ok := strings.Cut(spec, "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("limite inválido %q: use requisições/período, como 120/1m", raw)
+	}
+
+	var limit Limit
+	var err error
+	if limit.Requests, err = strconv.Atoi(strings.TrimSpace(requests)); err != nil || limit.Requests <= 0 {
+		return Limit{}, fmt.Errorf("limite inválido %q: quantidade de requisições", raw)
+	}
+	if limit.Period, err = time.ParseDuration(strings.TrimSpace(period)); err != nil || limit.Period <= 0 {
+		return Limit{}, fmt.Errorf("limite inválido %q: período", raw)
+	}
+	if hasBurst {
+		if limit.Burst, err = strconv.Atoi(strings.TrimSpace(burst)); err != nil || limit.Burst <= 0 {
+			return Limit{}, fmt.Errorf("limite inválido %q: burst", raw)
+		}
+	}
+	return limit, nil
+}
+
+// Result é o estado do bucket após uma requisição
+type Result struct {
+	Allowed bool
+	// Limit é a capacidade do bucket
+	Limit     int
+	Remaining int
+	// Reset é o tempo até o bucket voltar a ficar cheio
+	Reset time.Duration
+	// RetryAfter é o tempo até a próxima requisição ser aceita, quando recusada
+	RetryAfter time.Duration
+}
+
+// Store guarda os buckets. Take consome um token do bucket da chave, se houver.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error)
+}
+
+// ErrUnknownBackend indica um backend de Store não suportado
+var ErrUnknownBackend = errors.New("backend de rate limit desconhecido")
+
+// NewStore cria o Store do backend informado. Apenas "memory" é embutido; backends
+// compartilhados implementam Store e são passados diretamente para o middleware.
+func NewStore(backend string) (Store, error) {
+	switch backend {
+	case "", "memory":
+		return NewMemoryStore(), nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownBackend, backend)
+}
+
+// bucket é o estado de um token bucket
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// take repõe os tokens desde a última requisição e consome um, se houver
+func (b *bucket) take(limit Limit, now time.Time) Result {
+	capacity, rate := limit.capacity(), limit.rate()
+
+	if elapsed := now.Sub(b.updated).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(capacity, b.tokens+elapsed*rate)
+	}
+	b.updated = now
+
+	result := Result{Limit: int(capacity)}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = seconds((1 - b.tokens) / rate)
+	}
+	result.Remaining = int(b.tokens)
+	result.Reset = seconds((capacity - b.tokens) / rate)
+	return result
+}
+
+// full indica se o bucket já teria se reposto por completo em now
+func (b *bucket) full(limit Limit, now time.Time) bool {
+	return b.tokens+now.Sub(b.updated).Seconds()*limit.rate() >= limit.capacity()
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,110 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want Limit
+	}{
+		{"120/1m", Limit{Requests: 120, Period: time.Minute}},
+		{" 30/1m, 10 ", Limit{Requests: 30, Period: time.Minute, Burst: 10}},
+		{"5/1s", Limit{Requests: 5, Period: time.Second}},
+		{"off", Limit{}},
+		{"0", Limit{}},
+	}
+	for _, tt := range tests {
+		got, err := ParseLimit(tt.raw)
+		if err != nil {
+			t.Errorf("ParseLimit(%q): erro inesperado: %v", tt.raw, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseLimit(%q) = %+v, esperado %+v", tt.raw, got, tt.want)
+		}
+	}
+
+	for _, raw := range []string{"", "120", "abc/1m", "0/1m", "10/abc", "10/0s", "10/1m,0", "10/1m,x"} {
+		if _, err := ParseLimit(raw); err == nil {
+			t.Errorf("ParseLimit(%q) aceitou um limite inválido", raw)
+		}
+	}
+}
+
+func TestMemoryStoreTake(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	// 2 requisições por segundo, acumulando até 4
+	limit := Limit{Requests: 2, Period: time.Second, Burst: 4}
+	now := time.Date(2025, 4, 12, 18, 0, 0, 0, time.UTC)
+
+	take := func(key string, at time.Time) Result {
+		t.Helper()
+		result, err := store.Take(ctx, key, limit, at)
+		if err != nil {
+			t.Fatalf("erro inesperado: %v", err)
+		}
+		return result
+	}
+
+	// O bucket começa cheio e aceita o burst inteiro
+	for i := 3; i >= 0; i-- {
+		result := take("a", now)
+		if !result.Allowed || result.Remaining != i || result.Limit != 4 {
+			t.Fatalf("requisição do burst = %+v, esperado %d restantes", result, i)
+		}
+	}
+
+	denied := take("a", now)
+	if denied.Allowed {
+		t.Fatal("requisição aceita com o bucket vazio")
+	}
+	if denied.RetryAfter != 500*time.Millisecond || denied.Reset != 2*time.Second {
+		t.Errorf("recusa com retryAfter %v e reset %v, esperado 500ms e 2s", denied.RetryAfter, denied.Reset)
+	}
+
+	// Cada chave tem o próprio bucket
+	if result := take("b", now); !result.Allowed {
+		t.Error("outra chave recusada")
+	}
+
+	// Meio segundo repõe um token
+	if result := take("a", now.Add(500*time.Millisecond)); !result.Allowed || result.Remaining != 0 {
+		t.Errorf("após a reposição = %+v, esperado aceita sem tokens restantes", result)
+	}
+
+	// A reposição não passa da capacidade
+	if result := take("a", now.Add(time.Hour)); result.Remaining != 3 {
+		t.Errorf("após uma hora restam %d, esperado 3", result.Remaining)
+	}
+}
+
+func TestMemoryStoreCleanup(t *testing.T) {
+	store := NewMemoryStore()
+	limit := Limit{Requests: 1, Period: time.Second}
+	now := time.Date(2025, 4, 12, 18, 0, 0, 0, time.UTC)
+
+	store.Take(context.Background(), "a", limit, now)
+	store.Take(context.Background(), "b", limit, now.Add(cleanupInterval+time.Second))
+
+	// O bucket de "a" já se repôs e é removido na limpeza
+	if _, ok := store.buckets["a"]; ok {
+		t.Error("bucket cheio mantido após a limpeza")
+	}
+	if _, ok := store.buckets["b"]; !ok {
+		t.Error("bucket em uso removido")
+	}
+}
+
+func TestNewStore(t *testing.T) {
+	if _, err := NewStore("memory"); err != nil {
+		t.Errorf("backend memory recusado: %v", err)
+	}
+	if _, err := NewStore("redis"); err == nil {
+		t.Error("backend desconhecido aceito")
+	}
+}
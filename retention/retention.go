@@ -0,0 +1,53 @@
+// Package retention exclui definitivamente as partidas que estão na lixeira há mais
+// tempo que o período de retenção.
+package retention
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/models"
+)
+
+// Intervalo entre as limpezas da lixeira
+const purgeInterval = time.Hour
+
+// Start inicia a limpeza periódica da lixeira até ctx ser cancelado, excluindo as
+// partidas que estão nela há mais de days dias. Zero desativa a exclusão definitiva.
+func Start(ctx context.Context, days int) {
+	if days <= 0 {
+		log.Println("Retenção da lixeira desativada; partidas excluídas não serão removidas")
+		return
+	}
+
+	go run(ctx, time.Duration(days)*24*time.Hour)
+	log.Printf("Limpeza da lixeira iniciada (retenção de %d dias)", days)
+}
+
+func run(ctx context.Context, period time.Duration) {
+	ticker := time.NewTicker(purgeInterval)
+	defer ticker.Stop()
+
+	for {
+		Purge(period)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Purge exclui as partidas que estão na lixeira há mais tempo que period
+func Purge(period time.Duration) {
+	purged, err := models.PurgeDeletedMatchResults(time.Now().Add(-period))
+	if err != nil {
+		log.Printf("Erro ao limpar a lixeira: %v", err)
+		return
+	}
+	if purged > 0 {
+		log.Printf("%d partidas excluídas definitivamente da lixeira", purged)
+	}
+}
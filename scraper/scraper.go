@@ -5,36 +5,44 @@ import (
 	"fmt"
 	"log"
 	_ "net/http"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/bulletdev/lta-results-api/config"
+	"github.com/bulletdev/lta-results-api/events"
 	"github.com/bulletdev/lta-results-api/models"
+	"github.com/bulletdev/lta-results-api/mvp"
+	"github.com/bulletdev/lta-results-api/validation"
 	"github.com/chromedp/chromedp"
 	"github.com/robfig/cron/v3"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-// URLs para scraping
-var LTA_URLS = map[string]string{
-	"sul":   "https://maisesports.com.br/campeonatos/league-of-legends-lta-sul-split-2-2025/",
-	"norte": "https://maisesports.com.br/campeonatos/league-of-legends-lta-norte-split-2-2025/",
+// settings guarda as fontes, o agendamento e os limites do scraping
+var settings = config.Defaults().Scraper
+
+// Configure define as configurações usadas pelo agendamento e pelo scraping
+func Configure(cfg config.Scraper) {
+	settings = cfg
 }
 
-// Configurações do scraper
-const (
-	maxRetries = 3
-	retryDelay = 5 * time.Second
-	timeout    = 180 * time.Second
+// scheduler e entry identificam o agendamento ativo, consultado pelo health check
+var (
+	schedulerMu sync.RWMutex
+	scheduler   *cron.Cron
+	entry       cron.EntryID
 )
 
 // ScheduleScraping configura o agendamento do scraping
 func ScheduleScraping() {
 	c := cron.New()
 
-	// Agendar scraping diário às 2h da manhã
-	_, err := c.AddFunc("0 2 * * *", func() {
+	id, err := c.AddFunc(settings.Schedule, func() {
 		log.Println("Executando scraping agendado...")
 		if err := ScrapeMatchResults(); err != nil {
 			log.Printf("Erro no scraping agendado: %v", err)
@@ -47,7 +55,22 @@ func ScheduleScraping() {
 	}
 
 	c.Start()
-	log.Println("Scraping agendado configurado com sucesso")
+	schedulerMu.Lock()
+	scheduler, entry = c, id
+	schedulerMu.Unlock()
+	log.Printf("Scraping agendado configurado com sucesso (%s)", settings.Schedule)
+}
+
+// NextRun retorna o horário da próxima execução agendada, ou false quando o scraping
+// não foi agendado
+func NextRun() (time.Time, bool) {
+	schedulerMu.RLock()
+	defer schedulerMu.RUnlock()
+	if scheduler == nil {
+		return time.Time{}, false
+	}
+	next := scheduler.Entry(entry).Next
+	return next, !next.IsZero()
 }
 
 // ScrapeMatchResults extrai os resultados de partidas
@@ -63,8 +86,8 @@ func ScrapeMatchResults() error {
 		chromedp.Flag("disable-setuid-sandbox", true),
 		chromedp.Flag("disable-dev-shm-usage", true),
 		chromedp.Flag("no-zygote", true),
-		chromedp.Flag("user-data-dir", "/app/chrome-data"),
-		chromedp.UserDataDir("/app/chrome-data"),
+		chromedp.Flag("user-data-dir", settings.ChromeDataDir),
+		chromedp.UserDataDir(settings.ChromeDataDir),
 	)
 
 	allocCtx, cancelAlloc := chromedp.NewExecAllocator(context.Background(), opts...)
@@ -78,19 +101,19 @@ func ScrapeMatchResults() error {
 	defer cancelCtx()
 
 	// Criar um contexto com timeout a partir do contexto principal do chromedp
-	timeoutCtx, cancelTimeout := context.WithTimeout(mainCtx, timeout)
+	timeoutCtx, cancelTimeout := context.WithTimeout(mainCtx, settings.Timeout.Duration)
 	defer cancelTimeout()
 	// --- Fim da Criação Única do Contexto Chrome ---
 
 	// Para cada região, extrair os resultados usando o mesmo contexto
-	for region, url := range LTA_URLS {
+	for region, url := range settings.Sources {
 		log.Printf("Extraindo resultados da região %s...", region)
 		log.Printf("URL: %s", url)
 
 		// Tentar extrair os dados com retry
 		var html string
 		var err error
-		for i := 0; i < maxRetries; i++ {
+		for i := 0; i < settings.MaxRetries; i++ {
 			// Passar o contexto com timeout para extractHTML
 			html, err = extractHTML(timeoutCtx, url) // Passa o contexto
 			if err == nil {
@@ -102,11 +125,12 @@ func ScrapeMatchResults() error {
 				break
 			}
 			log.Printf("Tentativa %d falhou para %s: %v", i+1, region, err)
-			time.Sleep(retryDelay)
+			time.Sleep(settings.RetryDelay.Duration)
 		}
 
 		if err != nil {
 			log.Printf("Erro ao extrair dados da região %s após tentativas: %v", region, err)
+			recordFailure(region, models.ScrapeErrorExtraction, err)
 			continue
 		}
 
@@ -114,20 +138,48 @@ func ScrapeMatchResults() error {
 		matchResults, err := parseHTML(html, region)
 		if err != nil {
 			log.Printf("Erro ao processar HTML da região %s: %v", region, err)
+			recordFailure(region, models.ScrapeErrorParse, err)
 			continue
 		}
 
 		log.Printf("Processados %d resultados da região %s", len(matchResults), region)
 
 		// Salvar os resultados
+		valid := make([]*models.MatchResult, 0, len(matchResults))
 		for _, result := range matchResults {
-			if err := models.CreateMatchResult(result); err != nil {
-				log.Printf("Erro ao salvar resultado %s: %v", result.MatchID, err)
-			} else {
-				log.Printf("Resultado %s salvo com sucesso", result.MatchID)
+			// Partidas que violam os invariantes (ex.: placar empatado) não são salvas
+			if errs := validation.MatchResult(result); errs != nil {
+				log.Printf("Resultado %s ignorado: %v", result.MatchID, errs)
+				continue
 			}
+
+			// A fonte não informa o MVP, então ele é calculado a partir das estatísticas
+			mvp.Assign(result)
+			valid = append(valid, result)
+		}
+
+		// Sem partidas válidas não há o que salvar, o que indica uma mudança na página
+		if len(valid) == 0 {
+			err := fmt.Errorf("nenhuma partida válida entre as %d encontradas na página", len(matchResults))
+			log.Printf("Erro no scraping da região %s: %v", region, err)
+			recordFailure(region, models.ScrapeErrorNoResults, err)
+			continue
+		}
+
+		// Cada execução traz de novo as partidas já salvas, que são atualizadas pelo matchId
+		// apenas se mudaram, não estiverem na lixeira nem tiverem sido corrigidas por um
+		// administrador
+		inserted, updated, err := models.SyncScrapedMatchResults(valid)
+		if err != nil {
+			log.Printf("Erro ao salvar resultados da região %s: %v", region, err)
+			recordFailure(region, models.ScrapeErrorStorage, fmt.Errorf("erro ao salvar as %d partidas extraídas: %w", len(valid), err))
+			continue
 		}
+		log.Printf("Resultados da região %s salvos: %d novos, %d atualizados", region, inserted, updated)
 
+		if err := models.RecordScrapeSuccess(region, inserted+updated, time.Now()); err != nil {
+			log.Printf("Erro ao registrar o scraping da região %s: %v", region, err)
+		}
 		log.Printf("Extração da região %s concluída!", region)
 	}
 
@@ -135,6 +187,34 @@ func ScrapeMatchResults() error {
 	return nil
 }
 
+// recordFailure registra a categoria da falha na situação da região, que é pública no
+// /health, e publica o evento correspondente com o erro completo
+func recordFailure(region, category string, err error) {
+	if err := models.RecordScrapeFailure(region, category, time.Now()); err != nil {
+		log.Printf("Erro ao registrar falha de scraping da região %s: %v", region, err)
+	}
+	publishFailure(region, category, err)
+}
+
+// publishFailure registra no outbox o evento de falha no scraping de uma região
+func publishFailure(region, category string, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	event := &models.OutboxEvent{
+		Type:   events.ScrapeFailed,
+		Region: region,
+		Details: map[string]string{
+			"url":      settings.Sources[region],
+			"category": category,
+			"error":    err.Error(),
+		},
+	}
+	if err := models.AppendOutboxEvent(ctx, event); err != nil {
+		log.Printf("Erro ao registrar falha de scraping da região %s: %v", region, err)
+	}
+}
+
 // extractHTML agora recebe um contexto chromedp existente
 func extractHTML(ctx context.Context, url string) (string, error) {
 	// Variável para armazenar o HTML extraído
@@ -167,13 +247,8 @@ func parseHTML(html, region string) ([]*models.MatchResult, error) {
 	var results []*models.MatchResult
 
 	// Encontrar todos os cards de partida
-	doc.Find(".match-card").Each(func(i int, s *goquery.Selection) {
+	doc.Find(".match-card").Each(func(_ int, s *goquery.Selection) {
 		// Extrair dados básicos da partida
-		matchID, _ := s.Attr("data-match-id")
-		if matchID == "" {
-			matchID = fmt.Sprintf("%s-%d", region, i)
-		}
-
 		dateStr := s.Find(".match-date").Text()
 		teamA := s.Find(".team-a .team-name").Text()
 		teamB := s.Find(".team-b .team-name").Text()
@@ -187,6 +262,13 @@ func parseHTML(html, region string) ([]*models.MatchResult, error) {
 			return
 		}
 
+		// Sem o id da fonte, a partida é identificada pela data e pelos times, que não
+		// dependem da posição do card na página
+		matchID, _ := s.Attr("data-match-id")
+		if matchID == "" {
+			matchID = fallbackMatchID(region, date, teamA, teamB)
+		}
+
 		scoreA, err := strconv.Atoi(strings.TrimSpace(scoreAStr))
 		if err != nil {
 			log.Printf("Erro ao converter score A: %v", err)
@@ -252,6 +334,32 @@ func parseHTML(html, region string) ([]*models.MatchResult, error) {
 }
 
 // parseInt converte string para int com tratamento de erro
+// fallbackMatchID monta um matchId estável a partir da região, da data e dos times,
+// em ordem alfabética, como em sul-2025-04-12-loud-pain-gaming
+func fallbackMatchID(region string, date time.Time, teamA, teamB string) string {
+	teams := []string{slug(teamA), slug(teamB)}
+	sort.Strings(teams)
+	return fmt.Sprintf("%s-%s-%s-%s", region, date.Format("2006-01-02"), teams[0], teams[1])
+}
+
+// slug converte o nome para minúsculas, trocando o que não for letra ou número por hífens
+func slug(name string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			hyphen = false
+			continue
+		}
+		hyphen = true
+	}
+	return b.String()
+}
+
 func parseInt(s string) int {
 	i, _ := strconv.Atoi(strings.TrimSpace(s))
 	return i
@@ -0,0 +1,225 @@
+package simulation
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/bulletdev/lta-results-api/models"
+	"github.com/bulletdev/lta-results-api/prediction"
+)
+
+// Options configura uma execução do simulador
+type Options struct {
+	Runs         int
+	Seed         int64
+	PlayoffSpots int
+}
+
+// Report contém as probabilidades calculadas para cada time
+type Report struct {
+	Runs             int              `json:"runs"`
+	Seed             int64            `json:"seed"`
+	PlayoffSpots     int              `json:"playoffSpots"`
+	RemainingMatches int              `json:"remainingMatches"`
+	Teams            []TeamProjection `json:"teams"`
+}
+
+// TeamProjection representa a projeção de um time ao fim da fase
+type TeamProjection struct {
+	Team                  string    `json:"team"`
+	Wins                  int       `json:"wins"`
+	Losses                int       `json:"losses"`
+	GameDifferential      int       `json:"gameDifferential"`
+	ExpectedWins          float64   `json:"expectedWins"`
+	PositionProbabilities []float64 `json:"positionProbabilities"`
+	PlayoffProbability    float64   `json:"playoffProbability"`
+}
+
+// record acumula a campanha de um time durante uma simulação
+type record struct {
+	team    string
+	wins    int
+	losses  int
+	gameDif int
+	tiebrk  float64
+}
+
+// Run simula o restante da fase a partir das partidas disputadas e do calendário
+func Run(played []models.MatchResult, remaining []models.ScheduledMatch, opts Options) *Report {
+	model := prediction.Build(played)
+	base := standings(played, remaining)
+
+	teams := make([]string, 0, len(base))
+	for team := range base {
+		teams = append(teams, team)
+	}
+	sort.Strings(teams)
+
+	// Probabilidade por jogo de cada confronto restante, calculada uma única vez
+	probabilities := make([]float64, len(remaining))
+	for i, match := range remaining {
+		probabilities[i] = model.GameProbability(match.TeamA, match.TeamB)
+	}
+
+	positions := make(map[string][]int, len(teams))
+	totalWins := make(map[string]int, len(teams))
+	for _, team := range teams {
+		positions[team] = make([]int, len(teams))
+	}
+
+	rng := rand.New(rand.NewSource(opts.Seed))
+	current := make(map[string]*record, len(teams))
+
+	for run := 0; run < opts.Runs; run++ {
+		for _, team := range teams {
+			r := *base[team]
+			r.tiebrk = rng.Float64()
+			current[team] = &r
+		}
+
+		for i, match := range remaining {
+			winsA, winsB := playSeries(rng, probabilities[i], bestOf(match))
+			a, b := current[match.TeamA], current[match.TeamB]
+			if winsA > winsB {
+				a.wins++
+				b.losses++
+			} else {
+				b.wins++
+				a.losses++
+			}
+			a.gameDif += winsA - winsB
+			b.gameDif += winsB - winsA
+		}
+
+		table := make([]*record, 0, len(teams))
+		for _, team := range teams {
+			table = append(table, current[team])
+		}
+		rank(table)
+
+		for pos, r := range table {
+			positions[r.team][pos]++
+			totalWins[r.team] += r.wins
+		}
+	}
+
+	report := &Report{
+		Runs:             opts.Runs,
+		Seed:             opts.Seed,
+		PlayoffSpots:     opts.PlayoffSpots,
+		RemainingMatches: len(remaining),
+	}
+
+	for _, team := range teams {
+		projection := TeamProjection{
+			Team:                  team,
+			Wins:                  base[team].wins,
+			Losses:                base[team].losses,
+			GameDifferential:      base[team].gameDif,
+			PositionProbabilities: make([]float64, len(teams)),
+		}
+
+		if opts.Runs > 0 {
+			runs := float64(opts.Runs)
+			projection.ExpectedWins = round(float64(totalWins[team]) / runs)
+			qualified := 0
+			for pos, count := range positions[team] {
+				projection.PositionProbabilities[pos] = round(float64(count) / runs)
+				if pos < opts.PlayoffSpots {
+					qualified += count
+				}
+			}
+			projection.PlayoffProbability = round(float64(qualified) / runs)
+		}
+
+		report.Teams = append(report.Teams, projection)
+	}
+
+	// Ordenar pela chance de classificação e, em seguida, pela campanha atual
+	sort.SliceStable(report.Teams, func(i, j int) bool {
+		a, b := report.Teams[i], report.Teams[j]
+		if a.PlayoffProbability != b.PlayoffProbability {
+			return a.PlayoffProbability > b.PlayoffProbability
+		}
+		return a.ExpectedWins > b.ExpectedWins
+	})
+
+	return report
+}
+
+// standings calcula a classificação atual a partir das partidas disputadas
+func standings(played []models.MatchResult, remaining []models.ScheduledMatch) map[string]*record {
+	table := make(map[string]*record)
+	get := func(team string) *record {
+		if _, ok := table[team]; !ok {
+			table[team] = &record{team: team}
+		}
+		return table[team]
+	}
+
+	for _, match := range played {
+		winner := prediction.SeriesWinner(match)
+		if winner == "" {
+			continue
+		}
+
+		a, b := get(match.TeamA), get(match.TeamB)
+		if winner == match.TeamA {
+			a.wins++
+			b.losses++
+		} else {
+			b.wins++
+			a.losses++
+		}
+		a.gameDif += match.ScoreA - match.ScoreB
+		b.gameDif += match.ScoreB - match.ScoreA
+	}
+
+	// Times que ainda não jogaram também entram na tabela
+	for _, match := range remaining {
+		get(match.TeamA)
+		get(match.TeamB)
+	}
+
+	return table
+}
+
+// playSeries sorteia os jogos de uma série até um dos times atingir a maioria
+func playSeries(rng *rand.Rand, p float64, bestOf int) (int, int) {
+	needed := bestOf/2 + 1
+	winsA, winsB := 0, 0
+	for winsA < needed && winsB < needed {
+		if rng.Float64() < p {
+			winsA++
+		} else {
+			winsB++
+		}
+	}
+	return winsA, winsB
+}
+
+// rank ordena a tabela por vitórias, saldo de jogos e, por fim, desempate sorteado
+func rank(table []*record) {
+	sort.Slice(table, func(i, j int) bool {
+		a, b := table[i], table[j]
+		if a.wins != b.wins {
+			return a.wins > b.wins
+		}
+		if a.gameDif != b.gameDif {
+			return a.gameDif > b.gameDif
+		}
+		return a.tiebrk > b.tiebrk
+	})
+}
+
+func bestOf(match models.ScheduledMatch) int {
+	if match.BestOf == 3 || match.BestOf == 5 {
+		return match.BestOf
+	}
+	return 1
+}
+
+func round(v float64) float64 {
+	return math.Round(v*10000) / 10000
+}
@@ -0,0 +1,111 @@
+package simulation
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/bulletdev/lta-results-api/models"
+)
+
+var (
+	played = []models.MatchResult{
+		{TeamA: "LOUD", TeamB: "RED", ScoreA: 2, ScoreB: 0, Winner: "LOUD"},
+		{TeamA: "paiN Gaming", TeamB: "Fluxo", ScoreA: 1, ScoreB: 2, Winner: "Fluxo"},
+		{TeamA: "LOUD", TeamB: "Fluxo", ScoreA: 2, ScoreB: 1, Winner: "LOUD"},
+	}
+	remaining = []models.ScheduledMatch{
+		{TeamA: "RED", TeamB: "paiN Gaming", BestOf: 3},
+		{TeamA: "LOUD", TeamB: "paiN Gaming", BestOf: 3},
+		{TeamA: "Fluxo", TeamB: "RED", BestOf: 5},
+		{TeamA: "Vivo Keyd", TeamB: "LOUD"},
+	}
+)
+
+func TestRunIsReproducible(t *testing.T) {
+	opts := Options{Runs: 500, Seed: 42, PlayoffSpots: 2}
+
+	first := Run(played, remaining, opts)
+	if again := Run(played, remaining, opts); !reflect.DeepEqual(first, again) {
+		t.Errorf("a mesma semente gerou relatórios diferentes:\n%+v\n%+v", first, again)
+	}
+
+	opts.Seed = 7
+	if other := Run(played, remaining, opts); reflect.DeepEqual(first.Teams, other.Teams) {
+		t.Error("sementes diferentes geraram as mesmas probabilidades")
+	}
+}
+
+func TestRunProbabilities(t *testing.T) {
+	report := Run(played, remaining, Options{Runs: 1000, Seed: 1, PlayoffSpots: 2})
+
+	if len(report.Teams) != 5 {
+		t.Fatalf("%d times no relatório, esperado 5", len(report.Teams))
+	}
+	if report.RemainingMatches != len(remaining) {
+		t.Errorf("remainingMatches = %d, esperado %d", report.RemainingMatches, len(remaining))
+	}
+
+	// Cada time ocupa uma posição por execução, e cada posição tem um time
+	positions := make([]float64, len(report.Teams))
+	playoffs := 0.0
+	for _, team := range report.Teams {
+		sum := 0.0
+		for pos, p := range team.PositionProbabilities {
+			sum += p
+			positions[pos] += p
+		}
+		if math.Abs(sum-1) > 1e-3 {
+			t.Errorf("probabilidades de posição de %s somam %v", team.Team, sum)
+		}
+		playoffs += team.PlayoffProbability
+	}
+	for pos, sum := range positions {
+		if math.Abs(sum-1) > 1e-3 {
+			t.Errorf("probabilidades da posição %d somam %v", pos+1, sum)
+		}
+	}
+	if math.Abs(playoffs-2) > 1e-3 {
+		t.Errorf("probabilidades de classificação somam %v, esperado 2 vagas", playoffs)
+	}
+
+	// Os times são ordenados pela chance de classificação
+	for i := 1; i < len(report.Teams); i++ {
+		if report.Teams[i].PlayoffProbability > report.Teams[i-1].PlayoffProbability {
+			t.Errorf("%s antes de %s com menor chance de classificação", report.Teams[i-1].Team, report.Teams[i].Team)
+		}
+	}
+}
+
+func TestRunWithoutRemainingMatches(t *testing.T) {
+	report := Run(played, nil, Options{Runs: 100, Seed: 1, PlayoffSpots: 1})
+
+	// Sem partidas restantes a classificação atual é definitiva
+	want := map[string]float64{"LOUD": 1, "Fluxo": 0, "RED": 0, "paiN Gaming": 0}
+	for _, team := range report.Teams {
+		if team.PlayoffProbability != want[team.Team] {
+			t.Errorf("classificação de %s = %v, esperado %v", team.Team, team.PlayoffProbability, want[team.Team])
+		}
+	}
+	if first := report.Teams[0]; first.Team != "LOUD" || first.Wins != 2 || first.Losses != 0 || first.GameDifferential != 3 {
+		t.Errorf("líder = %+v, esperado LOUD com 2-0 e saldo 3", first)
+	}
+}
+
+func TestPlaySeries(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, bestOf := range []int{1, 3, 5} {
+		needed := bestOf/2 + 1
+		for i := 0; i < 100; i++ {
+			a, b := playSeries(rng, 0.5, bestOf)
+			if (a == needed) == (b == needed) || a > needed || b > needed {
+				t.Fatalf("Bo%d terminou %d-%d", bestOf, a, b)
+			}
+		}
+	}
+
+	if a, b := playSeries(rng, 1, 5); a != 3 || b != 0 {
+		t.Errorf("vitória certa terminou %d-%d, esperado 3-0", a, b)
+	}
+}
@@ -0,0 +1,49 @@
+package validation
+
+import (
+	"strings"
+
+	"github.com/bulletdev/lta-results-api/models"
+)
+
+// ScheduledMatch normaliza uma partida do calendário e verifica os campos usados pela
+// simulação, retornando nil quando ela é válida. Sem bestOf, a série é uma MD1.
+func ScheduledMatch(match *models.ScheduledMatch) Errors {
+	match.MatchID = strings.TrimSpace(match.MatchID)
+	match.TeamA = strings.TrimSpace(match.TeamA)
+	match.TeamB = strings.TrimSpace(match.TeamB)
+	match.Region = strings.ToLower(strings.TrimSpace(match.Region))
+	if match.BestOf == 0 {
+		match.BestOf = 1
+	}
+
+	var errs Errors
+	if match.MatchID == "" {
+		errs.add("matchId", "required")
+	}
+	if match.Date.IsZero() {
+		errs.add("date", "required")
+	}
+	if match.Region == "" {
+		errs.add("region", "required")
+	} else if !contains(Regions, match.Region) {
+		errs.add("region", "region_invalid", strings.Join(Regions, ", "))
+	}
+	if match.TeamA == "" {
+		errs.add("teamA", "required")
+	}
+	if match.TeamB == "" {
+		errs.add("teamB", "required")
+	}
+	if match.TeamA != "" && strings.EqualFold(match.TeamA, match.TeamB) {
+		errs.add("teamB", "teams_equal")
+	}
+	if match.BestOf != 1 && match.BestOf != 3 && match.BestOf != 5 {
+		errs.add("bestOf", "best_of_invalid")
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
@@ -0,0 +1,225 @@
+// Package validation verifica os invariantes de uma partida antes de ela ser gravada.
+// É usado pelos endpoints administrativos, pela importação em lote e pelo scraper.
+package validation
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/bulletdev/lta-results-api/i18n"
+	"github.com/bulletdev/lta-results-api/models"
+)
+
+// Regions lista as regiões aceitas
+var Regions = []string{"sul", "norte"}
+
+// Positions lista as posições aceitas para os jogadores
+var Positions = []string{"TOP", "JUNGLE", "MID", "ADC", "SUPPORT"}
+
+// FieldError descreve um problema em um campo. Code identifica a regra violada e
+// Message é a mensagem em pt-BR; Args completam a mensagem em outros idiomas.
+type FieldError struct {
+	Field   string        `json:"field"`
+	Code    string        `json:"code"`
+	Message string        `json:"message"`
+	Args    []interface{} `json:"-"`
+}
+
+// Errors agrupa os problemas encontrados em uma partida
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	return strings.Join(e.Messages(), "; ")
+}
+
+// Messages retorna os problemas no formato "campo: mensagem"
+func (e Errors) Messages() []string {
+	messages := make([]string, len(e))
+	for i, fieldErr := range e {
+		messages[i] = fieldErr.Field + ": " + fieldErr.Message
+	}
+	return messages
+}
+
+// Translate retorna uma cópia dos erros com as mensagens no idioma informado
+func (e Errors) Translate(lang string) Errors {
+	translated := make(Errors, len(e))
+	for i, fieldErr := range e {
+		fieldErr.Message = i18n.Translate(lang, messageKey(fieldErr.Code), fieldErr.Args...)
+		translated[i] = fieldErr
+	}
+	return translated
+}
+
+// NewFieldError cria o erro de um campo com a mensagem da regra violada
+func NewFieldError(field, code string, args ...interface{}) FieldError {
+	return FieldError{
+		Field:   field,
+		Code:    code,
+		Message: i18n.Translate(i18n.Default, messageKey(code), args...),
+		Args:    args,
+	}
+}
+
+func (e *Errors) add(field, code string, args ...interface{}) {
+	*e = append(*e, NewFieldError(field, code, args...))
+}
+
+func messageKey(code string) string {
+	return "validation_" + code
+}
+
+// Normalize remove espaços das extremidades dos campos de texto e padroniza a
+// região em minúsculas e as posições em maiúsculas
+func Normalize(match *models.MatchResult) {
+	match.MatchID = strings.TrimSpace(match.MatchID)
+	match.TeamA = strings.TrimSpace(match.TeamA)
+	match.TeamB = strings.TrimSpace(match.TeamB)
+	match.Winner = strings.TrimSpace(match.Winner)
+	match.Region = strings.ToLower(strings.TrimSpace(match.Region))
+	for i := range match.Players {
+		player := &match.Players[i]
+		player.Name = strings.TrimSpace(player.Name)
+		player.Team = strings.TrimSpace(player.Team)
+		player.Champion = strings.TrimSpace(player.Champion)
+		player.Position = strings.ToUpper(strings.TrimSpace(player.Position))
+	}
+}
+
+// MatchResult normaliza a partida e verifica seus invariantes, retornando nil
+// quando ela é válida
+func MatchResult(match *models.MatchResult) Errors {
+	Normalize(match)
+
+	var errs Errors
+	if match.MatchID == "" {
+		errs.add("matchId", "required")
+	}
+	if match.Date.IsZero() {
+		errs.add("date", "required")
+	}
+	if match.Region == "" {
+		errs.add("region", "required")
+	} else if !contains(Regions, match.Region) {
+		errs.add("region", "region_invalid", strings.Join(Regions, ", "))
+	}
+
+	teamsValid := true
+	if match.TeamA == "" {
+		errs.add("teamA", "required")
+		teamsValid = false
+	}
+	if match.TeamB == "" {
+		errs.add("teamB", "required")
+		teamsValid = false
+	}
+	if teamsValid && strings.EqualFold(match.TeamA, match.TeamB) {
+		errs.add("teamB", "teams_equal")
+		teamsValid = false
+	}
+
+	scoresValid := true
+	if match.ScoreA < 0 {
+		errs.add("scoreA", "negative")
+		scoresValid = false
+	}
+	if match.ScoreB < 0 {
+		errs.add("scoreB", "negative")
+		scoresValid = false
+	}
+	if scoresValid && !validSeries(match.ScoreA, match.ScoreB) {
+		errs.add("scoreA", "score_series")
+		scoresValid = false
+	}
+
+	switch {
+	case match.Winner == "":
+		errs.add("winner", "required")
+	case teamsValid && match.Winner != match.TeamA && match.Winner != match.TeamB:
+		errs.add("winner", "winner_invalid")
+	case teamsValid && scoresValid && match.Winner != leader(match):
+		errs.add("winner", "winner_score_mismatch")
+	}
+
+	validatePlayers(match, teamsValid, &errs)
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func validatePlayers(match *models.MatchResult, teamsValid bool, errs *Errors) {
+	seen := make(map[string]int, len(match.Players))
+	for i, player := range match.Players {
+		field := "players[" + strconv.Itoa(i) + "]"
+
+		if player.Name == "" {
+			errs.add(field+".name", "required")
+		} else {
+			key := strings.ToLower(player.Name)
+			if first, ok := seen[key]; ok {
+				errs.add(field+".name", "player_duplicate", first)
+			} else {
+				seen[key] = i
+			}
+		}
+
+		if player.Team == "" {
+			errs.add(field+".team", "required")
+		} else if teamsValid && player.Team != match.TeamA && player.Team != match.TeamB {
+			errs.add(field+".team", "player_team_invalid")
+		}
+
+		if player.Position == "" {
+			errs.add(field+".position", "required")
+		} else if !contains(Positions, player.Position) {
+			errs.add(field+".position", "position_invalid", strings.Join(Positions, ", "))
+		}
+
+		stats := []struct {
+			name  string
+			value int
+		}{
+			{"kills", player.Kills},
+			{"deaths", player.Deaths},
+			{"assists", player.Assists},
+			{"cs", player.CS},
+			{"gold", player.Gold},
+			{"damageDealt", player.DamageDealt},
+			{"visionScore", player.VisionScore},
+		}
+		for _, stat := range stats {
+			if stat.value < 0 {
+				errs.add(field+"."+stat.name, "negative")
+			}
+		}
+	}
+}
+
+// validSeries indica se o placar encerra uma série MD1, MD3 ou MD5: o vencedor
+// tem exatamente as vitórias necessárias e o perdedor, menos que elas
+func validSeries(scoreA, scoreB int) bool {
+	winner, loser := scoreA, scoreB
+	if loser > winner {
+		winner, loser = loser, winner
+	}
+	return winner >= 1 && winner <= 3 && loser < winner
+}
+
+// leader retorna o time com mais vitórias na série
+func leader(match *models.MatchResult) string {
+	if match.ScoreA > match.ScoreB {
+		return match.TeamA
+	}
+	return match.TeamB
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,177 @@
+package validation
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/i18n"
+	"github.com/bulletdev/lta-results-api/models"
+)
+
+// validMatch monta uma partida válida, que cada caso altera
+func validMatch() *models.MatchResult {
+	return &models.MatchResult{
+		MatchID: "sul-1",
+		Date:    time.Date(2025, 4, 12, 18, 0, 0, 0, time.UTC),
+		Region:  "sul",
+		TeamA:   "LOUD",
+		TeamB:   "paiN Gaming",
+		ScoreA:  2,
+		ScoreB:  1,
+		Winner:  "LOUD",
+		Players: []models.Player{
+			{Name: "Robo", Team: "LOUD", Position: "TOP", Kills: 3},
+			{Name: "Tinowns", Team: "paiN Gaming", Position: "MID", Assists: 5},
+		},
+	}
+}
+
+// fieldCodes reduz os erros a "campo código" para comparação
+func fieldCodes(errs Errors) []string {
+	var codes []string
+	for _, e := range errs {
+		codes = append(codes, e.Field+" "+e.Code)
+	}
+	return codes
+}
+
+func TestMatchResult(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(*models.MatchResult)
+		want   []string
+	}{
+		{name: "válida"},
+		{
+			name: "normaliza espaços, região e posição",
+			change: func(m *models.MatchResult) {
+				m.Region = " SUL "
+				m.TeamA = " LOUD"
+				m.Winner = "LOUD "
+				m.Players[0].Team = "LOUD "
+				m.Players[0].Position = " top"
+			},
+		},
+		{
+			name:   "campos obrigatórios",
+			change: func(m *models.MatchResult) { m.MatchID, m.Date, m.Region, m.Winner = " ", time.Time{}, "", "" },
+			want:   []string{"matchId required", "date required", "region required", "winner required"},
+		},
+		{
+			name:   "região desconhecida",
+			change: func(m *models.MatchResult) { m.Region = "leste" },
+			want:   []string{"region region_invalid"},
+		},
+		{
+			name:   "times iguais",
+			change: func(m *models.MatchResult) { m.TeamB = "loud" },
+			want:   []string{"teamB teams_equal"},
+		},
+		{
+			name:   "placar negativo",
+			change: func(m *models.MatchResult) { m.ScoreB = -1 },
+			want:   []string{"scoreB negative"},
+		},
+		{
+			name:   "placar que não encerra a série",
+			change: func(m *models.MatchResult) { m.ScoreA, m.ScoreB = 2, 2 },
+			want:   []string{"scoreA score_series"},
+		},
+		{
+			name:   "placar acima de uma MD5",
+			change: func(m *models.MatchResult) { m.ScoreA, m.ScoreB = 4, 1 },
+			want:   []string{"scoreA score_series"},
+		},
+		{
+			name:   "vencedor fora da partida",
+			change: func(m *models.MatchResult) { m.Winner = "RED Canids" },
+			want:   []string{"winner winner_invalid"},
+		},
+		{
+			name:   "vencedor diferente do placar",
+			change: func(m *models.MatchResult) { m.Winner = "paiN Gaming" },
+			want:   []string{"winner winner_score_mismatch"},
+		},
+		{
+			name: "jogadores inválidos",
+			change: func(m *models.MatchResult) {
+				m.Players = append(m.Players,
+					models.Player{Name: "robo", Team: "RED Canids", Position: "CARRY", Deaths: -1},
+					models.Player{},
+				)
+			},
+			want: []string{
+				"players[2].name player_duplicate",
+				"players[2].team player_team_invalid",
+				"players[2].position position_invalid",
+				"players[2].deaths negative",
+				"players[3].name required",
+				"players[3].team required",
+				"players[3].position required",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := validMatch()
+			if tt.change != nil {
+				tt.change(match)
+			}
+
+			if got := fieldCodes(MatchResult(match)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("erros = %v, esperado %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchResultNormalizes(t *testing.T) {
+	match := validMatch()
+	match.Region = " Norte "
+	match.Players[1].Position = "mid "
+
+	if errs := MatchResult(match); errs != nil {
+		t.Fatalf("partida válida rejeitada: %v", errs)
+	}
+	if match.Region != "norte" || match.Players[1].Position != "MID" {
+		t.Errorf("partida normalizada com região %q e posição %q", match.Region, match.Players[1].Position)
+	}
+}
+
+func TestScheduledMatch(t *testing.T) {
+	match := &models.ScheduledMatch{
+		MatchID: " sul-10 ",
+		Date:    time.Date(2025, 5, 3, 18, 0, 0, 0, time.UTC),
+		Region:  "SUL",
+		TeamA:   "LOUD",
+		TeamB:   "RED Canids",
+	}
+	if errs := ScheduledMatch(match); errs != nil {
+		t.Fatalf("partida válida rejeitada: %v", errs)
+	}
+	if match.BestOf != 1 || match.MatchID != "sul-10" || match.Region != "sul" {
+		t.Errorf("partida normalizada = %+v", match)
+	}
+
+	invalid := &models.ScheduledMatch{Region: "leste", TeamA: "LOUD", TeamB: "loud", BestOf: 2}
+	want := []string{"matchId required", "date required", "region region_invalid", "teamB teams_equal", "bestOf best_of_invalid"}
+	if got := fieldCodes(ScheduledMatch(invalid)); !reflect.DeepEqual(got, want) {
+		t.Errorf("erros = %v, esperado %v", got, want)
+	}
+}
+
+func TestErrorsTranslate(t *testing.T) {
+	errs := Errors{NewFieldError("teamB", "teams_equal")}
+	if errs.Error() != "teamB: teamA e teamB devem ser diferentes" {
+		t.Errorf("mensagem padrão = %q", errs.Error())
+	}
+
+	translated := errs.Translate(i18n.EN)
+	if translated[0].Message != "teamA and teamB must be different" {
+		t.Errorf("mensagem em inglês = %q", translated[0].Message)
+	}
+	if errs[0].Message == translated[0].Message {
+		t.Error("Translate alterou os erros originais")
+	}
+}
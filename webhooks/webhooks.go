@@ -0,0 +1,213 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/events"
+	"github.com/bulletdev/lta-results-api/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Configurações da fila de entregas
+const (
+	pollInterval   = 5 * time.Second
+	batchSize      = 50
+	maxAttempts    = 8
+	baseBackoff    = 30 * time.Second
+	maxBackoff     = time.Hour
+	requestTimeout = 10 * time.Second
+)
+
+// Headers enviados em cada entrega
+const (
+	HeaderEvent     = "X-LTA-Event"
+	HeaderDelivery  = "X-LTA-Delivery"
+	HeaderTimestamp = "X-LTA-Timestamp"
+	HeaderSignature = "X-LTA-Signature"
+)
+
+var client = &http.Client{Timeout: requestTimeout}
+
+// Start inicia o envio das entregas até ctx ser cancelado. As entregas são criadas por
+// Enqueue, registrado como assinante do outbox.
+func Start(ctx context.Context) {
+	go deliverLoop(ctx)
+	log.Println("Dispatcher de webhooks iniciado")
+}
+
+// Sign calcula a assinatura HMAC-SHA256 de uma entrega no formato "sha256=<hex>".
+// O conteúdo assinado é "<timestamp>.<corpo>", para impedir o reenvio de payloads antigos.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify confere a assinatura recebida por um consumidor de webhooks
+func Verify(secret, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// Backoff retorna a espera antes da próxima tentativa, dobrando a cada falha
+func Backoff(attempts int) time.Duration {
+	delay := baseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return delay
+}
+
+// Enqueue cria uma entrega do evento para cada webhook inscrito. É um assinante do
+// outbox: um erro faz o evento ser processado de novo, e as entregas já criadas não se
+// repetem, pois são identificadas pelo webhook e pelo ID do evento.
+func Enqueue(e events.Event) error {
+	webhooks, err := models.GetWebhooksForEvent(e.Type)
+	if err != nil {
+		return fmt.Errorf("erro ao buscar webhooks para o evento %s: %w", e.Type, err)
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar evento %d: %w", e.ID, err)
+	}
+
+	now := time.Now()
+	for _, webhook := range webhooks {
+		delivery := &models.WebhookDelivery{
+			ID:            primitive.NewObjectID(),
+			WebhookID:     webhook.ID,
+			URL:           webhook.URL,
+			EventID:       e.ID,
+			EventType:     e.Type,
+			Payload:       string(payload),
+			Status:        models.DeliveryPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+		if err := models.CreateWebhookDelivery(delivery); err != nil {
+			return fmt.Errorf("erro ao enfileirar entrega para %s: %w", webhook.URL, err)
+		}
+	}
+	return nil
+}
+
+// deliverLoop envia periodicamente as entregas pendentes
+func deliverLoop(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deliveries, err := models.GetDueWebhookDeliveries(time.Now(), batchSize)
+			if err != nil {
+				log.Printf("Erro ao buscar entregas pendentes: %v", err)
+				continue
+			}
+			for i := range deliveries {
+				if ctx.Err() != nil {
+					return
+				}
+				attempt(ctx, &deliveries[i])
+			}
+		}
+	}
+}
+
+// attempt realiza uma tentativa de entrega e agenda a próxima em caso de falha
+func attempt(ctx context.Context, delivery *models.WebhookDelivery) {
+	webhook, err := models.GetWebhookByID(delivery.WebhookID)
+	if err != nil && err != mongo.ErrNoDocuments {
+		log.Printf("Erro ao buscar webhook da entrega %s: %v", delivery.ID.Hex(), err)
+		return
+	}
+	if err == mongo.ErrNoDocuments || !webhook.Active {
+		delivery.Status = models.DeliveryDead
+		delivery.LastError = "webhook removido ou desativado"
+		delivery.UpdatedAt = time.Now()
+		save(delivery)
+		return
+	}
+
+	delivery.Attempts++
+	status, err := send(ctx, webhook, delivery)
+	now := time.Now()
+	delivery.ResponseStatus = status
+	delivery.UpdatedAt = now
+
+	if err == nil {
+		delivery.Status = models.DeliveryDelivered
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+		save(delivery)
+		return
+	}
+
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= maxAttempts {
+		delivery.Status = models.DeliveryDead
+		log.Printf("Entrega %s para %s movida para a fila de mortas após %d tentativas: %v",
+			delivery.ID.Hex(), delivery.URL, delivery.Attempts, err)
+	} else {
+		delivery.NextAttemptAt = now.Add(Backoff(delivery.Attempts))
+	}
+	save(delivery)
+}
+
+// send envia o payload assinado e retorna o status HTTP recebido
+func send(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "lta-results-api-webhooks")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, delivery.ID.Hex())
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, timestamp, body))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("resposta inesperada: %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+func save(delivery *models.WebhookDelivery) {
+	if err := models.UpdateWebhookDelivery(delivery); err != nil {
+		log.Printf("Erro ao salvar entrega %s: %v", delivery.ID.Hex(), err)
+	}
+}
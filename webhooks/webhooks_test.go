@@ -0,0 +1,229 @@
+package webhooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bulletdev/lta-results-api/config"
+	"github.com/bulletdev/lta-results-api/database"
+	"github.com/bulletdev/lta-results-api/events"
+	"github.com/bulletdev/lta-results-api/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const testSecret = "segredo-de-teste"
+
+// openStore usa o armazenamento embutido em um diretório temporário
+func openStore(t *testing.T) {
+	t.Helper()
+
+	cfg := config.Defaults().Database
+	cfg.Backend = config.BackendEmbedded
+	cfg.Path = filepath.Join(t.TempDir(), "webhooks.db")
+	if err := database.Connect(cfg); err != nil {
+		t.Fatalf("erro ao abrir o armazenamento: %v", err)
+	}
+	t.Cleanup(database.Close)
+}
+
+// receiver sobe um endpoint que confere a assinatura e responde com os status da
+// lista, um por requisição, repetindo o último
+func receiver(t *testing.T, statuses ...int) (*httptest.Server, *int32) {
+	t.Helper()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&calls, 1))
+
+		body, _ := io.ReadAll(r.Body)
+		if !Verify(testSecret, r.Header.Get(HeaderTimestamp), body, r.Header.Get(HeaderSignature)) {
+			t.Errorf("assinatura inválida na requisição %d", n)
+		}
+		if r.Header.Get(HeaderEvent) != events.MatchCreated {
+			t.Errorf("%s = %q, esperado %q", HeaderEvent, r.Header.Get(HeaderEvent), events.MatchCreated)
+		}
+
+		w.WriteHeader(statuses[min(n, len(statuses))-1])
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+// newDelivery cadastra um webhook para a URL e enfileira uma entrega para ele
+func newDelivery(t *testing.T, url string) *models.WebhookDelivery {
+	t.Helper()
+
+	now := time.Now()
+	webhook := &models.Webhook{
+		ID:        primitive.NewObjectID(),
+		URL:       url,
+		Secret:    testSecret,
+		Events:    []string{events.MatchCreated},
+		Active:    true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := models.CreateWebhook(webhook); err != nil {
+		t.Fatalf("erro ao criar webhook: %v", err)
+	}
+
+	if err := Enqueue(events.Event{ID: 7, Type: events.MatchCreated, MatchID: "sul-1", Time: now}); err != nil {
+		t.Fatalf("erro ao enfileirar evento: %v", err)
+	}
+	return loadDelivery(t, webhook.ID)
+}
+
+func loadDelivery(t *testing.T, webhookID primitive.ObjectID) *models.WebhookDelivery {
+	t.Helper()
+
+	deliveries, err := models.GetWebhookDeliveries(bson.M{"webhookId": webhookID}, nil)
+	if err != nil {
+		t.Fatalf("erro ao buscar entregas: %v", err)
+	}
+	if len(deliveries) != 1 {
+		t.Fatalf("%d entregas para o webhook, esperada 1", len(deliveries))
+	}
+	return &deliveries[0]
+}
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"id":1,"type":"match.created"}`)
+	signature := Sign(testSecret, "1700000000", body)
+
+	if signature != Sign(testSecret, "1700000000", body) {
+		t.Fatal("a assinatura deve ser determinística")
+	}
+	if !Verify(testSecret, "1700000000", body, signature) {
+		t.Error("assinatura válida rejeitada")
+	}
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		body      []byte
+	}{
+		{"segredo diferente", "outro-segredo", "1700000000", body},
+		{"timestamp diferente", testSecret, "1700000001", body},
+		{"corpo alterado", testSecret, "1700000000", []byte(`{"id":2,"type":"match.created"}`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Verify(tt.secret, tt.timestamp, tt.body, signature) {
+				t.Error("assinatura aceita com dados diferentes dos assinados")
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{7, 32 * time.Minute},
+		{8, time.Hour},
+		{20, time.Hour},
+	}
+	for _, tt := range tests {
+		if got := Backoff(tt.attempts); got != tt.want {
+			t.Errorf("Backoff(%d) = %v, esperado %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestEnqueueIsIdempotent(t *testing.T) {
+	openStore(t)
+	server, _ := receiver(t, http.StatusOK)
+	delivery := newDelivery(t, server.URL)
+
+	// Reprocessar o evento, como o dispatcher faz após uma falha, não cria outra entrega
+	if err := Enqueue(events.Event{ID: 7, Type: events.MatchCreated, MatchID: "sul-1"}); err != nil {
+		t.Fatalf("erro ao enfileirar evento: %v", err)
+	}
+	if again := loadDelivery(t, delivery.WebhookID); again.ID != delivery.ID {
+		t.Errorf("entrega substituída: %s, esperada %s", again.ID.Hex(), delivery.ID.Hex())
+	}
+	if delivery.EventID != 7 || delivery.Status != models.DeliveryPending {
+		t.Errorf("entrega = evento %d, %s; esperado evento 7, %s", delivery.EventID, delivery.Status, models.DeliveryPending)
+	}
+}
+
+func TestAttemptRetriesUntilSuccess(t *testing.T) {
+	openStore(t)
+	server, calls := receiver(t, http.StatusInternalServerError, http.StatusServiceUnavailable, http.StatusNoContent)
+	delivery := newDelivery(t, server.URL)
+
+	for i := 1; i <= 2; i++ {
+		before := time.Now()
+		attempt(context.Background(), delivery)
+		delivery = loadDelivery(t, delivery.WebhookID)
+
+		if delivery.Status != models.DeliveryPending {
+			t.Fatalf("tentativa %d: status %s, esperado %s", i, delivery.Status, models.DeliveryPending)
+		}
+		if delivery.Attempts != i || delivery.LastError == "" {
+			t.Errorf("tentativa %d: attempts = %d, lastError = %q", i, delivery.Attempts, delivery.LastError)
+		}
+		if wait := delivery.NextAttemptAt.Sub(before); wait < Backoff(i)-time.Second {
+			t.Errorf("tentativa %d: próxima tentativa em %v, esperado ao menos %v", i, wait, Backoff(i))
+		}
+	}
+
+	attempt(context.Background(), delivery)
+	delivery = loadDelivery(t, delivery.WebhookID)
+	if delivery.Status != models.DeliveryDelivered || delivery.DeliveredAt == nil {
+		t.Fatalf("status %s após a resposta 2xx, esperado %s", delivery.Status, models.DeliveryDelivered)
+	}
+	if delivery.Attempts != 3 || delivery.ResponseStatus != http.StatusNoContent || delivery.LastError != "" {
+		t.Errorf("entrega = %d tentativas, status %d, erro %q", delivery.Attempts, delivery.ResponseStatus, delivery.LastError)
+	}
+	if n := atomic.LoadInt32(calls); n != 3 {
+		t.Errorf("%d requisições ao receptor, esperadas 3", n)
+	}
+}
+
+func TestAttemptDeadLettersAfterMaxAttempts(t *testing.T) {
+	openStore(t)
+	server, calls := receiver(t, http.StatusInternalServerError)
+	delivery := newDelivery(t, server.URL)
+
+	for i := 1; i <= maxAttempts; i++ {
+		attempt(context.Background(), delivery)
+		delivery = loadDelivery(t, delivery.WebhookID)
+
+		want := models.DeliveryPending
+		if i == maxAttempts {
+			want = models.DeliveryDead
+		}
+		if delivery.Status != want {
+			t.Fatalf("tentativa %d: status %s, esperado %s", i, delivery.Status, want)
+		}
+	}
+
+	if delivery.Attempts != maxAttempts || delivery.ResponseStatus != http.StatusInternalServerError {
+		t.Errorf("entrega = %d tentativas, status %d", delivery.Attempts, delivery.ResponseStatus)
+	}
+	if n := atomic.LoadInt32(calls); n != maxAttempts {
+		t.Errorf("%d requisições ao receptor, esperadas %d", n, maxAttempts)
+	}
+
+	// Entregas mortas saem da fila de envio até serem reenfileiradas
+	due, err := models.GetDueWebhookDeliveries(time.Now().Add(24*time.Hour), batchSize)
+	if err != nil {
+		t.Fatalf("erro ao buscar entregas pendentes: %v", err)
+	}
+	if len(due) != 0 {
+		t.Errorf("%d entregas pendentes após a fila de mortas, esperado 0", len(due))
+	}
+}